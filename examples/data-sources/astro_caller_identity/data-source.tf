@@ -0,0 +1,6 @@
+data "astro_caller_identity" "example_caller_identity" {}
+
+# Output the API token the provider is authenticated with using terraform apply
+output "caller_identity" {
+  value = data.astro_caller_identity.example_caller_identity
+}
@@ -0,0 +1,39 @@
+variable "staging_api_key" {
+  type      = string
+  sensitive = true
+}
+
+data "astro_deployment_template" "production" {
+  id = "clozc036j01to01jrlgvueo8t"
+}
+
+# Create a staging copy of the production deployment, attributes set explicitly override the template
+resource "astro_deployment" "staging" {
+  name                           = "staging"
+  description                    = "Staging copy of ${data.astro_deployment_template.production.id}"
+  workspace_id                   = data.astro_deployment_template.production.workspace_id
+  type                           = data.astro_deployment_template.production.type
+  cloud_provider                 = data.astro_deployment_template.production.cloud_provider
+  region                         = data.astro_deployment_template.production.region
+  original_astro_runtime_version = data.astro_deployment_template.production.astro_runtime_version
+  contact_emails                 = data.astro_deployment_template.production.contact_emails
+  executor                       = data.astro_deployment_template.production.executor
+  is_cicd_enforced               = data.astro_deployment_template.production.is_cicd_enforced
+  is_dag_deploy_enabled          = data.astro_deployment_template.production.is_dag_deploy_enabled
+  is_development_mode            = true
+  is_high_availability           = false
+  scheduler_size                 = data.astro_deployment_template.production.scheduler_size
+  resource_quota_cpu             = data.astro_deployment_template.production.resource_quota_cpu
+  resource_quota_memory          = data.astro_deployment_template.production.resource_quota_memory
+  default_task_pod_cpu           = data.astro_deployment_template.production.default_task_pod_cpu
+  default_task_pod_memory        = data.astro_deployment_template.production.default_task_pod_memory
+  worker_queues                  = data.astro_deployment_template.production.worker_queues
+  scaling_spec                   = data.astro_deployment_template.production.scaling_spec
+  environment_variables_map = merge(data.astro_deployment_template.production.environment_variables_map, {
+    ENVIRONMENT = "staging"
+  })
+  # Secret values are not returned by the API, they must be set for each of the secret_environment_variable_keys
+  secret_environment_variables = {
+    API_KEY = var.staging_api_key
+  }
+}
@@ -82,7 +82,7 @@ resource "astro_api_token" "deployment_token_with_custom_role" {
 
 # Import an existing api token
 import {
-  id = "clxm46ged05b301neuucdqwox" // ID of the existing api token
+  id = "clxm46ged05b301neuucdqwox" // ID of the existing api token, or "api_token:<name>" to import it by name
   to = astro_api_token.imported_api_token
 }
 resource "astro_api_token" "imported_api_token" {
@@ -33,9 +33,21 @@ resource "astro_cluster" "gcp_example" {
   workspace_ids         = []
 }
 
+// An ephemeral test cluster whose deployments named with the -ephemeral suffix are deleted when it is destroyed
+resource "astro_cluster" "ephemeral_example" {
+  type                                  = "DEDICATED"
+  name                                  = "an ephemeral test cluster"
+  region                                = "us-east-1"
+  cloud_provider                        = "AWS"
+  vpc_subnet_range                      = "172.20.0.0/20"
+  workspace_ids                         = []
+  force_destroy                         = true
+  force_destroy_deployment_name_pattern = "-ephemeral$"
+}
+
 // Import an existing cluster
 import {
-  id = "clozc036j01to01jrlgvuf98d" // ID of the existing cluster
+  id = "clozc036j01to01jrlgvuf98d" // ID of the existing cluster, or "cluster:<name>" to import it by name
   to = astro_cluster.imported_cluster
 }
 resource "astro_cluster" "imported_cluster" {
@@ -67,16 +67,17 @@ resource "astro_deployment" "hybrid" {
   scheduler_replicas             = 1
   scheduler_au                   = 5
   workspace_id                   = "clnp86ly5000401ndaga20g81"
-  environment_variables = [{
-    key       = "key1"
-    value     = "value1"
-    is_secret = false
-  }]
+  environment_variables_map = {
+    key1 = "value1"
+  }
+  secret_environment_variables = {
+    secret1 = "secret-value1"
+  }
 }
 
 // Import an existing deployment
 import {
-  id = "clv17vgft000801kkydsws63x" // ID of the existing deployment
+  id = "clv17vgft000801kkydsws63x" // ID of the existing deployment, or "<workspace name>/<deployment name>" to import it by name
   to = astro_deployment.imported_deployment
 }
 resource "astro_deployment" "imported_deployment" {
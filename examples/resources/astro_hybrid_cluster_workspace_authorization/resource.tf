@@ -5,7 +5,7 @@ resource "astro_hybrid_cluster_workspace_authorization" "example" {
 
 // Import existing hybrid cluster workspace authorization
 import {
-  id = "clk8h0fv1006801j8yysfybbt" // ID of the existing hybrid cluster
+  id = "clk8h0fv1006801j8yysfybbt" // ID of the existing hybrid cluster, or "cluster:<name>" to import it by name
   to = astro_hybrid_cluster_workspace_authorization.imported_cluster_workspace_authorization
 }
 resource "astro_hybrid_cluster_workspace_authorization" "imported_cluster_workspace_authorization" {
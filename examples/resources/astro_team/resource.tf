@@ -20,7 +20,7 @@ resource "astro_team" "team_with_no_optional_fields" {
 
 # Import an existing team
 import {
-  id = "clx486hno068301il306nuhsm" # ID of the existing team
+  id = "clx486hno068301il306nuhsm" # ID of the existing team, or "team:<name>" to import it by name
   to = astro_team.imported_team
 }
 resource "astro_team" "imported_team" {
@@ -52,7 +52,7 @@ resource "astro_team_roles" "all_roles" {
 
 // Import existing team roles
 import {
-  id = "clnp86ly5000401ndaga21g81" // ID of the existing team
+  id = "clnp86ly5000401ndaga21g81" // ID of the existing team, or "team:<name>" to import it by name
   to = astro_team_roles.imported_team_roles
 }
 resource "astro_team_roles" "imported_team_roles" {
@@ -1,4 +1,14 @@
 resource "astro_user_invite" "user_invite" {
   email = "email@organization.com"
   role  = "ORGANIZATION_MEMBER"
-}
\ No newline at end of file
+}
+
+// Import an existing pending user invite
+import {
+  id = "clzaftcaz006001lhkey6qzzg/clzafte7z006001lhkey6qzzh" // <ID of the invited user>/<ID of the invite>, the user can also be given as "user:<email>"
+  to = astro_user_invite.imported_user_invite
+}
+resource "astro_user_invite" "imported_user_invite" {
+  email = "email@organization.com"
+  role  = "ORGANIZATION_MEMBER"
+}
@@ -48,7 +48,7 @@ resource "astro_user_roles" "all_roles" {
 
 # Import an existing user roles
 import {
-  id = "clzaftcaz006001lhkey6qzzg" # ID of the existing user
+  id = "clzaftcaz006001lhkey6qzzg" # ID of the existing user, or "user:<email>" to import it by email
   to = astro_user_roles.imported_user_roles
 }
 resource "astro_user_roles" "imported_user_roles" {
@@ -4,9 +4,17 @@ resource "astro_workspace" "example" {
   cicd_enforced_default = true
 }
 
+// A sandbox workspace whose deployments and workspace API tokens are deleted when it is destroyed
+resource "astro_workspace" "sandbox" {
+  name                  = "sandbox"
+  description           = "a workspace for experiments"
+  cicd_enforced_default = false
+  force_destroy         = true
+}
+
 // Import an existing workspace
 import {
-  id = "clozc036j01to01jrlgvu798d" // ID of the existing workspace
+  id = "clozc036j01to01jrlgvu798d" // ID of the existing workspace, or "workspace:<name>" to import it by name
   to = astro_workspace.imported_workspace
 }
 resource "astro_workspace" "imported_workspace" {
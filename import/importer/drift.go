@@ -0,0 +1,503 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+)
+
+// DriftReportVersion is the version of the JSON drift report schema
+const DriftReportVersion = 1
+
+// resourceTypes maps each accepted resource to its Terraform resource type
+var resourceTypes = map[string]string{
+	"workspace":                              "astro_workspace",
+	"deployment":                             "astro_deployment",
+	"cluster":                                "astro_cluster",
+	"hybrid_cluster_workspace_authorization": "astro_hybrid_cluster_workspace_authorization",
+	"api_token":                              "astro_api_token",
+	"team":                                   "astro_team",
+	"team_roles":                             "astro_team_roles",
+	"user_roles":                             "astro_user_roles",
+}
+
+// LiveObject is an object of the organization, its attributes are named after the Terraform resource attributes
+type LiveObject struct {
+	ResourceType string            `json:"resource_type"`
+	Id           string            `json:"id"`
+	Name         string            `json:"name,omitempty"`
+	Attributes   map[string]string `json:"-"`
+}
+
+// AttributeDifference is an attribute whose value in the state differs from its live value
+type AttributeDifference struct {
+	Attribute string `json:"attribute"`
+	State     string `json:"state"`
+	Live      string `json:"live"`
+}
+
+// DriftedObject is a managed object whose live attributes differ from the state
+type DriftedObject struct {
+	StateObject
+	Differences []AttributeDifference `json:"differences"`
+}
+
+// DriftSummary counts the objects of each drift category
+type DriftSummary struct {
+	Managed   int `json:"managed"`
+	Unmanaged int `json:"unmanaged"`
+	Deleted   int `json:"deleted"`
+	Drifted   int `json:"drifted"`
+}
+
+// DriftReport compares the live objects of an organization with the Terraform state
+type DriftReport struct {
+	Version        int          `json:"version"`
+	OrganizationId string       `json:"organization_id"`
+	GeneratedAt    time.Time    `json:"generated_at"`
+	StateFiles     []string     `json:"state_files"`
+	Summary        DriftSummary `json:"summary"`
+	// Unmanaged holds the live objects that are not in any state
+	Unmanaged []LiveObject `json:"unmanaged"`
+	// Deleted holds the state objects that no longer exist, they were deleted outside Terraform
+	Deleted []StateObject `json:"deleted"`
+	// Drifted holds the state objects whose attributes were changed outside Terraform
+	Drifted []DriftedObject `json:"drifted"`
+}
+
+// HasDrift returns true if the report found unmanaged, deleted or drifted objects
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Unmanaged) > 0 || len(r.Deleted) > 0 || len(r.Drifted) > 0
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *DriftReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteText writes the report in a human readable format
+func (r *DriftReport) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Drift report for organization %s, generated at %s\n", r.OrganizationId, r.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "State files: %s\n", strings.Join(r.StateFiles, ", "))
+	fmt.Fprintf(&b, "Managed: %d, Unmanaged: %d, Deleted outside Terraform: %d, Drifted: %d\n", r.Summary.Managed, r.Summary.Unmanaged, r.Summary.Deleted, r.Summary.Drifted)
+
+	if len(r.Unmanaged) > 0 {
+		b.WriteString("\nUnmanaged objects:\n")
+		for _, object := range r.Unmanaged {
+			fmt.Fprintf(&b, "  %s %s", object.ResourceType, object.Id)
+			if object.Name != "" {
+				fmt.Fprintf(&b, " (%s)", object.Name)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(r.Deleted) > 0 {
+		b.WriteString("\nObjects deleted outside Terraform:\n")
+		for _, object := range r.Deleted {
+			fmt.Fprintf(&b, "  %s %s in %s\n", object.Address, object.Id, object.StateFile)
+		}
+	}
+
+	if len(r.Drifted) > 0 {
+		b.WriteString("\nDrifted objects:\n")
+		for _, object := range r.Drifted {
+			fmt.Fprintf(&b, "  %s %s in %s\n", object.Address, object.Id, object.StateFile)
+			for _, difference := range object.Differences {
+				fmt.Fprintf(&b, "    %s: %q => %q\n", difference.Attribute, difference.State, difference.Live)
+			}
+		}
+	}
+
+	if !r.HasDrift() {
+		b.WriteString("\nNo drift found\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Drift lists the live objects of the requested resources and compares them with the given state files, which are
+// either terraform.tfstate files or `terraform show -json` outputs. If no state file is given, the local state of
+// OutputDir is used, or the output of `terraform show -json` if there is no local state file.
+func (i *Importer) Drift(ctx context.Context, stateFiles []string) (*DriftReport, error) {
+	var stateObjects []StateObject
+	if len(stateFiles) == 0 {
+		localStateFile := filepath.Join(i.opts.OutputDir, StateFileName)
+		if _, err := os.Stat(localStateFile); err == nil {
+			stateFiles = []string{localStateFile}
+		} else {
+			i.logger.Printf("%s does not exist, reading the state with terraform show -json", localStateFile)
+			cmd := exec.Command("terraform", "show", "-json")
+			cmd.Dir = i.opts.OutputDir
+			output, err := cmd.Output()
+			if err != nil {
+				return nil, fmt.Errorf("failed to run terraform show: %v", err)
+			}
+			stateObjects, err = ParseState(output)
+			if err != nil {
+				return nil, err
+			}
+			stateFiles = []string{"terraform show -json"}
+			for idx := range stateObjects {
+				stateObjects[idx].StateFile = stateFiles[0]
+			}
+		}
+	}
+	if stateObjects == nil {
+		for _, stateFile := range stateFiles {
+			objects, err := LoadStateFile(stateFile)
+			if err != nil {
+				return nil, err
+			}
+			i.logger.Printf("Found %d astro resources in %s", len(objects), stateFile)
+			stateObjects = append(stateObjects, objects...)
+		}
+	}
+
+	types := lo.Map(i.resources, func(resource string, _ int) string {
+		return resourceTypes[resource]
+	})
+	liveObjects, err := ListLiveObjects(ctx, i.platformClient, i.iamClient, i.opts.OrganizationId, types)
+	if err != nil {
+		return nil, err
+	}
+	i.logger.Printf("Found %d live objects in organization %s", len(liveObjects), i.opts.OrganizationId)
+
+	report := CompareState(liveObjects, stateObjects, types)
+	report.OrganizationId = i.opts.OrganizationId
+	report.StateFiles = stateFiles
+	return report, nil
+}
+
+// CompareState compares the live objects with the state objects of the given resource types
+func CompareState(liveObjects []LiveObject, stateObjects []StateObject, types []string) *DriftReport {
+	report := &DriftReport{
+		Version:     DriftReportVersion,
+		GeneratedAt: time.Now().UTC(),
+		Unmanaged:   []LiveObject{},
+		Deleted:     []StateObject{},
+		Drifted:     []DriftedObject{},
+	}
+
+	liveByKey := lo.KeyBy(liveObjects, func(object LiveObject) string {
+		return managedIdKey(object.ResourceType, object.Id)
+	})
+	managed := map[string]bool{}
+
+	for _, stateObject := range stateObjects {
+		if !lo.Contains(types, stateObject.ResourceType) || stateObject.Id == "" {
+			continue
+		}
+		key := managedIdKey(stateObject.ResourceType, stateObject.Id)
+		liveObject, ok := liveByKey[key]
+		if !ok {
+			report.Deleted = append(report.Deleted, stateObject)
+			continue
+		}
+		managed[key] = true
+
+		var differences []AttributeDifference
+		for _, attribute := range lo.Keys(liveObject.Attributes) {
+			stateValue, ok := stateObject.Attributes[attribute]
+			if !ok {
+				continue
+			}
+			if canonicalStateValue(stateValue) != liveObject.Attributes[attribute] {
+				differences = append(differences, AttributeDifference{
+					Attribute: attribute,
+					State:     canonicalStateValue(stateValue),
+					Live:      liveObject.Attributes[attribute],
+				})
+			}
+		}
+		if len(differences) > 0 {
+			sort.Slice(differences, func(a, b int) bool {
+				return differences[a].Attribute < differences[b].Attribute
+			})
+			report.Drifted = append(report.Drifted, DriftedObject{StateObject: stateObject, Differences: differences})
+		}
+	}
+
+	for _, liveObject := range liveObjects {
+		if !managed[managedIdKey(liveObject.ResourceType, liveObject.Id)] {
+			report.Unmanaged = append(report.Unmanaged, liveObject)
+		}
+	}
+
+	sort.SliceStable(report.Unmanaged, func(a, b int) bool {
+		return managedIdKey(report.Unmanaged[a].ResourceType, report.Unmanaged[a].Id) < managedIdKey(report.Unmanaged[b].ResourceType, report.Unmanaged[b].Id)
+	})
+	sort.SliceStable(report.Deleted, func(a, b int) bool {
+		return report.Deleted[a].Address < report.Deleted[b].Address
+	})
+	sort.SliceStable(report.Drifted, func(a, b int) bool {
+		return report.Drifted[a].Address < report.Drifted[b].Address
+	})
+
+	report.Summary = DriftSummary{
+		Managed:   len(managed),
+		Unmanaged: len(report.Unmanaged),
+		Deleted:   len(report.Deleted),
+		Drifted:   len(report.Drifted),
+	}
+
+	return report
+}
+
+// ListLiveObjects lists the objects of the given resource types in the organization
+func ListLiveObjects(ctx context.Context, platformClient platform.ClientWithResponsesInterface, iamClient iam.ClientWithResponsesInterface, organizationId string, types []string) ([]LiveObject, error) {
+	var objects []LiveObject
+
+	if lo.Contains(types, "astro_workspace") {
+		workspaces, err := ListWorkspaces(ctx, platformClient, organizationId)
+		if err != nil {
+			return nil, err
+		}
+		for _, workspace := range workspaces {
+			objects = append(objects, LiveObject{
+				ResourceType: "astro_workspace",
+				Id:           workspace.Id,
+				Name:         workspace.Name,
+				Attributes: map[string]string{
+					"name":                  workspace.Name,
+					"description":           stringValue(workspace.Description),
+					"cicd_enforced_default": strconv.FormatBool(workspace.CicdEnforcedDefault),
+				},
+			})
+		}
+	}
+
+	if lo.Contains(types, "astro_deployment") {
+		deployments, err := ListDeployments(ctx, platformClient, organizationId)
+		if err != nil {
+			return nil, err
+		}
+		for _, deployment := range deployments {
+			objects = append(objects, LiveObject{
+				ResourceType: "astro_deployment",
+				Id:           deployment.Id,
+				Name:         deployment.Name,
+				Attributes: map[string]string{
+					"name":                    deployment.Name,
+					"description":             stringValue(deployment.Description),
+					"workspace_id":            deployment.WorkspaceId,
+					"cluster_id":              stringValue(deployment.ClusterId),
+					"type":                    stringValue((*string)(deployment.Type)),
+					"cloud_provider":          stringValue((*string)(deployment.CloudProvider)),
+					"region":                  stringValue(deployment.Region),
+					"executor":                stringValue((*string)(deployment.Executor)),
+					"scheduler_size":          stringValue((*string)(deployment.SchedulerSize)),
+					"is_cicd_enforced":        strconv.FormatBool(deployment.IsCicdEnforced),
+					"is_dag_deploy_enabled":   strconv.FormatBool(deployment.IsDagDeployEnabled),
+					"is_development_mode":     canonicalBool(deployment.IsDevelopmentMode),
+					"is_high_availability":    canonicalBool(deployment.IsHighAvailability),
+					"resource_quota_cpu":      stringValue(deployment.ResourceQuotaCpu),
+					"resource_quota_memory":   stringValue(deployment.ResourceQuotaMemory),
+					"default_task_pod_cpu":    stringValue(deployment.DefaultTaskPodCpu),
+					"default_task_pod_memory": stringValue(deployment.DefaultTaskPodMemory),
+					"contact_emails":          canonicalList(lo.FromPtr(deployment.ContactEmails)),
+				},
+			})
+		}
+	}
+
+	if lo.Contains(types, "astro_cluster") || lo.Contains(types, "astro_hybrid_cluster_workspace_authorization") {
+		clusters, err := ListClusters(ctx, platformClient, organizationId)
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range clusters {
+			workspaceIds := canonicalList(lo.FromPtr(cluster.WorkspaceIds))
+			if cluster.Type != platform.ClusterTypeHYBRID && lo.Contains(types, "astro_cluster") {
+				objects = append(objects, LiveObject{
+					ResourceType: "astro_cluster",
+					Id:           cluster.Id,
+					Name:         cluster.Name,
+					Attributes: map[string]string{
+						"name":                  cluster.Name,
+						"type":                  string(cluster.Type),
+						"cloud_provider":        string(cluster.CloudProvider),
+						"region":                cluster.Region,
+						"vpc_subnet_range":      cluster.VpcSubnetRange,
+						"pod_subnet_range":      stringValue(cluster.PodSubnetRange),
+						"service_subnet_range":  stringValue(cluster.ServiceSubnetRange),
+						"service_peering_range": stringValue(cluster.ServicePeeringRange),
+						"workspace_ids":         workspaceIds,
+					},
+				})
+			}
+			// a hybrid cluster without authorized workspaces has no authorization to manage
+			if cluster.Type == platform.ClusterTypeHYBRID && workspaceIds != "" && lo.Contains(types, "astro_hybrid_cluster_workspace_authorization") {
+				objects = append(objects, LiveObject{
+					ResourceType: "astro_hybrid_cluster_workspace_authorization",
+					Id:           cluster.Id,
+					Name:         cluster.Name,
+					Attributes: map[string]string{
+						"cluster_id":    cluster.Id,
+						"workspace_ids": workspaceIds,
+					},
+				})
+			}
+		}
+	}
+
+	if lo.Contains(types, "astro_api_token") {
+		apiTokens, err := ListApiTokens(ctx, iamClient, organizationId)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiToken := range apiTokens {
+			objects = append(objects, LiveObject{
+				ResourceType: "astro_api_token",
+				Id:           apiToken.Id,
+				Name:         apiToken.Name,
+				Attributes: map[string]string{
+					"name":        apiToken.Name,
+					"description": apiToken.Description,
+					"type":        string(apiToken.Type),
+					"roles": canonicalList(lo.Map(lo.FromPtr(apiToken.Roles), func(role iam.ApiTokenRole, _ int) string {
+						return roleBinding(role.Role, role.EntityId, string(role.EntityType))
+					})),
+				},
+			})
+		}
+	}
+
+	if lo.Contains(types, "astro_team") || lo.Contains(types, "astro_team_roles") {
+		teams, err := ListTeams(ctx, iamClient, organizationId)
+		if err != nil {
+			return nil, err
+		}
+		for _, team := range teams {
+			roles := map[string]string{
+				"organization_role": string(team.OrganizationRole),
+				"workspace_roles":   workspaceRoleBindings(team.WorkspaceRoles),
+				"deployment_roles":  deploymentRoleBindings(team.DeploymentRoles),
+			}
+			if lo.Contains(types, "astro_team") {
+				objects = append(objects, LiveObject{
+					ResourceType: "astro_team",
+					Id:           team.Id,
+					Name:         team.Name,
+					Attributes: lo.Assign(roles, map[string]string{
+						"name":        team.Name,
+						"description": stringValue(team.Description),
+					}),
+				})
+			}
+			if lo.Contains(types, "astro_team_roles") {
+				objects = append(objects, LiveObject{
+					ResourceType: "astro_team_roles",
+					Id:           team.Id,
+					Name:         team.Name,
+					Attributes:   lo.Assign(roles, map[string]string{"team_id": team.Id}),
+				})
+			}
+		}
+	}
+
+	if lo.Contains(types, "astro_user_roles") {
+		users, err := ListUsers(ctx, iamClient, organizationId)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			objects = append(objects, LiveObject{
+				ResourceType: "astro_user_roles",
+				Id:           user.Id,
+				Name:         user.Username,
+				Attributes: map[string]string{
+					"user_id":           user.Id,
+					"organization_role": stringValue((*string)(user.OrganizationRole)),
+					"workspace_roles":   workspaceRoleBindings(user.WorkspaceRoles),
+					"deployment_roles":  deploymentRoleBindings(user.DeploymentRoles),
+				},
+			})
+		}
+	}
+
+	return objects, nil
+}
+
+func workspaceRoleBindings(roles *[]iam.WorkspaceRole) string {
+	return canonicalList(lo.Map(lo.FromPtr(roles), func(role iam.WorkspaceRole, _ int) string {
+		return roleBinding(string(role.Role), role.WorkspaceId)
+	}))
+}
+
+func deploymentRoleBindings(roles *[]iam.DeploymentRole) string {
+	return canonicalList(lo.Map(lo.FromPtr(roles), func(role iam.DeploymentRole, _ int) string {
+		return roleBinding(role.Role, role.DeploymentId)
+	}))
+}
+
+// roleBinding returns the canonical form of a role binding, the entity IDs are given in the alphabetical order of
+// their attribute names in the state
+func roleBinding(role string, ids ...string) string {
+	return strings.Join(ids, ":") + "=" + role
+}
+
+// canonicalList sorts the values of a set so they can be compared regardless of order
+func canonicalList(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// canonicalBool converts an optional boolean to its canonical string form, an absent value is empty like a null value
+// of the state
+func canonicalBool(value *bool) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatBool(*value)
+}
+
+// canonicalStateValue converts a state attribute value to the canonical string form of the live attributes
+func canonicalStateValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		return canonicalList(lo.Map(v, func(element interface{}, _ int) string {
+			return canonicalStateValue(element)
+		}))
+	case map[string]interface{}:
+		keys := lo.Keys(v)
+		sort.Strings(keys)
+		if role, ok := v["role"]; ok {
+			ids := lo.FilterMap(keys, func(key string, _ int) (string, bool) {
+				return canonicalStateValue(v[key]), key != "role"
+			})
+			return roleBinding(canonicalStateValue(role), ids...)
+		}
+		return strings.Join(lo.Map(keys, func(key string, _ int) string {
+			return key + "=" + canonicalStateValue(v[key])
+		}), ";")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
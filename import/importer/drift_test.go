@@ -0,0 +1,214 @@
+package importer_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/lucsky/cuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/astronomer/terraform-provider-astro/import/importer"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	mocks_iam "github.com/astronomer/terraform-provider-astro/internal/mocks/iam"
+	mocks_platform "github.com/astronomer/terraform-provider-astro/internal/mocks/platform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drift", func() {
+	types := []string{"astro_workspace", "astro_team_roles", "astro_user_roles"}
+
+	Describe("ParseState", func() {
+		It("should parse a terraform.tfstate file", func() {
+			objects, err := importer.ParseState([]byte(`{
+  "version": 4,
+  "resources": [
+    {"mode": "managed", "type": "astro_workspace", "name": "ws", "instances": [{"index_key": "prod", "attributes": {"id": "ws1", "name": "prod"}}]},
+    {"module": "module.astro", "mode": "managed", "type": "astro_team_roles", "name": "team", "instances": [{"attributes": {"team_id": "t1"}}]},
+    {"mode": "managed", "type": "aws_s3_bucket", "name": "bucket", "instances": [{"attributes": {"id": "bucket"}}]}
+  ]
+}`))
+
+			Expect(err).To(BeNil())
+			Expect(objects).To(HaveLen(2))
+			Expect(objects[0].Address).To(Equal(`astro_workspace.ws["prod"]`))
+			Expect(objects[0].Id).To(Equal("ws1"))
+			Expect(objects[1].Address).To(Equal("module.astro.astro_team_roles.team"))
+			Expect(objects[1].Id).To(Equal("t1"))
+		})
+
+		It("should parse terraform show -json output", func() {
+			objects, err := importer.ParseState([]byte(`{
+  "format_version": "1.0",
+  "values": {
+    "root_module": {
+      "resources": [{"address": "astro_workspace.ws", "mode": "managed", "type": "astro_workspace", "name": "ws", "values": {"id": "ws1"}}],
+      "child_modules": [{
+        "resources": [{"address": "module.users.astro_user_roles.admin", "mode": "managed", "type": "astro_user_roles", "name": "admin", "values": {"user_id": "u1"}}]
+      }]
+    }
+  }
+}`))
+
+			Expect(err).To(BeNil())
+			Expect(lo.Map(objects, func(object importer.StateObject, _ int) string {
+				return object.Address + "=" + object.Id
+			})).To(Equal([]string{"astro_workspace.ws=ws1", "module.users.astro_user_roles.admin=u1"}))
+		})
+	})
+
+	Describe("CompareState", func() {
+		It("should report unmanaged, deleted and drifted objects", func() {
+			liveObjects := []importer.LiveObject{
+				{ResourceType: "astro_workspace", Id: "ws1", Name: "prod", Attributes: map[string]string{"name": "prod", "description": "changed", "cicd_enforced_default": "true"}},
+				{ResourceType: "astro_workspace", Id: "ws2", Name: "shadow", Attributes: map[string]string{"name": "shadow"}},
+				{ResourceType: "astro_team_roles", Id: "t1", Attributes: map[string]string{"organization_role": "ORGANIZATION_MEMBER", "workspace_roles": "ws1=WORKSPACE_OWNER,ws2=WORKSPACE_MEMBER"}},
+			}
+			stateObjects := []importer.StateObject{
+				{Address: "astro_workspace.prod", ResourceType: "astro_workspace", Id: "ws1", StateFile: "prod.tfstate", Attributes: map[string]interface{}{"name": "prod", "description": "original", "cicd_enforced_default": true}},
+				{Address: "astro_team_roles.team", ResourceType: "astro_team_roles", Id: "t1", StateFile: "prod.tfstate", Attributes: map[string]interface{}{
+					"organization_role": "ORGANIZATION_MEMBER",
+					"workspace_roles": []interface{}{
+						map[string]interface{}{"workspace_id": "ws2", "role": "WORKSPACE_MEMBER"},
+						map[string]interface{}{"workspace_id": "ws1", "role": "WORKSPACE_OWNER"},
+					},
+				}},
+				{Address: "astro_user_roles.gone", ResourceType: "astro_user_roles", Id: "u1", StateFile: "prod.tfstate"},
+				{Address: "astro_user_invite.invite", ResourceType: "astro_user_invite", Id: "u2", StateFile: "prod.tfstate"},
+			}
+
+			report := importer.CompareState(liveObjects, stateObjects, types)
+
+			Expect(report.Summary).To(Equal(importer.DriftSummary{Managed: 2, Unmanaged: 1, Deleted: 1, Drifted: 1}))
+			Expect(report.Unmanaged[0].Id).To(Equal("ws2"))
+			Expect(report.Deleted[0].Address).To(Equal("astro_user_roles.gone"))
+			Expect(report.Drifted[0].Address).To(Equal("astro_workspace.prod"))
+			Expect(report.Drifted[0].Differences).To(Equal([]importer.AttributeDifference{{Attribute: "description", State: "original", Live: "changed"}}))
+			Expect(report.HasDrift()).To(BeTrue())
+
+			var text bytes.Buffer
+			Expect(report.WriteText(&text)).To(Succeed())
+			Expect(text.String()).To(ContainSubstring("astro_workspace ws2 (shadow)"))
+			Expect(text.String()).To(ContainSubstring(`description: "original" => "changed"`))
+
+			var jsonReport bytes.Buffer
+			Expect(report.WriteJSON(&jsonReport)).To(Succeed())
+			var decoded map[string]interface{}
+			Expect(json.Unmarshal(jsonReport.Bytes(), &decoded)).To(Succeed())
+			Expect(decoded["version"]).To(BeEquivalentTo(importer.DriftReportVersion))
+			Expect(decoded["summary"]).To(HaveKeyWithValue("drifted", BeEquivalentTo(1)))
+		})
+
+		It("should not report drift if the state matches", func() {
+			report := importer.CompareState(
+				[]importer.LiveObject{{ResourceType: "astro_workspace", Id: "ws1", Attributes: map[string]string{"description": ""}}},
+				[]importer.StateObject{{Address: "astro_workspace.ws", ResourceType: "astro_workspace", Id: "ws1", Attributes: map[string]interface{}{"description": nil}}},
+				types,
+			)
+
+			Expect(report.HasDrift()).To(BeFalse())
+			Expect(report.Summary.Managed).To(Equal(1))
+		})
+
+		It("should not report drift of hybrid deployments without development mode and high availability", func() {
+			ctx := context.Background()
+			organizationId := cuid.New()
+			mockPlatformClient := new(mocks_platform.ClientWithResponsesInterface)
+			mockIAMClient := new(mocks_iam.ClientWithResponsesInterface)
+
+			mockPlatformClient.On("ListDeploymentsWithResponse", ctx, organizationId, mock.Anything).Return(&platform.ListDeploymentsResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200: &platform.DeploymentsPaginated{Deployments: []platform.Deployment{{
+					Id:          "d1",
+					Name:        "hybrid",
+					WorkspaceId: "ws1",
+					ClusterId:   lo.ToPtr("c1"),
+					Type:        lo.ToPtr(platform.DeploymentTypeHYBRID),
+				}}, TotalCount: 1},
+			}, nil)
+
+			liveObjects, err := importer.ListLiveObjects(ctx, mockPlatformClient, mockIAMClient, organizationId, []string{"astro_deployment"})
+			Expect(err).To(BeNil())
+			Expect(liveObjects).To(HaveLen(1))
+			Expect(liveObjects[0].Attributes).To(HaveKeyWithValue("is_development_mode", ""))
+			Expect(liveObjects[0].Attributes).To(HaveKeyWithValue("is_high_availability", ""))
+
+			report := importer.CompareState(
+				liveObjects,
+				[]importer.StateObject{{Address: "astro_deployment.hybrid", ResourceType: "astro_deployment", Id: "d1", Attributes: map[string]interface{}{
+					"name":                  "hybrid",
+					"workspace_id":          "ws1",
+					"cluster_id":            "c1",
+					"type":                  "HYBRID",
+					"is_cicd_enforced":      false,
+					"is_dag_deploy_enabled": false,
+					"is_development_mode":   nil,
+					"is_high_availability":  nil,
+					"contact_emails":        []interface{}{},
+				}}},
+				[]string{"astro_deployment"},
+			)
+
+			Expect(report.Summary).To(Equal(importer.DriftSummary{Managed: 1}))
+			Expect(report.HasDrift()).To(BeFalse())
+		})
+	})
+
+	Describe("ListLiveObjects", func() {
+		It("should list role bindings of users and teams", func() {
+			ctx := context.Background()
+			organizationId := cuid.New()
+			mockPlatformClient := new(mocks_platform.ClientWithResponsesInterface)
+			mockIAMClient := new(mocks_iam.ClientWithResponsesInterface)
+
+			mockIAMClient.On("ListUsersWithResponse", ctx, organizationId, mock.Anything).Return(&iam.ListUsersResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200: &iam.UsersPaginated{Users: []iam.User{{
+					Id:               "u1",
+					OrganizationRole: lo.ToPtr(iam.ORGANIZATIONOWNER),
+					WorkspaceRoles:   &[]iam.WorkspaceRole{{WorkspaceId: "ws1", Role: iam.WORKSPACEOWNER}},
+				}}},
+			}, nil)
+			mockIAMClient.On("ListTeamsWithResponse", ctx, organizationId, mock.Anything).Return(&iam.ListTeamsResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200:      &iam.TeamsPaginated{Teams: []iam.Team{{Id: "t1", OrganizationRole: iam.TeamOrganizationRoleORGANIZATIONMEMBER}}},
+			}, nil)
+
+			objects, err := importer.ListLiveObjects(ctx, mockPlatformClient, mockIAMClient, organizationId, []string{"astro_team_roles", "astro_user_roles"})
+
+			Expect(err).To(BeNil())
+			Expect(objects).To(HaveLen(2))
+			Expect(objects[0].ResourceType).To(Equal("astro_team_roles"))
+			Expect(objects[0].Attributes).To(HaveKeyWithValue("organization_role", "ORGANIZATION_MEMBER"))
+			Expect(objects[1].ResourceType).To(Equal("astro_user_roles"))
+			Expect(objects[1].Attributes).To(HaveKeyWithValue("workspace_roles", "ws1=WORKSPACE_OWNER"))
+			mockPlatformClient.AssertNotCalled(GinkgoT(), "ListWorkspacesWithResponse", mock.Anything, mock.Anything, mock.Anything)
+		})
+	})
+
+	It("should request every page when listing", func() {
+		ctx := context.Background()
+		organizationId := cuid.New()
+		mockPlatformClient := new(mocks_platform.ClientWithResponsesInterface)
+
+		mockPlatformClient.On("ListWorkspacesWithResponse", ctx, organizationId, &platform.ListWorkspacesParams{Limit: lo.ToPtr(1000), Offset: lo.ToPtr(0)}).Return(&platform.ListWorkspacesResponse{
+			HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+			JSON200:      &platform.WorkspacesPaginated{Workspaces: []platform.Workspace{{Id: "ws1"}}, TotalCount: 2},
+		}, nil)
+		mockPlatformClient.On("ListWorkspacesWithResponse", ctx, organizationId, &platform.ListWorkspacesParams{Limit: lo.ToPtr(1000), Offset: lo.ToPtr(1)}).Return(&platform.ListWorkspacesResponse{
+			HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+			JSON200:      &platform.WorkspacesPaginated{Workspaces: []platform.Workspace{{Id: "ws2"}}, TotalCount: 2},
+		}, nil)
+
+		workspaces, err := importer.ListWorkspaces(ctx, mockPlatformClient, organizationId)
+
+		Expect(err).To(BeNil())
+		Expect(lo.Map(workspaces, func(workspace platform.Workspace, _ int) string {
+			return workspace.Id
+		})).To(Equal([]string{"ws1", "ws2"}))
+	})
+})
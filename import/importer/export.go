@@ -0,0 +1,397 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+)
+
+// InventoryVersion is the version of the inventory schema, it is increased on every breaking change of the schema
+const InventoryVersion = 1
+
+// Inventory is a snapshot of the objects of an organization
+// Objects are sorted by ID and secret values are never included so that snapshots can be diffed over time
+type Inventory struct {
+	Version        int                   `json:"version" yaml:"version"`
+	OrganizationId string                `json:"organization_id" yaml:"organization_id"`
+	GeneratedAt    time.Time             `json:"generated_at" yaml:"generated_at"`
+	Workspaces     []InventoryWorkspace  `json:"workspaces" yaml:"workspaces"`
+	Deployments    []InventoryDeployment `json:"deployments" yaml:"deployments"`
+	Clusters       []InventoryCluster    `json:"clusters" yaml:"clusters"`
+	Teams          []InventoryTeam       `json:"teams" yaml:"teams"`
+	Users          []InventoryUser       `json:"users" yaml:"users"`
+	ApiTokens      []InventoryApiToken   `json:"api_tokens" yaml:"api_tokens"`
+}
+
+type InventoryWorkspace struct {
+	Id                  string `json:"id" yaml:"id"`
+	Name                string `json:"name" yaml:"name"`
+	Description         string `json:"description" yaml:"description"`
+	CicdEnforcedDefault bool   `json:"cicd_enforced_default" yaml:"cicd_enforced_default"`
+}
+
+type InventoryDeployment struct {
+	Id                   string                         `json:"id" yaml:"id"`
+	Name                 string                         `json:"name" yaml:"name"`
+	Description          string                         `json:"description" yaml:"description"`
+	WorkspaceId          string                         `json:"workspace_id" yaml:"workspace_id"`
+	ClusterId            string                         `json:"cluster_id" yaml:"cluster_id"`
+	Type                 string                         `json:"type" yaml:"type"`
+	CloudProvider        string                         `json:"cloud_provider" yaml:"cloud_provider"`
+	Region               string                         `json:"region" yaml:"region"`
+	Executor             string                         `json:"executor" yaml:"executor"`
+	AstroRuntimeVersion  string                         `json:"astro_runtime_version" yaml:"astro_runtime_version"`
+	SchedulerSize        string                         `json:"scheduler_size" yaml:"scheduler_size"`
+	IsCicdEnforced       bool                           `json:"is_cicd_enforced" yaml:"is_cicd_enforced"`
+	IsDagDeployEnabled   bool                           `json:"is_dag_deploy_enabled" yaml:"is_dag_deploy_enabled"`
+	IsDevelopmentMode    bool                           `json:"is_development_mode" yaml:"is_development_mode"`
+	IsHighAvailability   bool                           `json:"is_high_availability" yaml:"is_high_availability"`
+	ContactEmails        []string                       `json:"contact_emails" yaml:"contact_emails"`
+	EnvironmentVariables []InventoryEnvironmentVariable `json:"environment_variables" yaml:"environment_variables"`
+	WorkerQueues         []InventoryWorkerQueue         `json:"worker_queues" yaml:"worker_queues"`
+	HibernationSchedules []InventoryHibernationSchedule `json:"hibernation_schedules" yaml:"hibernation_schedules"`
+}
+
+// InventoryEnvironmentVariable only holds the key of an environment variable, values are never exported
+type InventoryEnvironmentVariable struct {
+	Key      string `json:"key" yaml:"key"`
+	IsSecret bool   `json:"is_secret" yaml:"is_secret"`
+}
+
+type InventoryWorkerQueue struct {
+	Name              string `json:"name" yaml:"name"`
+	IsDefault         bool   `json:"is_default" yaml:"is_default"`
+	AstroMachine      string `json:"astro_machine" yaml:"astro_machine"`
+	NodePoolId        string `json:"node_pool_id" yaml:"node_pool_id"`
+	MinWorkerCount    int    `json:"min_worker_count" yaml:"min_worker_count"`
+	MaxWorkerCount    int    `json:"max_worker_count" yaml:"max_worker_count"`
+	WorkerConcurrency int    `json:"worker_concurrency" yaml:"worker_concurrency"`
+}
+
+type InventoryHibernationSchedule struct {
+	HibernateAtCron string `json:"hibernate_at_cron" yaml:"hibernate_at_cron"`
+	WakeAtCron      string `json:"wake_at_cron" yaml:"wake_at_cron"`
+	IsEnabled       bool   `json:"is_enabled" yaml:"is_enabled"`
+	Description     string `json:"description" yaml:"description"`
+}
+
+type InventoryCluster struct {
+	Id             string              `json:"id" yaml:"id"`
+	Name           string              `json:"name" yaml:"name"`
+	Type           string              `json:"type" yaml:"type"`
+	CloudProvider  string              `json:"cloud_provider" yaml:"cloud_provider"`
+	Region         string              `json:"region" yaml:"region"`
+	Status         string              `json:"status" yaml:"status"`
+	VpcSubnetRange string              `json:"vpc_subnet_range" yaml:"vpc_subnet_range"`
+	WorkspaceIds   []string            `json:"workspace_ids" yaml:"workspace_ids"`
+	NodePools      []InventoryNodePool `json:"node_pools" yaml:"node_pools"`
+}
+
+type InventoryNodePool struct {
+	Id               string `json:"id" yaml:"id"`
+	Name             string `json:"name" yaml:"name"`
+	IsDefault        bool   `json:"is_default" yaml:"is_default"`
+	NodeInstanceType string `json:"node_instance_type" yaml:"node_instance_type"`
+	MaxNodeCount     int    `json:"max_node_count" yaml:"max_node_count"`
+}
+
+// InventoryRoles holds the role bindings of a team or user
+type InventoryRoles struct {
+	OrganizationRole string                           `json:"organization_role" yaml:"organization_role"`
+	WorkspaceRoles   []InventoryWorkspaceRoleBinding  `json:"workspace_roles" yaml:"workspace_roles"`
+	DeploymentRoles  []InventoryDeploymentRoleBinding `json:"deployment_roles" yaml:"deployment_roles"`
+}
+
+type InventoryWorkspaceRoleBinding struct {
+	WorkspaceId string `json:"workspace_id" yaml:"workspace_id"`
+	Role        string `json:"role" yaml:"role"`
+}
+
+type InventoryDeploymentRoleBinding struct {
+	DeploymentId string `json:"deployment_id" yaml:"deployment_id"`
+	Role         string `json:"role" yaml:"role"`
+}
+
+type InventoryTeam struct {
+	Id           string         `json:"id" yaml:"id"`
+	Name         string         `json:"name" yaml:"name"`
+	Description  string         `json:"description" yaml:"description"`
+	IsIdpManaged bool           `json:"is_idp_managed" yaml:"is_idp_managed"`
+	Roles        InventoryRoles `json:"roles" yaml:"roles"`
+}
+
+type InventoryUser struct {
+	Id       string         `json:"id" yaml:"id"`
+	Username string         `json:"username" yaml:"username"`
+	FullName string         `json:"full_name" yaml:"full_name"`
+	Status   string         `json:"status" yaml:"status"`
+	Roles    InventoryRoles `json:"roles" yaml:"roles"`
+}
+
+type InventoryApiToken struct {
+	Id                 string                     `json:"id" yaml:"id"`
+	Name               string                     `json:"name" yaml:"name"`
+	Description        string                     `json:"description" yaml:"description"`
+	Type               string                     `json:"type" yaml:"type"`
+	StartAt            time.Time                  `json:"start_at" yaml:"start_at"`
+	EndAt              *time.Time                 `json:"end_at" yaml:"end_at"`
+	ExpiryPeriodInDays *int                       `json:"expiry_period_in_days" yaml:"expiry_period_in_days"`
+	Roles              []InventoryApiTokenBinding `json:"roles" yaml:"roles"`
+}
+
+type InventoryApiTokenBinding struct {
+	EntityType string `json:"entity_type" yaml:"entity_type"`
+	EntityId   string `json:"entity_id" yaml:"entity_id"`
+	Role       string `json:"role" yaml:"role"`
+}
+
+// WriteJSON writes the inventory as indented JSON
+func (inv *Inventory) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(inv)
+}
+
+// WriteYAML writes the inventory as YAML
+func (inv *Inventory) WriteYAML(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(inv); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// Export lists the objects of the requested resources and returns them as an inventory
+// Sections of resources that are not requested are empty
+func (i *Importer) Export(ctx context.Context) (*Inventory, error) {
+	inv := &Inventory{
+		Version:        InventoryVersion,
+		OrganizationId: i.opts.OrganizationId,
+		GeneratedAt:    time.Now().UTC(),
+		Workspaces:     []InventoryWorkspace{},
+		Deployments:    []InventoryDeployment{},
+		Clusters:       []InventoryCluster{},
+		Teams:          []InventoryTeam{},
+		Users:          []InventoryUser{},
+		ApiTokens:      []InventoryApiToken{},
+	}
+	requested := func(resources ...string) bool {
+		return lo.Some(i.resources, resources)
+	}
+
+	if requested("workspace") {
+		workspaces, err := ListWorkspaces(ctx, i.platformClient, i.opts.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+		inv.Workspaces = lo.Map(workspaces, func(workspace platform.Workspace, _ int) InventoryWorkspace {
+			return InventoryWorkspace{
+				Id:                  workspace.Id,
+				Name:                workspace.Name,
+				Description:         stringValue(workspace.Description),
+				CicdEnforcedDefault: workspace.CicdEnforcedDefault,
+			}
+		})
+		sort.Slice(inv.Workspaces, func(a, b int) bool { return inv.Workspaces[a].Id < inv.Workspaces[b].Id })
+	}
+
+	if requested("deployment") {
+		deployments, err := ListDeployments(ctx, i.platformClient, i.opts.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+		inv.Deployments = lo.Map(deployments, func(deployment platform.Deployment, _ int) InventoryDeployment {
+			return inventoryDeployment(deployment)
+		})
+		sort.Slice(inv.Deployments, func(a, b int) bool { return inv.Deployments[a].Id < inv.Deployments[b].Id })
+	}
+
+	if requested("cluster", "hybrid_cluster_workspace_authorization") {
+		clusters, err := ListClusters(ctx, i.platformClient, i.opts.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+		inv.Clusters = lo.Map(clusters, func(cluster platform.Cluster, _ int) InventoryCluster {
+			nodePools := lo.Map(lo.FromPtr(cluster.NodePools), func(nodePool platform.NodePool, _ int) InventoryNodePool {
+				return InventoryNodePool{
+					Id:               nodePool.Id,
+					Name:             nodePool.Name,
+					IsDefault:        nodePool.IsDefault,
+					NodeInstanceType: nodePool.NodeInstanceType,
+					MaxNodeCount:     nodePool.MaxNodeCount,
+				}
+			})
+			sort.Slice(nodePools, func(a, b int) bool { return nodePools[a].Id < nodePools[b].Id })
+			return InventoryCluster{
+				Id:             cluster.Id,
+				Name:           cluster.Name,
+				Type:           string(cluster.Type),
+				CloudProvider:  string(cluster.CloudProvider),
+				Region:         cluster.Region,
+				Status:         string(cluster.Status),
+				VpcSubnetRange: cluster.VpcSubnetRange,
+				WorkspaceIds:   sortedStrings(lo.FromPtr(cluster.WorkspaceIds)),
+				NodePools:      nodePools,
+			}
+		})
+		sort.Slice(inv.Clusters, func(a, b int) bool { return inv.Clusters[a].Id < inv.Clusters[b].Id })
+	}
+
+	if requested("team", "team_roles") {
+		teams, err := ListTeams(ctx, i.iamClient, i.opts.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+		inv.Teams = lo.Map(teams, func(team iam.Team, _ int) InventoryTeam {
+			return InventoryTeam{
+				Id:           team.Id,
+				Name:         team.Name,
+				Description:  stringValue(team.Description),
+				IsIdpManaged: team.IsIdpManaged,
+				Roles:        inventoryRoles(string(team.OrganizationRole), team.WorkspaceRoles, team.DeploymentRoles),
+			}
+		})
+		sort.Slice(inv.Teams, func(a, b int) bool { return inv.Teams[a].Id < inv.Teams[b].Id })
+	}
+
+	if requested("user_roles") {
+		users, err := ListUsers(ctx, i.iamClient, i.opts.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+		inv.Users = lo.Map(users, func(user iam.User, _ int) InventoryUser {
+			return InventoryUser{
+				Id:       user.Id,
+				Username: user.Username,
+				FullName: user.FullName,
+				Status:   string(user.Status),
+				Roles:    inventoryRoles(stringValue((*string)(user.OrganizationRole)), user.WorkspaceRoles, user.DeploymentRoles),
+			}
+		})
+		sort.Slice(inv.Users, func(a, b int) bool { return inv.Users[a].Id < inv.Users[b].Id })
+	}
+
+	if requested("api_token") {
+		apiTokens, err := ListApiTokens(ctx, i.iamClient, i.opts.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+		inv.ApiTokens = lo.Map(apiTokens, func(apiToken iam.ApiToken, _ int) InventoryApiToken {
+			roles := lo.Map(lo.FromPtr(apiToken.Roles), func(role iam.ApiTokenRole, _ int) InventoryApiTokenBinding {
+				return InventoryApiTokenBinding{
+					EntityType: string(role.EntityType),
+					EntityId:   role.EntityId,
+					Role:       role.Role,
+				}
+			})
+			sort.Slice(roles, func(a, b int) bool {
+				return roleBinding(roles[a].Role, roles[a].EntityType, roles[a].EntityId) < roleBinding(roles[b].Role, roles[b].EntityType, roles[b].EntityId)
+			})
+			return InventoryApiToken{
+				Id:                 apiToken.Id,
+				Name:               apiToken.Name,
+				Description:        apiToken.Description,
+				Type:               string(apiToken.Type),
+				StartAt:            apiToken.StartAt,
+				EndAt:              apiToken.EndAt,
+				ExpiryPeriodInDays: apiToken.ExpiryPeriodInDays,
+				Roles:              roles,
+			}
+		})
+		sort.Slice(inv.ApiTokens, func(a, b int) bool { return inv.ApiTokens[a].Id < inv.ApiTokens[b].Id })
+	}
+
+	return inv, nil
+}
+
+func inventoryDeployment(deployment platform.Deployment) InventoryDeployment {
+	environmentVariables := lo.Map(lo.FromPtr(deployment.EnvironmentVariables), func(envVar platform.DeploymentEnvironmentVariable, _ int) InventoryEnvironmentVariable {
+		return InventoryEnvironmentVariable{Key: envVar.Key, IsSecret: envVar.IsSecret}
+	})
+	sort.Slice(environmentVariables, func(a, b int) bool { return environmentVariables[a].Key < environmentVariables[b].Key })
+
+	workerQueues := lo.Map(lo.FromPtr(deployment.WorkerQueues), func(queue platform.WorkerQueue, _ int) InventoryWorkerQueue {
+		return InventoryWorkerQueue{
+			Name:              queue.Name,
+			IsDefault:         queue.IsDefault,
+			AstroMachine:      stringValue(queue.AstroMachine),
+			NodePoolId:        stringValue(queue.NodePoolId),
+			MinWorkerCount:    queue.MinWorkerCount,
+			MaxWorkerCount:    queue.MaxWorkerCount,
+			WorkerConcurrency: queue.WorkerConcurrency,
+		}
+	})
+	sort.Slice(workerQueues, func(a, b int) bool { return workerQueues[a].Name < workerQueues[b].Name })
+
+	hibernationSchedules := []InventoryHibernationSchedule{}
+	if deployment.ScalingSpec != nil && deployment.ScalingSpec.HibernationSpec != nil {
+		hibernationSchedules = lo.Map(lo.FromPtr(deployment.ScalingSpec.HibernationSpec.Schedules), func(schedule platform.DeploymentHibernationSchedule, _ int) InventoryHibernationSchedule {
+			return InventoryHibernationSchedule{
+				HibernateAtCron: schedule.HibernateAtCron,
+				WakeAtCron:      schedule.WakeAtCron,
+				IsEnabled:       schedule.IsEnabled,
+				Description:     stringValue(schedule.Description),
+			}
+		})
+	}
+
+	return InventoryDeployment{
+		Id:                   deployment.Id,
+		Name:                 deployment.Name,
+		Description:          stringValue(deployment.Description),
+		WorkspaceId:          deployment.WorkspaceId,
+		ClusterId:            stringValue(deployment.ClusterId),
+		Type:                 stringValue((*string)(deployment.Type)),
+		CloudProvider:        stringValue((*string)(deployment.CloudProvider)),
+		Region:               stringValue(deployment.Region),
+		Executor:             stringValue((*string)(deployment.Executor)),
+		AstroRuntimeVersion:  deployment.AstroRuntimeVersion,
+		SchedulerSize:        stringValue((*string)(deployment.SchedulerSize)),
+		IsCicdEnforced:       deployment.IsCicdEnforced,
+		IsDagDeployEnabled:   deployment.IsDagDeployEnabled,
+		IsDevelopmentMode:    boolValue(deployment.IsDevelopmentMode),
+		IsHighAvailability:   boolValue(deployment.IsHighAvailability),
+		ContactEmails:        sortedStrings(lo.FromPtr(deployment.ContactEmails)),
+		EnvironmentVariables: environmentVariables,
+		WorkerQueues:         workerQueues,
+		HibernationSchedules: hibernationSchedules,
+	}
+}
+
+func inventoryRoles(organizationRole string, workspaceRoles *[]iam.WorkspaceRole, deploymentRoles *[]iam.DeploymentRole) InventoryRoles {
+	workspaceRoleBindings := lo.Map(lo.FromPtr(workspaceRoles), func(role iam.WorkspaceRole, _ int) InventoryWorkspaceRoleBinding {
+		return InventoryWorkspaceRoleBinding{WorkspaceId: role.WorkspaceId, Role: string(role.Role)}
+	})
+	sort.Slice(workspaceRoleBindings, func(a, b int) bool {
+		return workspaceRoleBindings[a].WorkspaceId < workspaceRoleBindings[b].WorkspaceId
+	})
+
+	deploymentRoleBindings := lo.Map(lo.FromPtr(deploymentRoles), func(role iam.DeploymentRole, _ int) InventoryDeploymentRoleBinding {
+		return InventoryDeploymentRoleBinding{DeploymentId: role.DeploymentId, Role: role.Role}
+	})
+	sort.Slice(deploymentRoleBindings, func(a, b int) bool {
+		return deploymentRoleBindings[a].DeploymentId < deploymentRoleBindings[b].DeploymentId
+	})
+
+	return InventoryRoles{
+		OrganizationRole: organizationRole,
+		WorkspaceRoles:   workspaceRoleBindings,
+		DeploymentRoles:  deploymentRoleBindings,
+	}
+}
+
+// sortedStrings returns a sorted copy of the values, never nil so that it is exported as an empty list
+func sortedStrings(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,91 @@
+package importer_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/lucsky/cuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"gopkg.in/yaml.v3"
+
+	"github.com/astronomer/terraform-provider-astro/import/importer"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	mocks_iam "github.com/astronomer/terraform-provider-astro/internal/mocks/iam"
+	mocks_platform "github.com/astronomer/terraform-provider-astro/internal/mocks/platform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Export", func() {
+	var ctx context.Context
+	var mockPlatformClient *mocks_platform.ClientWithResponsesInterface
+	var mockIAMClient *mocks_iam.ClientWithResponsesInterface
+	var organizationId string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mockPlatformClient = new(mocks_platform.ClientWithResponsesInterface)
+		mockIAMClient = new(mocks_iam.ClientWithResponsesInterface)
+		organizationId = cuid.New()
+	})
+
+	It("should export the requested resources without secret values", func() {
+		mockPlatformClient.On("ListDeploymentsWithResponse", ctx, organizationId, mock.Anything).Return(&platform.ListDeploymentsResponse{
+			HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+			JSON200: &platform.DeploymentsPaginated{Deployments: []platform.Deployment{
+				{
+					Id:   "d2",
+					Name: "prod",
+					EnvironmentVariables: &[]platform.DeploymentEnvironmentVariable{
+						{Key: "SECRET", Value: lo.ToPtr("super-secret"), IsSecret: true},
+						{Key: "PLAIN", Value: lo.ToPtr("plain-value")},
+					},
+					WorkerQueues: &[]platform.WorkerQueue{{Name: "default", IsDefault: true, AstroMachine: lo.ToPtr("A5"), MaxWorkerCount: 10, MinWorkerCount: 1, WorkerConcurrency: 5}},
+					ScalingSpec: &platform.DeploymentScalingSpec{HibernationSpec: &platform.DeploymentHibernationSpec{
+						Schedules: &[]platform.DeploymentHibernationSchedule{{HibernateAtCron: "0 18 * * 1-5", WakeAtCron: "0 8 * * 1-5", IsEnabled: true}},
+					}},
+				},
+				{Id: "d1", Name: "dev"},
+			}},
+		}, nil)
+
+		imp, err := importer.New(importer.Options{
+			OrganizationId: organizationId,
+			Resources:      []string{"deployment"},
+			PlatformClient: mockPlatformClient,
+			IamClient:      mockIAMClient,
+		})
+		Expect(err).To(BeNil())
+
+		inventory, err := imp.Export(ctx)
+
+		Expect(err).To(BeNil())
+		Expect(inventory.Version).To(Equal(importer.InventoryVersion))
+		Expect(inventory.Workspaces).To(BeEmpty())
+		Expect(lo.Map(inventory.Deployments, func(deployment importer.InventoryDeployment, _ int) string {
+			return deployment.Id
+		})).To(Equal([]string{"d1", "d2"}))
+		Expect(inventory.Deployments[1].EnvironmentVariables).To(Equal([]importer.InventoryEnvironmentVariable{{Key: "PLAIN"}, {Key: "SECRET", IsSecret: true}}))
+		Expect(inventory.Deployments[1].WorkerQueues[0].AstroMachine).To(Equal("A5"))
+		Expect(inventory.Deployments[1].HibernationSchedules[0].HibernateAtCron).To(Equal("0 18 * * 1-5"))
+		mockIAMClient.AssertNotCalled(GinkgoT(), "ListUsersWithResponse", mock.Anything, mock.Anything, mock.Anything)
+
+		var jsonInventory bytes.Buffer
+		Expect(inventory.WriteJSON(&jsonInventory)).To(Succeed())
+		Expect(jsonInventory.String()).ToNot(ContainSubstring("super-secret"))
+		Expect(jsonInventory.String()).ToNot(ContainSubstring("plain-value"))
+		var decodedJson map[string]interface{}
+		Expect(json.Unmarshal(jsonInventory.Bytes(), &decodedJson)).To(Succeed())
+		Expect(decodedJson).To(HaveKeyWithValue("organization_id", organizationId))
+		Expect(decodedJson).To(HaveKeyWithValue("users", BeEmpty()))
+
+		var yamlInventory bytes.Buffer
+		Expect(inventory.WriteYAML(&yamlInventory)).To(Succeed())
+		var decodedYaml importer.Inventory
+		Expect(yaml.Unmarshal(yamlInventory.Bytes(), &decodedYaml)).To(Succeed())
+		Expect(decodedYaml.Deployments).To(Equal(inventory.Deployments))
+	})
+})
@@ -0,0 +1,154 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/samber/lo"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+)
+
+// newImportBlocks returns an import block per ID, named `<namePrefix>_<id>`
+func newImportBlocks(resourceType, namePrefix string, ids []string) []ImportBlock {
+	return lo.Map(ids, func(id string, _ int) ImportBlock {
+		return ImportBlock{
+			ResourceType: resourceType,
+			ResourceName: fmt.Sprintf("%v_%v", namePrefix, id),
+			Id:           id,
+		}
+	})
+}
+
+// HandleWorkspaces returns the import blocks of all workspaces in the organization
+func HandleWorkspaces(ctx context.Context, platformClient platform.ClientWithResponsesInterface, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]ImportBlock, error) {
+	workspaces, err := ListWorkspaces(ctx, platformClient, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceIds := lo.Map(workspaces, func(workspace platform.Workspace, _ int) string {
+		return workspace.Id
+	})
+
+	return newImportBlocks("astro_workspace", "workspace", workspaceIds), nil
+}
+
+// HandleDeployments returns the import blocks of all deployments in the organization
+func HandleDeployments(ctx context.Context, platformClient platform.ClientWithResponsesInterface, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]ImportBlock, error) {
+	deployments, err := ListDeployments(ctx, platformClient, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentIds := lo.Map(deployments, func(deployment platform.Deployment, _ int) string {
+		return deployment.Id
+	})
+
+	return newImportBlocks("astro_deployment", "deployment", deploymentIds), nil
+}
+
+// HandleClusters returns the import blocks of all dedicated clusters in the organization
+func HandleClusters(ctx context.Context, platformClient platform.ClientWithResponsesInterface, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]ImportBlock, error) {
+	clusters, err := ListClusters(ctx, platformClient, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	// hybrid clusters cannot be managed by astro_cluster, their workspace authorizations are handled by HandleHybridClusterWorkspaceAuthorizations
+	clusterIds := lo.FilterMap(clusters, func(cluster platform.Cluster, _ int) (string, bool) {
+		return cluster.Id, cluster.Id != "" && cluster.Type != platform.ClusterTypeHYBRID
+	})
+
+	return newImportBlocks("astro_cluster", "cluster", clusterIds), nil
+}
+
+// HandleHybridClusterWorkspaceAuthorizations returns the import blocks of the workspace authorizations of all hybrid clusters in the organization
+func HandleHybridClusterWorkspaceAuthorizations(ctx context.Context, platformClient platform.ClientWithResponsesInterface, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]ImportBlock, error) {
+	clusters, err := ListClusters(ctx, platformClient, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	// only hybrid clusters with at least one authorized workspace have an authorization to import
+	clusterIds := lo.FilterMap(clusters, func(cluster platform.Cluster, _ int) (string, bool) {
+		return cluster.Id, cluster.Type == platform.ClusterTypeHYBRID && cluster.WorkspaceIds != nil && len(*cluster.WorkspaceIds) > 0
+	})
+
+	return newImportBlocks("astro_hybrid_cluster_workspace_authorization", "cluster", clusterIds), nil
+}
+
+// HandleApiTokens returns the import blocks of all API tokens in the organization
+func HandleApiTokens(ctx context.Context, platformClient platform.ClientWithResponsesInterface, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]ImportBlock, error) {
+	apiTokens, err := ListApiTokens(ctx, iamClient, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	apiTokenIds := lo.Map(apiTokens, func(apiToken iam.ApiToken, _ int) string {
+		return apiToken.Id
+	})
+
+	return newImportBlocks("astro_api_token", "api_token", apiTokenIds), nil
+}
+
+// HandleTeams returns the import blocks of all teams in the organization, teams cannot be imported if SCIM is enabled
+func HandleTeams(ctx context.Context, platformClient platform.ClientWithResponsesInterface, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]ImportBlock, error) {
+	organizationResp, err := platformClient.GetOrganizationWithResponse(ctx, organizationId, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %v", err)
+	}
+
+	if organizationResp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", organizationResp.StatusCode(), string(organizationResp.Body))
+	}
+
+	if organizationResp.JSON200 == nil {
+		return nil, fmt.Errorf("failed to get organization, JSON200 resp is nil, organizationId: %v", organizationId)
+	}
+
+	if organizationResp.JSON200.IsScimEnabled {
+		return nil, fmt.Errorf("SCIM is enabled for the organization, teams cannot be imported")
+	}
+
+	teams, err := ListTeams(ctx, iamClient, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	teamIds := lo.Map(teams, func(team iam.Team, _ int) string {
+		return team.Id
+	})
+
+	return newImportBlocks("astro_team", "team", teamIds), nil
+}
+
+// HandleTeamRoles returns the import blocks of the roles of all teams in the organization
+func HandleTeamRoles(ctx context.Context, platformClient platform.ClientWithResponsesInterface, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]ImportBlock, error) {
+	teams, err := ListTeams(ctx, iamClient, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	teamIds := lo.Map(teams, func(team iam.Team, _ int) string {
+		return team.Id
+	})
+
+	return newImportBlocks("astro_team_roles", "team", teamIds), nil
+}
+
+// HandleUserRoles returns the import blocks of the roles of all users in the organization
+func HandleUserRoles(ctx context.Context, platformClient platform.ClientWithResponsesInterface, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]ImportBlock, error) {
+	users, err := ListUsers(ctx, iamClient, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	userIds := lo.Map(users, func(user iam.User, _ int) string {
+		return user.Id
+	})
+
+	return newImportBlocks("astro_user_roles", "user", userIds), nil
+}
@@ -0,0 +1,209 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+)
+
+// GenerateDeploymentHCL generates the HCL of the given deployments
+// terraform plan -generate-config-out has trouble with deployments, so we generate the HCL manually
+func GenerateDeploymentHCL(ctx context.Context, platformClient platform.ClientWithResponsesInterface, organizationId string, deploymentIds []string) (string, error) {
+	var hclString string
+	for _, deploymentId := range deploymentIds {
+		var deploymentHCL string
+
+		// get deployment details
+		deploymentResp, err := platformClient.GetDeploymentWithResponse(ctx, organizationId, deploymentId)
+		if err != nil {
+			return "", fmt.Errorf("failed to get deployment: %v", err)
+		}
+
+		if deploymentResp.StatusCode() != http.StatusOK {
+			return "", fmt.Errorf("unexpected status code: %d, body: %s", deploymentResp.StatusCode(), string(deploymentResp.Body))
+		}
+
+		deployment := deploymentResp.JSON200
+		if deployment == nil {
+			return "", fmt.Errorf("failed to get deployment, JSON200 resp is nil, deploymentId: %v", deploymentId)
+		}
+
+		contactEmailsString := formatContactEmails(deployment.ContactEmails)
+		environmentVariablesString := formatEnvironmentVariables(deployment.EnvironmentVariables)
+		workerQueuesString := formatWorkerQueues(deployment.WorkerQueues, (*string)(deployment.Executor))
+
+		deploymentType := deployment.Type
+
+		if deploymentType == nil {
+			deploymentHCL = fmt.Sprintf("\n// skipped deployment %s: unknown deployment type\n", deployment.Id)
+		} else if *deploymentType == platform.DeploymentTypeDEDICATED {
+			deploymentHCL = fmt.Sprintf(`
+resource "astro_deployment" "deployment_%s" {
+	cluster_id = "%s"
+	%s
+	default_task_pod_cpu = "%s"
+	default_task_pod_memory = "%s"
+	description = "%s"
+	%s
+	executor = "%s"
+	is_cicd_enforced = %t
+	is_dag_deploy_enabled = %t
+	is_development_mode = %t
+	is_high_availability = %t
+	name = "%s"
+	resource_quota_cpu = "%s"
+	resource_quota_memory = "%s"
+	scheduler_size = "%s"
+	type = "%s"
+	workspace_id = "%s"
+	%s
+}
+`,
+				deployment.Id,
+				stringValue(deployment.ClusterId),
+				contactEmailsString,
+				stringValue(deployment.DefaultTaskPodCpu),
+				stringValue(deployment.DefaultTaskPodMemory),
+				stringValue(deployment.Description),
+				environmentVariablesString,
+				stringValue((*string)(deployment.Executor)),
+				deployment.IsCicdEnforced,
+				deployment.IsDagDeployEnabled,
+				boolValue(deployment.IsDevelopmentMode),
+				boolValue(deployment.IsHighAvailability),
+				deployment.Name,
+				stringValue(deployment.ResourceQuotaCpu),
+				stringValue(deployment.ResourceQuotaMemory),
+				stringValue((*string)(deployment.SchedulerSize)),
+				stringValue((*string)(deploymentType)),
+				deployment.WorkspaceId,
+				workerQueuesString,
+			)
+		} else if *deploymentType == platform.DeploymentTypeSTANDARD {
+			deploymentHCL = fmt.Sprintf(`
+resource "astro_deployment" "deployment_%s" {
+	cloud_provider = "%s"
+	%s
+	default_task_pod_cpu = "%s"
+	default_task_pod_memory = "%s"
+	description = "%s"
+	%s
+	executor = "%s"
+	is_cicd_enforced = %t
+	is_dag_deploy_enabled = %t
+	is_development_mode = %t
+	is_high_availability = %t
+	name = "%s"
+	region = "%s"
+	resource_quota_cpu = "%s"
+	resource_quota_memory = "%s"
+	scheduler_size = "%s"
+	type = "%s"
+	workspace_id = "%s"
+	%s
+}
+`,
+				deployment.Id,
+				stringValue((*string)(deployment.CloudProvider)),
+				contactEmailsString,
+				stringValue(deployment.DefaultTaskPodCpu),
+				stringValue(deployment.DefaultTaskPodMemory),
+				stringValue(deployment.Description),
+				environmentVariablesString,
+				stringValue((*string)(deployment.Executor)),
+				deployment.IsCicdEnforced,
+				deployment.IsDagDeployEnabled,
+				boolValue(deployment.IsDevelopmentMode),
+				boolValue(deployment.IsHighAvailability),
+				deployment.Name,
+				stringValue(deployment.Region),
+				stringValue(deployment.ResourceQuotaCpu),
+				stringValue(deployment.ResourceQuotaMemory),
+				stringValue((*string)(deployment.SchedulerSize)),
+				stringValue((*string)(deploymentType)),
+				deployment.WorkspaceId,
+				workerQueuesString,
+			)
+		} else {
+			deploymentHCL = fmt.Sprintf("\n// skipped deployment %s: unsupported deployment type %s\n", deployment.Id, *deploymentType)
+		}
+
+		hclString += deploymentHCL
+	}
+
+	return hclString, nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func boolValue(b *bool) bool {
+	if b == nil {
+		return false
+	}
+	return *b
+}
+
+func formatContactEmails(emails *[]string) string {
+	if emails == nil || len(*emails) == 0 {
+		return fmt.Sprintf(`contact_emails = []`)
+	}
+	quotedEmails := make([]string, len(*emails))
+	for i, email := range *emails {
+		quotedEmails[i] = fmt.Sprintf(`"%s"`, email)
+	}
+	return fmt.Sprintf(`contact_emails = [%s]`, strings.Join(quotedEmails, ", "))
+}
+
+func formatEnvironmentVariables(envVars *[]platform.DeploymentEnvironmentVariable) string {
+	if envVars == nil || len(*envVars) == 0 {
+		return fmt.Sprintf(`environment_variables = []`)
+	}
+	variables := lo.Map(*envVars, func(envVar platform.DeploymentEnvironmentVariable, _ int) string {
+		return fmt.Sprintf(`{
+		name = "%s"
+		value = "%s"
+		is_secret = %t
+	}`, envVar.Key, stringValue(envVar.Value), envVar.IsSecret)
+	})
+	return fmt.Sprintf(`environment_variables = [%s]`, strings.Join(variables, ", "))
+}
+
+func formatWorkerQueues(queues *[]platform.WorkerQueue, executor *string) string {
+	// If queues is nil and executor is not CELERY, return an empty string
+	if queues == nil && (executor == nil || *executor != "CELERY") {
+		return ""
+	}
+
+	// If queues is empty but executor is CELERY, return an empty worker_queues array
+	if (queues == nil || len(*queues) == 0) && executor != nil && *executor == "CELERY" {
+		return `worker_queues = []`
+	}
+
+	// If we have queues, format them
+	if queues != nil && len(*queues) > 0 {
+		workerQueues := lo.Map(*queues, func(queue platform.WorkerQueue, _ int) string {
+			return fmt.Sprintf(`{
+		astro_machine = "%s"
+		name = "%s"
+		is_default = %t
+		max_worker_count = %d
+		min_worker_count = %d
+		worker_concurrency = %d
+	}`, stringValue(queue.AstroMachine), queue.Name, queue.IsDefault, queue.MaxWorkerCount, queue.MinWorkerCount, queue.WorkerConcurrency)
+		})
+		return fmt.Sprintf(`worker_queues = [%s]`, strings.Join(workerQueues, ", "))
+	}
+
+	// If we've reached here, it means queues is nil or empty, and executor is not CELERY
+	return ""
+}
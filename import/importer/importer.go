@@ -0,0 +1,485 @@
+// Package importer discovers the objects of an Astro organization and generates the Terraform import blocks and
+// configuration needed to manage them with the Astro Terraform provider.
+//
+// The import script in the parent directory is a thin command line wrapper around this package.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+)
+
+const (
+	ProdHost  = "https://api.astronomer.io"
+	DevHost   = "https://api.astronomer-dev.io"
+	StageHost = "https://api.astronomer-stage.io"
+
+	ImportFileName    = "import.tf"
+	GeneratedFileName = "generated.tf"
+)
+
+// AcceptedResources lists every resource that can be imported, in the order they are imported by default
+var AcceptedResources = []string{
+	"workspace",
+	"deployment",
+	"cluster",
+	"hybrid_cluster_workspace_authorization",
+	"api_token",
+	"team",
+	"team_roles",
+	"user_roles",
+}
+
+// Handler lists the objects of one resource type in an organization and returns their import blocks
+type Handler func(ctx context.Context, platformClient platform.ClientWithResponsesInterface, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]ImportBlock, error)
+
+// ResourceHandlers maps every accepted resource to its handler
+var ResourceHandlers = map[string]Handler{
+	"workspace":                              HandleWorkspaces,
+	"deployment":                             HandleDeployments,
+	"cluster":                                HandleClusters,
+	"hybrid_cluster_workspace_authorization": HandleHybridClusterWorkspaceAuthorizations,
+	"api_token":                              HandleApiTokens,
+	"team":                                   HandleTeams,
+	"team_roles":                             HandleTeamRoles,
+	"user_roles":                             HandleUserRoles,
+}
+
+// Options configures the discovery and import of an organization
+type Options struct {
+	// OrganizationId is the ID of the organization to import resources from
+	OrganizationId string
+	// Token is the API token used to authenticate with Astro, it is not needed if both clients are set
+	Token string
+	// Host is the Astro API host, either a URL or one of the `prod`, `dev` and `stage` shortcuts. Default is `https://api.astronomer.io`
+	Host string
+	// Resources is the list of resources to import, all AcceptedResources are imported if empty
+	Resources []string
+	// OutputDir is the Terraform working directory the import and generated configuration are written to. Default is the current directory
+	OutputDir string
+	// RunTerraformInit runs `terraform init` in OutputDir before generating the configuration
+	RunTerraformInit bool
+	// Incremental only imports objects that are not already managed by the configuration or state in OutputDir,
+	// existing files are never modified or deleted
+	Incremental bool
+	// ClientVersion is sent to the Astro API as the client version. Default is `import`
+	ClientVersion string
+	// PlatformClient and IamClient override the API clients created from Host and Token
+	PlatformClient platform.ClientWithResponsesInterface
+	IamClient      iam.ClientWithResponsesInterface
+	// Logger receives progress messages, they are discarded if nil
+	Logger *log.Logger
+	// TerraformOutput receives the output of the terraform commands. Default is os.Stdout
+	TerraformOutput io.Writer
+}
+
+// ImportBlock is the Terraform import block of an existing Astro object
+type ImportBlock struct {
+	// ResourceType is the Terraform resource type, for example `astro_workspace`
+	ResourceType string
+	// ResourceName is the Terraform resource name, for example `workspace_<id>`
+	ResourceName string
+	// Id is the import ID of the object
+	Id string
+}
+
+// Address returns the Terraform resource address the object is imported to
+func (b ImportBlock) Address() string {
+	return fmt.Sprintf("%v.%v", b.ResourceType, b.ResourceName)
+}
+
+// HCL returns the import block in HCL
+func (b ImportBlock) HCL() string {
+	return fmt.Sprintf(`
+import {
+	id = "%v"
+	to = %v
+}`, b.Id, b.Address())
+}
+
+// RenderImportBlocks returns the HCL of all import blocks, one block after the other
+func RenderImportBlocks(blocks []ImportBlock) string {
+	var importString string
+	for _, block := range blocks {
+		importString += block.HCL() + "\n"
+	}
+	return importString
+}
+
+// ResourceResult is the outcome of discovering one resource type
+type ResourceResult struct {
+	Resource     string
+	ImportBlocks []ImportBlock
+	// Managed holds the objects skipped in incremental mode because they are already managed
+	Managed []ImportBlock
+	Err     error
+}
+
+// Result is the outcome of discovering or importing an organization
+type Result struct {
+	OrganizationId string
+	Host           string
+	// Resources holds one result per requested resource, in the requested order
+	Resources []ResourceResult
+	// ImportFile and GeneratedFile are the paths of the written files, empty if they were not written
+	ImportFile    string
+	GeneratedFile string
+}
+
+// ImportBlocks returns the import blocks of every successfully discovered resource
+func (r *Result) ImportBlocks() []ImportBlock {
+	var blocks []ImportBlock
+	for _, resource := range r.Resources {
+		blocks = append(blocks, resource.ImportBlocks...)
+	}
+	return blocks
+}
+
+// Failed returns the results of the resources that could not be discovered
+func (r *Result) Failed() []ResourceResult {
+	return lo.Filter(r.Resources, func(resource ResourceResult, _ int) bool {
+		return resource.Err != nil
+	})
+}
+
+// SkipManaged moves the import blocks of objects that are already managed out of the import blocks of each resource
+func (r *Result) SkipManaged(managed *ManagedObjects) {
+	for idx, resource := range r.Resources {
+		var newBlocks, managedBlocks []ImportBlock
+		for _, block := range resource.ImportBlocks {
+			if managed.IsManaged(block) {
+				managedBlocks = append(managedBlocks, block)
+			} else {
+				newBlocks = append(newBlocks, block)
+			}
+		}
+		r.Resources[idx].ImportBlocks = newBlocks
+		r.Resources[idx].Managed = managedBlocks
+	}
+}
+
+// ResolveHost converts the `prod`, `dev` and `stage` shortcuts to their API host and validates any other host URL
+func ResolveHost(host string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(host)) {
+	case "", "prod":
+		return ProdHost, nil
+	case "dev":
+		return DevHost, nil
+	case "stage":
+		return StageHost, nil
+	}
+	hostUrl, err := url.Parse(strings.TrimSpace(host))
+	if err != nil || (hostUrl.Scheme != "http" && hostUrl.Scheme != "https") || hostUrl.Host == "" {
+		return "", fmt.Errorf("invalid host %q, expected a URL such as %v or one of prod, dev, stage", host, ProdHost)
+	}
+	return strings.TrimSuffix(hostUrl.String(), "/"), nil
+}
+
+// ValidateResources lower-cases the requested resources and checks that each of them can be imported
+func ValidateResources(resources []string) ([]string, error) {
+	if len(resources) == 0 {
+		return AcceptedResources, nil
+	}
+	resources = lo.Map(resources, func(resource string, _ int) string {
+		return strings.ToLower(strings.TrimSpace(resource))
+	})
+	for _, resource := range resources {
+		if !lo.Contains(AcceptedResources, resource) {
+			return nil, fmt.Errorf("invalid resource: %s is not accepted. The only accepted resources are %s", resource, AcceptedResources)
+		}
+	}
+	return lo.Uniq(resources), nil
+}
+
+// Importer discovers and imports the objects of one organization
+type Importer struct {
+	opts           Options
+	host           string
+	resources      []string
+	platformClient platform.ClientWithResponsesInterface
+	iamClient      iam.ClientWithResponsesInterface
+	logger         *log.Logger
+}
+
+// New validates the options and creates the API clients of an Importer
+func New(opts Options) (*Importer, error) {
+	if opts.OrganizationId == "" {
+		return nil, fmt.Errorf("organization ID not provided")
+	}
+
+	host, err := ResolveHost(opts.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := ValidateResources(opts.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.OutputDir == "" {
+		opts.OutputDir = "."
+	}
+	if opts.ClientVersion == "" {
+		opts.ClientVersion = "import"
+	}
+	if opts.TerraformOutput == nil {
+		opts.TerraformOutput = os.Stdout
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+
+	platformClient := opts.PlatformClient
+	iamClient := opts.IamClient
+	if platformClient == nil || iamClient == nil {
+		if opts.Token == "" {
+			return nil, fmt.Errorf("API token not provided")
+		}
+	}
+	if platformClient == nil {
+		platformClient, err = platform.NewPlatformClient(host, clients.StaticToken(opts.Token), opts.ClientVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create platform client: %v", err)
+		}
+	}
+	if iamClient == nil {
+		iamClient, err = iam.NewIamClient(host, clients.StaticToken(opts.Token), opts.ClientVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create iam client: %v", err)
+		}
+	}
+
+	return &Importer{
+		opts:           opts,
+		host:           host,
+		resources:      resources,
+		platformClient: platformClient,
+		iamClient:      iamClient,
+		logger:         logger,
+	}, nil
+}
+
+// Host returns the resolved API host
+func (i *Importer) Host() string {
+	return i.host
+}
+
+// Discover lists the objects of every requested resource concurrently and returns their import blocks without writing any files
+func (i *Importer) Discover(ctx context.Context) *Result {
+	result := &Result{
+		OrganizationId: i.opts.OrganizationId,
+		Host:           i.host,
+		Resources:      make([]ResourceResult, len(i.resources)),
+	}
+
+	var wg sync.WaitGroup
+	for idx, resource := range i.resources {
+		wg.Add(1)
+		go func(idx int, resource string) {
+			defer wg.Done()
+			blocks, err := ResourceHandlers[resource](ctx, i.platformClient, i.iamClient, i.opts.OrganizationId)
+			if err != nil {
+				i.logger.Printf("Error handling resource %s: %v", resource, err)
+			} else {
+				i.logger.Printf("Successfully handled resource %s: %v", resource, lo.Map(blocks, func(block ImportBlock, _ int) string {
+					return block.Address()
+				}))
+			}
+			result.Resources[idx] = ResourceResult{Resource: resource, ImportBlocks: blocks, Err: err}
+		}(idx, resource)
+	}
+	wg.Wait()
+
+	return result
+}
+
+// Run discovers the requested resources, writes the import blocks to import.tf and generates their configuration
+// in generated.tf with `terraform plan -generate-config-out`
+//
+// In incremental mode, objects already managed by the configuration or state of the output directory are skipped and
+// the new objects are written to timestamped import and generated files, leaving the existing files untouched
+func (i *Importer) Run(ctx context.Context) (*Result, error) {
+	i.logger.Printf("Using organization ID: %s", i.opts.OrganizationId)
+
+	version, err := CheckTerraformVersion()
+	if err != nil {
+		return nil, err
+	}
+	i.logger.Printf("Terraform version %s is installed and meets the minimum required version.", version)
+
+	result := i.Discover(ctx)
+
+	importFileName := ImportFileName
+	generatedFileName := GeneratedFileName
+	providerConfig := ProviderConfig(i.opts.OrganizationId, i.host)
+	if i.opts.Incremental {
+		managed, err := i.loadManagedObjects()
+		if err != nil {
+			return result, fmt.Errorf("failed to load existing configuration: %v", err)
+		}
+		i.logger.Printf("Found %d managed objects in %s", managed.Len(), i.opts.OutputDir)
+
+		result.SkipManaged(managed)
+		if len(result.ImportBlocks()) == 0 {
+			i.logger.Println("No new resources to import")
+			return result, nil
+		}
+
+		if managed.HasProvider {
+			providerConfig = ""
+		}
+		suffix := time.Now().UTC().Format("20060102150405")
+		importFileName = fmt.Sprintf("import_%s.tf", suffix)
+		generatedFileName = fmt.Sprintf("generated_%s.tf", suffix)
+	}
+
+	// deployments are generated manually because generating their configuration with terraform does not work
+	var importString string
+	var deploymentBlocks []ImportBlock
+	for _, resource := range result.Resources {
+		if resource.Err != nil {
+			continue
+		}
+		if resource.Resource == "deployment" {
+			deploymentBlocks = append(deploymentBlocks, resource.ImportBlocks...)
+		} else {
+			importString += RenderImportBlocks(resource.ImportBlocks)
+		}
+	}
+
+	if providerConfig != "" || importString != "" {
+		importFile := filepath.Join(i.opts.OutputDir, importFileName)
+		err = os.WriteFile(importFile, []byte(providerConfig+importString), 0644)
+		if err != nil {
+			return result, fmt.Errorf("failed to write import configuration to file: %v", err)
+		}
+		result.ImportFile = importFile
+		i.logger.Printf("Successfully wrote import configuration to %s", importFile)
+	}
+
+	if i.opts.RunTerraformInit {
+		i.logger.Println("Running terraform init")
+		if err = i.runTerraform("init"); err != nil {
+			return result, fmt.Errorf("failed to run terraform init: %v", err)
+		}
+	}
+
+	if !i.opts.Incremental {
+		if err = i.removeGeneratedFiles(); err != nil {
+			return result, err
+		}
+	}
+
+	generatedFile := filepath.Join(i.opts.OutputDir, generatedFileName)
+	if importString != "" {
+		if err = i.runTerraform("plan", "-generate-config-out="+generatedFileName); err != nil {
+			return result, fmt.Errorf("failed to run Terraform command: %v", err)
+		}
+		result.GeneratedFile = generatedFile
+	}
+
+	if len(deploymentBlocks) > 0 {
+		deploymentIds := lo.Map(deploymentBlocks, func(block ImportBlock, _ int) string {
+			return block.Id
+		})
+		deploymentHCL, err := GenerateDeploymentHCL(ctx, i.platformClient, i.opts.OrganizationId, deploymentIds)
+		if err != nil {
+			return result, fmt.Errorf("failed to generate deployment HCL: %v", err)
+		}
+		if err = appendToFile(generatedFile, "// generated Deployment HCL \n"+strings.TrimSpace(RenderImportBlocks(deploymentBlocks))+"\n\n"+strings.TrimSpace(deploymentHCL)); err != nil {
+			return result, fmt.Errorf("failed to add deployments to generated file: %v", err)
+		}
+		result.GeneratedFile = generatedFile
+		i.logger.Printf("Successfully updated %s with deployment information.", generatedFile)
+	}
+
+	return result, nil
+}
+
+// loadManagedObjects loads the objects managed by the output directory, the state is pulled from the configured
+// backend if there is no local state file
+func (i *Importer) loadManagedObjects() (*ManagedObjects, error) {
+	managed, err := LoadManagedObjects(i.opts.OutputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = os.Stat(filepath.Join(i.opts.OutputDir, StateFileName)); os.IsNotExist(err) {
+		cmd := exec.Command("terraform", "state", "pull")
+		cmd.Dir = i.opts.OutputDir
+		if output, err := cmd.Output(); err != nil {
+			i.logger.Printf("Unable to pull state, only the configuration is used to find managed objects: %v", err)
+		} else if err = managed.AddState(output); err != nil {
+			return nil, err
+		}
+	}
+
+	return managed, nil
+}
+
+// ProviderConfig returns the terraform and provider blocks for the organization
+func ProviderConfig(organizationId, host string) string {
+	return fmt.Sprintf(`terraform {
+	required_providers {
+		astro = {
+			source = "astronomer/astro"
+		}
+	}
+}
+
+provider "astro" {
+	organization_id = "%s"
+	host = "%s"
+}
+`, organizationId, host)
+}
+
+// removeGeneratedFiles deletes the generated.tf and state files of a previous import if they exist
+func (i *Importer) removeGeneratedFiles() error {
+	filenames := []string{GeneratedFileName, StateFileName}
+	for _, filename := range filenames {
+		path := filepath.Join(i.opts.OutputDir, filename)
+		if err := os.Remove(path); err == nil {
+			i.logger.Printf("Successfully deleted %s", path)
+		} else if os.IsNotExist(err) {
+			i.logger.Printf("%s does not exist, no need to delete", path)
+		} else {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendToFile appends content to a file, separated from any existing content by an empty line
+func appendToFile(path string, content string) error {
+	contentBytes, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading %s: %v", path, err)
+	}
+
+	newContent := strings.TrimSpace(string(contentBytes))
+	if newContent != "" {
+		newContent += "\n\n"
+	}
+	newContent += content
+
+	return os.WriteFile(path, []byte(newContent), 0644)
+}
@@ -0,0 +1,563 @@
+package importer_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/astronomer/terraform-provider-astro/import/importer"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	mocks_iam "github.com/astronomer/terraform-provider-astro/internal/mocks/iam"
+	mocks_platform "github.com/astronomer/terraform-provider-astro/internal/mocks/platform"
+	"github.com/lucsky/cuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Handlers", func() {
+	var ctx context.Context
+	var mockPlatformClient *mocks_platform.ClientWithResponsesInterface
+	var mockIAMClient *mocks_iam.ClientWithResponsesInterface
+	var organizationId string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mockPlatformClient = new(mocks_platform.ClientWithResponsesInterface)
+		mockIAMClient = new(mocks_iam.ClientWithResponsesInterface)
+		organizationId = cuid.New()
+	})
+
+	Describe("HandleWorkspaces", func() {
+		It("should return an error if the platform client returns an error", func() {
+			mockPlatformClient.On("ListWorkspacesWithResponse", ctx, organizationId, mock.Anything).Return(nil, fmt.Errorf("error"))
+
+			result, err := importer.HandleWorkspaces(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return an error if the platform client returns a non-200 status code", func() {
+			mockResponse := &platform.ListWorkspacesResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusInternalServerError},
+			}
+
+			mockPlatformClient.On("ListWorkspacesWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleWorkspaces(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return a list of workspace resources", func() {
+			workspaceId1 := cuid.New()
+			workspaceId2 := cuid.New()
+
+			workspaces := []platform.Workspace{
+				{Id: workspaceId1},
+				{Id: workspaceId2},
+			}
+
+			mockResponse := &platform.ListWorkspacesResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200: &platform.WorkspacesPaginated{
+					Workspaces: workspaces,
+				},
+			}
+
+			mockPlatformClient.On("ListWorkspacesWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleWorkspaces(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).To(BeNil())
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_workspace.workspace_%s", workspaceId1)))
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_workspace.workspace_%s", workspaceId2)))
+		})
+	})
+
+	Describe("HandleDeployments", func() {
+		It("should return an error if the platform client returns an error", func() {
+			mockPlatformClient.On("ListDeploymentsWithResponse", ctx, organizationId, mock.Anything).Return(nil, fmt.Errorf("error"))
+
+			result, err := importer.HandleDeployments(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return an error if the platform client returns a non-200 status code", func() {
+			mockResponse := &platform.ListDeploymentsResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusInternalServerError},
+			}
+
+			mockPlatformClient.On("ListDeploymentsWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleDeployments(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return a list of deployment resources", func() {
+			deploymentId1 := cuid.New()
+			deploymentId2 := cuid.New()
+
+			deployments := []platform.Deployment{
+				{Id: deploymentId1},
+				{Id: deploymentId2},
+			}
+
+			mockResponse := &platform.ListDeploymentsResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200: &platform.DeploymentsPaginated{
+					Deployments: deployments,
+				},
+			}
+
+			mockPlatformClient.On("ListDeploymentsWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleDeployments(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).To(BeNil())
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_deployment.deployment_%s", deploymentId1)))
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_deployment.deployment_%s", deploymentId2)))
+		})
+	})
+
+	Describe("HandleClusters", func() {
+		It("should return an error if the platform client returns an error", func() {
+			mockPlatformClient.On("ListClustersWithResponse", ctx, organizationId, mock.Anything).Return(nil, fmt.Errorf("error"))
+
+			result, err := importer.HandleClusters(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return an error if the platform client returns a non-200 status code", func() {
+			mockResponse := &platform.ListClustersResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusInternalServerError},
+			}
+
+			mockPlatformClient.On("ListClustersWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleClusters(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return a list of cluster resources", func() {
+			clusterId1 := cuid.New()
+			clusterId2 := cuid.New()
+			workspaceId := cuid.New()
+
+			clusters := []platform.Cluster{
+				{Id: clusterId1},
+				{Id: clusterId2,
+					Type:         platform.ClusterTypeHYBRID,
+					WorkspaceIds: &[]string{workspaceId}},
+			}
+
+			mockResponse := &platform.ListClustersResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200: &platform.ClustersPaginated{
+					Clusters: clusters,
+				},
+			}
+
+			mockPlatformClient.On("ListClustersWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleClusters(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).To(BeNil())
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_cluster.cluster_%s", clusterId1)))
+
+			// Hybrid clusters are not managed by astro_cluster
+			Expect(addresses(result)).ToNot(ContainSubstring(fmt.Sprintf("astro_cluster.cluster_%s", clusterId2)))
+			Expect(addresses(result)).ToNot(ContainSubstring("astro_hybrid_cluster_workspace_authorization"))
+		})
+	})
+
+	Describe("HandleHybridClusterWorkspaceAuthorizations", func() {
+		It("should return an error if the platform client returns an error", func() {
+			mockPlatformClient.On("ListClustersWithResponse", ctx, organizationId, mock.Anything).Return(nil, fmt.Errorf("error"))
+
+			result, err := importer.HandleHybridClusterWorkspaceAuthorizations(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return an error if the platform client returns a non-200 status code", func() {
+			mockResponse := &platform.ListClustersResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusInternalServerError},
+			}
+
+			mockPlatformClient.On("ListClustersWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleHybridClusterWorkspaceAuthorizations(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return a list of hybrid cluster workspace authorization resources", func() {
+			dedicatedClusterId := cuid.New()
+			hybridClusterId := cuid.New()
+			unauthorizedHybridClusterId := cuid.New()
+			workspaceId := cuid.New()
+
+			clusters := []platform.Cluster{
+				{Id: dedicatedClusterId,
+					Type:         platform.ClusterTypeDEDICATED,
+					WorkspaceIds: &[]string{workspaceId}},
+				{Id: hybridClusterId,
+					Type:         platform.ClusterTypeHYBRID,
+					WorkspaceIds: &[]string{workspaceId}},
+				{Id: unauthorizedHybridClusterId,
+					Type:         platform.ClusterTypeHYBRID,
+					WorkspaceIds: &[]string{}},
+			}
+
+			mockResponse := &platform.ListClustersResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200: &platform.ClustersPaginated{
+					Clusters: clusters,
+				},
+			}
+
+			mockPlatformClient.On("ListClustersWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleHybridClusterWorkspaceAuthorizations(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).To(BeNil())
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_hybrid_cluster_workspace_authorization.cluster_%s", hybridClusterId)))
+			Expect(addresses(result)).ToNot(ContainSubstring(dedicatedClusterId))
+			Expect(addresses(result)).ToNot(ContainSubstring(unauthorizedHybridClusterId))
+			Expect(addresses(result)).ToNot(ContainSubstring("astro_cluster."))
+		})
+	})
+
+	Describe("HandleApiTokens", func() {
+		It("should return an error if the iam client returns an error", func() {
+			mockIAMClient.On("ListApiTokensWithResponse", ctx, organizationId, mock.Anything).Return(nil, fmt.Errorf("error"))
+
+			result, err := importer.HandleApiTokens(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return an error if the iam client returns a non-200 status code", func() {
+			mockResponse := &iam.ListApiTokensResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusInternalServerError},
+			}
+
+			mockIAMClient.On("ListApiTokensWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleApiTokens(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return a list of api token resources", func() {
+			apiTokenId1 := cuid.New()
+			apiTokenId2 := cuid.New()
+
+			apiTokens := []iam.ApiToken{
+				{Id: apiTokenId1},
+				{Id: apiTokenId2},
+			}
+
+			mockResponse := &iam.ListApiTokensResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200: &iam.ApiTokensPaginated{
+					Tokens: apiTokens,
+				},
+			}
+
+			mockIAMClient.On("ListApiTokensWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleApiTokens(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).To(BeNil())
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_api_token.api_token_%s", apiTokenId1)))
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_api_token.api_token_%s", apiTokenId2)))
+		})
+	})
+
+	Describe("HandleTeams", func() {
+		mockOrganization := func(isScimEnabled bool) {
+			mockResponse := &platform.GetOrganizationResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200:      &platform.Organization{IsScimEnabled: isScimEnabled},
+			}
+			mockPlatformClient.On("GetOrganizationWithResponse", ctx, organizationId, (*platform.GetOrganizationParams)(nil)).Return(mockResponse, nil)
+		}
+
+		It("should return an error if SCIM is enabled for the organization", func() {
+			mockOrganization(true)
+
+			result, err := importer.HandleTeams(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return an error if the iam client returns an error", func() {
+			mockIAMClient.On("ListTeamsWithResponse", ctx, organizationId, mock.Anything).Return(nil, fmt.Errorf("error"))
+
+			mockOrganization(false)
+			result, err := importer.HandleTeams(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return an error if the iam client returns a non-200 status code", func() {
+			mockResponse := &iam.ListTeamsResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusInternalServerError},
+			}
+
+			mockIAMClient.On("ListTeamsWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			mockOrganization(false)
+			result, err := importer.HandleTeams(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return a list of team resources", func() {
+			teamId1 := cuid.New()
+			teamId2 := cuid.New()
+
+			teams := []iam.Team{
+				{Id: teamId1},
+				{Id: teamId2},
+			}
+
+			mockResponse := &iam.ListTeamsResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200: &iam.TeamsPaginated{
+					Teams: teams,
+				},
+			}
+
+			mockIAMClient.On("ListTeamsWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			mockOrganization(false)
+			result, err := importer.HandleTeams(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).To(BeNil())
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_team.team_%s", teamId1)))
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_team.team_%s", teamId2)))
+		})
+	})
+
+	Describe("HandleTeamRoles", func() {
+		It("should return an error if the iam client returns an error", func() {
+			mockIAMClient.On("ListTeamsWithResponse", ctx, organizationId, mock.Anything).Return(nil, fmt.Errorf("error"))
+
+			result, err := importer.HandleTeamRoles(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return an error if the iam client returns a non-200 status code", func() {
+			mockResponse := &iam.ListTeamsResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusInternalServerError},
+			}
+
+			mockIAMClient.On("ListTeamsWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleTeamRoles(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return a list of team role resources", func() {
+			teamId1 := cuid.New()
+			teamId2 := cuid.New()
+
+			teams := []iam.Team{
+				{Id: teamId1},
+				{Id: teamId2},
+			}
+
+			mockResponse := &iam.ListTeamsResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200: &iam.TeamsPaginated{
+					Teams: teams,
+				},
+			}
+
+			mockIAMClient.On("ListTeamsWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleTeamRoles(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).To(BeNil())
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_team_roles.team_%s", teamId1)))
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_team_roles.team_%s", teamId2)))
+		})
+	})
+
+	Describe("HandleUserRoles", func() {
+		It("should return an error if the iam client returns an error", func() {
+			mockIAMClient.On("ListUsersWithResponse", ctx, organizationId, mock.Anything).Return(nil, fmt.Errorf("error"))
+
+			result, err := importer.HandleUserRoles(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return an error if the iam client returns a non-200 status code", func() {
+			mockResponse := &iam.ListUsersResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusInternalServerError},
+			}
+
+			mockIAMClient.On("ListUsersWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleUserRoles(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should return a list of user resources", func() {
+			userId1 := cuid.New()
+			userId2 := cuid.New()
+
+			users := []iam.User{
+				{Id: userId1},
+				{Id: userId2},
+			}
+
+			mockResponse := &iam.ListUsersResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200: &iam.UsersPaginated{
+					Users: users,
+				},
+			}
+
+			mockIAMClient.On("ListUsersWithResponse", ctx, organizationId, mock.Anything).Return(mockResponse, nil)
+
+			result, err := importer.HandleUserRoles(ctx, mockPlatformClient, mockIAMClient, organizationId)
+
+			Expect(err).To(BeNil())
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_user_roles.user_%s", userId1)))
+			Expect(addresses(result)).To(ContainSubstring(fmt.Sprintf("astro_user_roles.user_%s", userId2)))
+		})
+	})
+})
+
+// addresses returns the resource addresses of the import blocks
+func addresses(blocks []importer.ImportBlock) string {
+	return strings.Join(lo.Map(blocks, func(block importer.ImportBlock, _ int) string {
+		return block.Address()
+	}), "\n")
+}
+
+var _ = Describe("ResolveHost", func() {
+	DescribeTable("resolves the host",
+		func(host, expected string) {
+			resolved, err := importer.ResolveHost(host)
+			Expect(err).To(BeNil())
+			Expect(resolved).To(Equal(expected))
+		},
+		Entry("empty", "", importer.ProdHost),
+		Entry("prod", "prod", importer.ProdHost),
+		Entry("dev", "dev", importer.DevHost),
+		Entry("stage", "stage", importer.StageHost),
+		Entry("url", "https://api.astronomer.io", importer.ProdHost),
+		Entry("pr url with trailing slash", "https://pr1234api.astronomer-dev.io/", "https://pr1234api.astronomer-dev.io"),
+		Entry("local url", "http://localhost:8080", "http://localhost:8080"),
+	)
+
+	DescribeTable("returns an error for an invalid host",
+		func(host string) {
+			_, err := importer.ResolveHost(host)
+			Expect(err).ToNot(BeNil())
+		},
+		Entry("unknown alias", "production"),
+		Entry("missing scheme", "api.astronomer.io"),
+		Entry("unsupported scheme", "ftp://api.astronomer.io"),
+	)
+})
+
+var _ = Describe("Importer", func() {
+	var ctx context.Context
+	var mockPlatformClient *mocks_platform.ClientWithResponsesInterface
+	var mockIAMClient *mocks_iam.ClientWithResponsesInterface
+	var organizationId string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mockPlatformClient = new(mocks_platform.ClientWithResponsesInterface)
+		mockIAMClient = new(mocks_iam.ClientWithResponsesInterface)
+		organizationId = cuid.New()
+	})
+
+	It("should return an error if a resource is not accepted", func() {
+		_, err := importer.New(importer.Options{
+			OrganizationId: organizationId,
+			Resources:      []string{"workspace", "organization"},
+			PlatformClient: mockPlatformClient,
+			IamClient:      mockIAMClient,
+		})
+
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should return an error if no token or clients are provided", func() {
+		_, err := importer.New(importer.Options{
+			OrganizationId: organizationId,
+		})
+
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should discover the requested resources in order", func() {
+		workspaceId := cuid.New()
+		mockPlatformClient.On("ListWorkspacesWithResponse", ctx, organizationId, mock.Anything).Return(&platform.ListWorkspacesResponse{
+			HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+			JSON200:      &platform.WorkspacesPaginated{Workspaces: []platform.Workspace{{Id: workspaceId}}},
+		}, nil)
+		mockIAMClient.On("ListApiTokensWithResponse", ctx, organizationId, mock.Anything).Return(nil, fmt.Errorf("error"))
+
+		imp, err := importer.New(importer.Options{
+			OrganizationId: organizationId,
+			Host:           "https://api.example.com/",
+			Resources:      []string{"Workspace", "api_token"},
+			PlatformClient: mockPlatformClient,
+			IamClient:      mockIAMClient,
+		})
+		Expect(err).To(BeNil())
+		Expect(imp.Host()).To(Equal("https://api.example.com"))
+
+		result := imp.Discover(ctx)
+
+		Expect(result.Resources).To(HaveLen(2))
+		Expect(result.Resources[0].Resource).To(Equal("workspace"))
+		Expect(result.Resources[0].Err).To(BeNil())
+		Expect(result.Resources[1].Resource).To(Equal("api_token"))
+		Expect(result.Resources[1].Err).ToNot(BeNil())
+		Expect(result.Failed()).To(HaveLen(1))
+		Expect(result.ImportBlocks()).To(Equal([]importer.ImportBlock{{
+			ResourceType: "astro_workspace",
+			ResourceName: fmt.Sprintf("workspace_%s", workspaceId),
+			Id:           workspaceId,
+		}}))
+		Expect(importer.RenderImportBlocks(result.ImportBlocks())).To(ContainSubstring(fmt.Sprintf("id = \"%s\"\n\tto = astro_workspace.workspace_%s", workspaceId, workspaceId)))
+	})
+})
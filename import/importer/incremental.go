@@ -0,0 +1,144 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// StateFileName is the local state file of the Terraform working directory
+const StateFileName = "terraform.tfstate"
+
+// ManagedObjects holds the Astro objects already managed by the configuration and state of a Terraform working directory
+type ManagedObjects struct {
+	// HasProvider is true if the configuration already declares the astro provider
+	HasProvider bool
+	addresses   map[string]bool
+	ids         map[string]bool
+}
+
+// NewManagedObjects returns an empty ManagedObjects
+func NewManagedObjects() *ManagedObjects {
+	return &ManagedObjects{
+		addresses: map[string]bool{},
+		ids:       map[string]bool{},
+	}
+}
+
+func managedIdKey(resourceType, id string) string {
+	return resourceType + "/" + id
+}
+
+// IsManaged returns true if the object of the import block is already imported or its address is already declared
+func (m *ManagedObjects) IsManaged(block ImportBlock) bool {
+	return m.addresses[block.Address()] || m.ids[managedIdKey(block.ResourceType, block.Id)]
+}
+
+// Len returns the number of managed objects found
+func (m *ManagedObjects) Len() int {
+	return len(m.ids)
+}
+
+// LoadManagedObjects parses the `.tf` files and the local state file of a Terraform working directory
+func LoadManagedObjects(dir string) (*ManagedObjects, error) {
+	managed := NewManagedObjects()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	parser := hclparse.NewParser()
+	for _, path := range paths {
+		if err = managed.AddConfigFile(parser, path); err != nil {
+			return nil, err
+		}
+	}
+
+	state, err := os.ReadFile(filepath.Join(dir, StateFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return managed, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %v", StateFileName, err)
+	}
+	if err = managed.AddState(state); err != nil {
+		return nil, err
+	}
+
+	return managed, nil
+}
+
+// AddConfigFile adds the resources and import blocks declared in a Terraform configuration file
+func (m *ManagedObjects) AddConfigFile(parser *hclparse.Parser, path string) error {
+	file, diags := parser.ParseHCLFile(path)
+	if diags.HasErrors() {
+		return fmt.Errorf("failed to parse %s: %v", path, diags.Error())
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return fmt.Errorf("failed to parse %s: unexpected body type", path)
+	}
+
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "provider":
+			if len(block.Labels) == 1 && block.Labels[0] == "astro" {
+				m.HasProvider = true
+			}
+		case "resource":
+			if len(block.Labels) == 2 {
+				m.addresses[block.Labels[0]+"."+block.Labels[1]] = true
+			}
+		case "import":
+			to, ok := block.Body.Attributes["to"]
+			if !ok {
+				continue
+			}
+			traversal, diags := hcl.AbsTraversalForExpr(to.Expr)
+			if diags.HasErrors() || len(traversal) < 2 {
+				continue
+			}
+			name, ok := traversal[1].(hcl.TraverseAttr)
+			if !ok {
+				continue
+			}
+			resourceType := traversal.RootName()
+			m.addresses[resourceType+"."+name.Name] = true
+
+			// import IDs built from expressions cannot be evaluated without the rest of the configuration
+			id, ok := block.Body.Attributes["id"]
+			if !ok {
+				continue
+			}
+			value, diags := id.Expr.Value(nil)
+			if diags.HasErrors() || !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+				continue
+			}
+			m.ids[managedIdKey(resourceType, value.AsString())] = true
+		}
+	}
+
+	return nil
+}
+
+// AddState adds the resources of a Terraform state, as written to terraform.tfstate or by `terraform state pull`
+func (m *ManagedObjects) AddState(stateBytes []byte) error {
+	objects, err := ParseState(stateBytes)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		m.addresses[object.ResourceType+"."+object.Name] = true
+		if object.Id != "" {
+			m.ids[managedIdKey(object.ResourceType, object.Id)] = true
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package importer_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/astronomer/terraform-provider-astro/import/importer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Incremental", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	writeFile := func(name, content string) {
+		Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)).To(Succeed())
+	}
+
+	block := func(resourceType, namePrefix, id string) importer.ImportBlock {
+		return importer.ImportBlock{ResourceType: resourceType, ResourceName: namePrefix + "_" + id, Id: id}
+	}
+
+	It("should find objects imported with import blocks and declared resources", func() {
+		writeFile("import.tf", importer.ProviderConfig("org", importer.ProdHost)+importer.RenderImportBlocks([]importer.ImportBlock{
+			block("astro_workspace", "workspace", "ws1"),
+			{ResourceType: "astro_team_roles", ResourceName: "data_team", Id: "team1"},
+		}))
+		writeFile("main.tf", `
+resource "astro_cluster" "cluster_c1" {
+  name = "hand edited"
+}
+
+import {
+  id = var.deployment_id
+  to = astro_deployment.prod
+}
+`)
+
+		managed, err := importer.LoadManagedObjects(dir)
+
+		Expect(err).To(BeNil())
+		Expect(managed.HasProvider).To(BeTrue())
+		Expect(managed.Len()).To(Equal(2))
+		Expect(managed.IsManaged(block("astro_workspace", "workspace", "ws1"))).To(BeTrue())
+		Expect(managed.IsManaged(block("astro_team_roles", "team", "team1"))).To(BeTrue())
+		Expect(managed.IsManaged(block("astro_cluster", "cluster", "c1"))).To(BeTrue())
+		Expect(managed.IsManaged(block("astro_workspace", "workspace", "ws2"))).To(BeFalse())
+		Expect(managed.IsManaged(block("astro_team", "team", "team1"))).To(BeFalse())
+	})
+
+	It("should find objects in the state by their import ID", func() {
+		writeFile(importer.StateFileName, `{
+  "version": 4,
+  "resources": [
+    {"mode": "managed", "type": "astro_user_roles", "name": "admin", "instances": [{"attributes": {"user_id": "u1"}}]},
+    {"mode": "managed", "type": "astro_deployment", "name": "prod", "instances": [{"attributes": {"id": "d1"}}]},
+    {"mode": "data", "type": "astro_workspace", "name": "lookup", "instances": [{"attributes": {"id": "ws1"}}]}
+  ]
+}`)
+
+		managed, err := importer.LoadManagedObjects(dir)
+
+		Expect(err).To(BeNil())
+		Expect(managed.HasProvider).To(BeFalse())
+		Expect(managed.IsManaged(block("astro_user_roles", "user", "u1"))).To(BeTrue())
+		Expect(managed.IsManaged(block("astro_deployment", "deployment", "d1"))).To(BeTrue())
+		Expect(managed.IsManaged(block("astro_workspace", "workspace", "ws1"))).To(BeFalse())
+	})
+
+	It("should return an error if a configuration file cannot be parsed", func() {
+		writeFile("broken.tf", `resource "astro_workspace" {`)
+
+		_, err := importer.LoadManagedObjects(dir)
+
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should skip managed objects", func() {
+		managed := importer.NewManagedObjects()
+		Expect(managed.AddState([]byte(`{"resources": [{"mode": "managed", "type": "astro_workspace", "name": "ws", "instances": [{"attributes": {"id": "ws1"}}]}]}`))).To(Succeed())
+
+		result := &importer.Result{Resources: []importer.ResourceResult{{
+			Resource:     "workspace",
+			ImportBlocks: []importer.ImportBlock{block("astro_workspace", "workspace", "ws1"), block("astro_workspace", "workspace", "ws2")},
+		}}}
+		result.SkipManaged(managed)
+
+		Expect(result.ImportBlocks()).To(Equal([]importer.ImportBlock{block("astro_workspace", "workspace", "ws2")}))
+		Expect(result.Resources[0].Managed).To(Equal([]importer.ImportBlock{block("astro_workspace", "workspace", "ws1")}))
+	})
+})
@@ -0,0 +1,219 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/samber/lo"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+)
+
+// pageSize is the number of objects requested per page when listing
+const pageSize = 1000
+
+// ListWorkspaces returns all workspaces in the organization
+func ListWorkspaces(ctx context.Context, platformClient platform.ClientWithResponsesInterface, organizationId string) ([]platform.Workspace, error) {
+	var workspaces []platform.Workspace
+	offset := 0
+	for {
+		workspacesResp, err := platformClient.ListWorkspacesWithResponse(ctx, organizationId, &platform.ListWorkspacesParams{
+			Limit:  lo.ToPtr(pageSize),
+			Offset: lo.ToPtr(offset),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workspaces: %v", err)
+		}
+
+		if workspacesResp.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d, body: %s", workspacesResp.StatusCode(), string(workspacesResp.Body))
+		}
+
+		if workspacesResp.JSON200 == nil {
+			return nil, fmt.Errorf("failed to list workspaces, JSON200 resp is nil, organizationId: %v", organizationId)
+		}
+
+		if workspacesResp.JSON200.Workspaces == nil {
+			return nil, fmt.Errorf("workspaces list is nil")
+		}
+
+		workspaces = append(workspaces, workspacesResp.JSON200.Workspaces...)
+
+		offset += len(workspacesResp.JSON200.Workspaces)
+		if len(workspacesResp.JSON200.Workspaces) == 0 || offset >= workspacesResp.JSON200.TotalCount {
+			return workspaces, nil
+		}
+	}
+}
+
+// ListDeployments returns all deployments in the organization
+func ListDeployments(ctx context.Context, platformClient platform.ClientWithResponsesInterface, organizationId string) ([]platform.Deployment, error) {
+	var deployments []platform.Deployment
+	offset := 0
+	for {
+		deploymentsResp, err := platformClient.ListDeploymentsWithResponse(ctx, organizationId, &platform.ListDeploymentsParams{
+			Limit:  lo.ToPtr(pageSize),
+			Offset: lo.ToPtr(offset),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deployments: %v", err)
+		}
+
+		if deploymentsResp.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d, body: %s", deploymentsResp.StatusCode(), string(deploymentsResp.Body))
+		}
+
+		if deploymentsResp.JSON200 == nil {
+			return nil, fmt.Errorf("failed to list deployments, JSON200 resp is nil, organizationId: %v", organizationId)
+		}
+
+		if deploymentsResp.JSON200.Deployments == nil {
+			return nil, fmt.Errorf("deployments list is nil")
+		}
+
+		deployments = append(deployments, deploymentsResp.JSON200.Deployments...)
+
+		offset += len(deploymentsResp.JSON200.Deployments)
+		if len(deploymentsResp.JSON200.Deployments) == 0 || offset >= deploymentsResp.JSON200.TotalCount {
+			return deployments, nil
+		}
+	}
+}
+
+// ListClusters returns all clusters in the organization
+func ListClusters(ctx context.Context, platformClient platform.ClientWithResponsesInterface, organizationId string) ([]platform.Cluster, error) {
+	var clusters []platform.Cluster
+	offset := 0
+	for {
+		clustersResp, err := platformClient.ListClustersWithResponse(ctx, organizationId, &platform.ListClustersParams{
+			Limit:  lo.ToPtr(pageSize),
+			Offset: lo.ToPtr(offset),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %v", err)
+		}
+
+		if clustersResp.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d, body: %s", clustersResp.StatusCode(), string(clustersResp.Body))
+		}
+
+		if clustersResp.JSON200 == nil {
+			return nil, fmt.Errorf("failed to list clusters, JSON200 resp is nil, organizationId: %v", organizationId)
+		}
+
+		if clustersResp.JSON200.Clusters == nil {
+			return nil, fmt.Errorf("clusters list is nil")
+		}
+
+		clusters = append(clusters, clustersResp.JSON200.Clusters...)
+
+		offset += len(clustersResp.JSON200.Clusters)
+		if len(clustersResp.JSON200.Clusters) == 0 || offset >= clustersResp.JSON200.TotalCount {
+			return clusters, nil
+		}
+	}
+}
+
+// ListTeams returns all teams in the organization
+func ListTeams(ctx context.Context, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]iam.Team, error) {
+	var teams []iam.Team
+	offset := 0
+	for {
+		teamsResp, err := iamClient.ListTeamsWithResponse(ctx, organizationId, &iam.ListTeamsParams{
+			Limit:  lo.ToPtr(pageSize),
+			Offset: lo.ToPtr(offset),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list teams: %v", err)
+		}
+
+		if teamsResp.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d, body: %s", teamsResp.StatusCode(), string(teamsResp.Body))
+		}
+
+		if teamsResp.JSON200 == nil {
+			return nil, fmt.Errorf("failed to list teams, JSON200 resp is nil, organizationId: %v", organizationId)
+		}
+
+		if teamsResp.JSON200.Teams == nil {
+			return nil, fmt.Errorf("teams list is nil")
+		}
+
+		teams = append(teams, teamsResp.JSON200.Teams...)
+
+		offset += len(teamsResp.JSON200.Teams)
+		if len(teamsResp.JSON200.Teams) == 0 || offset >= teamsResp.JSON200.TotalCount {
+			return teams, nil
+		}
+	}
+}
+
+// ListUsers returns all users in the organization
+func ListUsers(ctx context.Context, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]iam.User, error) {
+	var users []iam.User
+	offset := 0
+	for {
+		usersResp, err := iamClient.ListUsersWithResponse(ctx, organizationId, &iam.ListUsersParams{
+			Limit:  lo.ToPtr(pageSize),
+			Offset: lo.ToPtr(offset),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %v", err)
+		}
+
+		if usersResp.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d, body: %s", usersResp.StatusCode(), string(usersResp.Body))
+		}
+
+		if usersResp.JSON200 == nil {
+			return nil, fmt.Errorf("failed to list users, JSON200 resp is nil, organizationId: %v", organizationId)
+		}
+
+		if usersResp.JSON200.Users == nil {
+			return nil, fmt.Errorf("users list is nil")
+		}
+
+		users = append(users, usersResp.JSON200.Users...)
+
+		offset += len(usersResp.JSON200.Users)
+		if len(usersResp.JSON200.Users) == 0 || offset >= usersResp.JSON200.TotalCount {
+			return users, nil
+		}
+	}
+}
+
+// ListApiTokens returns all API tokens in the organization
+func ListApiTokens(ctx context.Context, iamClient iam.ClientWithResponsesInterface, organizationId string) ([]iam.ApiToken, error) {
+	var apiTokens []iam.ApiToken
+	offset := 0
+	for {
+		apiTokensResp, err := iamClient.ListApiTokensWithResponse(ctx, organizationId, &iam.ListApiTokensParams{
+			Limit:  lo.ToPtr(pageSize),
+			Offset: lo.ToPtr(offset),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list API tokens: %v", err)
+		}
+
+		if apiTokensResp.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d, body: %s", apiTokensResp.StatusCode(), string(apiTokensResp.Body))
+		}
+
+		if apiTokensResp.JSON200 == nil {
+			return nil, fmt.Errorf("failed to list API tokens, JSON200 resp is nil, organizationId: %v", organizationId)
+		}
+
+		if apiTokensResp.JSON200.Tokens == nil {
+			return nil, fmt.Errorf("API tokens list is nil")
+		}
+
+		apiTokens = append(apiTokens, apiTokensResp.JSON200.Tokens...)
+
+		offset += len(apiTokensResp.JSON200.Tokens)
+		if len(apiTokensResp.JSON200.Tokens) == 0 || offset >= apiTokensResp.JSON200.TotalCount {
+			return apiTokens, nil
+		}
+	}
+}
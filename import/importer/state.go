@@ -0,0 +1,153 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// importIdAttributes maps each resource type to the state attribute holding its import ID
+var importIdAttributes = map[string]string{
+	"astro_workspace":  "id",
+	"astro_deployment": "id",
+	"astro_cluster":    "id",
+	"astro_hybrid_cluster_workspace_authorization": "cluster_id",
+	"astro_api_token":  "id",
+	"astro_team":       "id",
+	"astro_team_roles": "team_id",
+	"astro_user_roles": "user_id",
+}
+
+// StateObject is a managed resource instance of the astro provider in a Terraform state
+type StateObject struct {
+	Address      string                 `json:"address"`
+	ResourceType string                 `json:"resource_type"`
+	Name         string                 `json:"name"`
+	Id           string                 `json:"id"`
+	StateFile    string                 `json:"state_file,omitempty"`
+	Attributes   map[string]interface{} `json:"-"`
+}
+
+// rawState is the subset of the terraform.tfstate format and of the `terraform show -json` format needed to find
+// managed objects
+type rawState struct {
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey   interface{}            `json:"index_key"`
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+	Values *struct {
+		RootModule rawShowModule `json:"root_module"`
+	} `json:"values"`
+}
+
+type rawShowModule struct {
+	Resources []struct {
+		Address string                 `json:"address"`
+		Mode    string                 `json:"mode"`
+		Type    string                 `json:"type"`
+		Name    string                 `json:"name"`
+		Values  map[string]interface{} `json:"values"`
+	} `json:"resources"`
+	ChildModules []rawShowModule `json:"child_modules"`
+}
+
+// ParseState returns the astro resource instances of a state, either in the terraform.tfstate format written by
+// Terraform and `terraform state pull` or in the `terraform show -json` format
+func ParseState(stateBytes []byte) ([]StateObject, error) {
+	if len(strings.TrimSpace(string(stateBytes))) == 0 {
+		return nil, nil
+	}
+
+	var s rawState
+	if err := json.Unmarshal(stateBytes, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse state: %v", err)
+	}
+
+	var objects []StateObject
+	for _, resource := range s.Resources {
+		if resource.Mode != "managed" || !strings.HasPrefix(resource.Type, "astro_") {
+			continue
+		}
+		address := resource.Type + "." + resource.Name
+		if resource.Module != "" {
+			address = resource.Module + "." + address
+		}
+		for _, instance := range resource.Instances {
+			objects = append(objects, newStateObject(address+indexSuffix(instance.IndexKey), resource.Type, resource.Name, instance.Attributes))
+		}
+	}
+
+	if s.Values != nil {
+		objects = append(objects, parseShowModule(s.Values.RootModule)...)
+	}
+
+	return objects, nil
+}
+
+// LoadStateFile reads and parses a state file
+func LoadStateFile(path string) ([]StateObject, error) {
+	stateBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	objects, err := ParseState(stateBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+
+	for idx := range objects {
+		objects[idx].StateFile = path
+	}
+
+	return objects, nil
+}
+
+func parseShowModule(module rawShowModule) []StateObject {
+	var objects []StateObject
+	for _, resource := range module.Resources {
+		if resource.Mode != "managed" || !strings.HasPrefix(resource.Type, "astro_") {
+			continue
+		}
+		objects = append(objects, newStateObject(resource.Address, resource.Type, resource.Name, resource.Values))
+	}
+	for _, childModule := range module.ChildModules {
+		objects = append(objects, parseShowModule(childModule)...)
+	}
+	return objects
+}
+
+func newStateObject(address, resourceType, name string, attributes map[string]interface{}) StateObject {
+	attribute, ok := importIdAttributes[resourceType]
+	if !ok {
+		attribute = "id"
+	}
+	id, _ := attributes[attribute].(string)
+
+	return StateObject{
+		Address:      address,
+		ResourceType: resourceType,
+		Name:         name,
+		Id:           id,
+		Attributes:   attributes,
+	}
+}
+
+// indexSuffix returns the address suffix of a resource instance created with count or for_each
+func indexSuffix(indexKey interface{}) string {
+	switch key := indexKey.(type) {
+	case string:
+		return fmt.Sprintf("[%q]", key)
+	case float64:
+		return fmt.Sprintf("[%v]", key)
+	default:
+		return ""
+	}
+}
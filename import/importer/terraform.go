@@ -0,0 +1,53 @@
+package importer
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// MinTerraformVersion is the oldest Terraform version that supports generating configuration from import blocks
+const MinTerraformVersion = "1.7.0"
+
+// CheckTerraformVersion checks if Terraform is installed and the version is supported, and returns the installed version
+func CheckTerraformVersion() (*version.Version, error) {
+	// Check if Terraform is installed
+	_, err := exec.LookPath("terraform")
+	if err != nil {
+		return nil, fmt.Errorf("terraform is not installed or not in PATH. Please install Terraform and make sure it's in your system PATH")
+	}
+
+	// Get Terraform version
+	cmd := exec.Command("terraform", "version")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Terraform version: %v", err)
+	}
+
+	// Parse the version string
+	versionStr := strings.TrimSpace(strings.Split(string(output), "\n")[0])
+	versionStr = strings.TrimPrefix(versionStr, "Terraform v")
+
+	currentVersion, err := version.NewVersion(versionStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Terraform version: %v", err)
+	}
+
+	minVersion := version.Must(version.NewVersion(MinTerraformVersion))
+	if currentVersion.LessThan(minVersion) {
+		return nil, fmt.Errorf("terraform version %s is required. Your version (%s) is too old. Please upgrade Terraform", minVersion, currentVersion)
+	}
+
+	return currentVersion, nil
+}
+
+// runTerraform runs a terraform command in the output directory
+func (i *Importer) runTerraform(args ...string) error {
+	cmd := exec.Command("terraform", args...)
+	cmd.Dir = i.opts.OutputDir
+	cmd.Stdout = i.opts.TerraformOutput
+	cmd.Stderr = i.opts.TerraformOutput
+	return cmd.Run()
+}
@@ -0,0 +1,126 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
+)
+
+// Prefixes of the keys of the responses cached by ResponseCache, mutations invalidate the keys of their prefix
+const (
+	ClusterOptionsCacheKeyPrefix    = "cluster_options"
+	DeploymentOptionsCacheKeyPrefix = "deployment_options"
+	DeploymentsCacheKeyPrefix       = "deployments"
+	OrganizationCacheKeyPrefix      = "organization"
+	WorkspacesCacheKeyPrefix        = "workspaces"
+)
+
+// TTLs of the cached responses, they are short since the cache only deduplicates the lookups of a single Terraform run
+const (
+	ClusterOptionsCacheTTL    = 5 * time.Minute
+	DeploymentOptionsCacheTTL = 5 * time.Minute
+	ListCacheTTL              = 30 * time.Second
+	OrganizationCacheTTL      = time.Minute
+)
+
+// CacheKey joins the parts of a cache key, the first part should be one of the cache key prefixes
+func CacheKey(parts ...any) string {
+	key := make([]string, len(parts))
+	for i, part := range parts {
+		key[i] = fmt.Sprint(part)
+	}
+	return strings.Join(key, ":")
+}
+
+// ResponseCache caches the responses of read-only lookups for the lifetime of a provider, so that the data sources
+// and validations of a large plan do not send the same request many times.
+// Concurrent lookups of the same key are coalesced into a single request. A nil ResponseCache does not cache.
+type ResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	// generation is incremented by every invalidation
+	generation uint64
+	group      singleflight.Group
+	now        func() time.Time
+}
+
+type cacheEntry struct {
+	response  any
+	expiresAt time.Time
+}
+
+// NewResponseCache creates an empty cache
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+}
+
+// CachedResponse returns the cached response of key if it has not expired, or the response of fetch.
+// Responses are only cached for ttl if fetch succeeds with a 200 status, so errors are never cached.
+func CachedResponse[T interface{ StatusCode() int }](
+	ctx context.Context,
+	cache *ResponseCache,
+	key string,
+	ttl time.Duration,
+	fetch func() (T, error),
+) (T, error) {
+	if cache == nil {
+		return fetch()
+	}
+
+	cache.mu.Lock()
+	if entry, ok := cache.entries[key]; ok && cache.now().Before(entry.expiresAt) {
+		cache.mu.Unlock()
+		tflog.Debug(ctx, "using cached API response", map[string]interface{}{"key": key})
+		return entry.response.(T), nil
+	}
+	generation := cache.generation
+	cache.mu.Unlock()
+
+	// requests started after an invalidation must not join a request started before it
+	response, err, shared := cache.group.Do(fmt.Sprintf("%v@%v", key, generation), func() (any, error) {
+		response, err := fetch()
+		if err != nil {
+			return response, err
+		}
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		// do not cache a response fetched before a mutation invalidated the cache
+		if response.StatusCode() == http.StatusOK && generation == cache.generation {
+			cache.entries[key] = cacheEntry{response: response, expiresAt: cache.now().Add(ttl)}
+		}
+		return response, nil
+	})
+	if shared {
+		tflog.Debug(ctx, "coalesced concurrent API requests", map[string]interface{}{"key": key})
+	}
+	return response.(T), err
+}
+
+// Invalidate removes the cached responses whose key starts with one of prefixes, it must be called after mutations
+// that change the responses of these keys
+func (c *ResponseCache) Invalidate(ctx context.Context, prefixes ...string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for key := range c.entries {
+		for _, prefix := range prefixes {
+			if key == prefix || strings.HasPrefix(key, prefix+":") {
+				delete(c.entries, key)
+				break
+			}
+		}
+	}
+	tflog.Debug(ctx, "invalidated cached API responses", map[string]interface{}{"prefixes": prefixes})
+}
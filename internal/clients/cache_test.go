@@ -0,0 +1,130 @@
+package clients_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+)
+
+type testResponse struct {
+	status int
+	value  int
+}
+
+func (r *testResponse) StatusCode() int {
+	return r.status
+}
+
+func TestUnit_ResponseCache(t *testing.T) {
+	ctx := context.Background()
+	key := clients.CacheKey(clients.DeploymentsCacheKeyPrefix, "org", "deployment-1,deployment-2")
+
+	countingFetch := func(calls *int32, status int) func() (*testResponse, error) {
+		return func() (*testResponse, error) {
+			return &testResponse{status: status, value: int(atomic.AddInt32(calls, 1))}, nil
+		}
+	}
+
+	t.Run("builds keys from their parts", func(t *testing.T) {
+		assert.Equal(t, "deployments:org:deployment-1,deployment-2", key)
+	})
+
+	t.Run("returns cached responses until they expire", func(t *testing.T) {
+		cache := clients.NewResponseCache()
+		var calls int32
+
+		response, err := clients.CachedResponse(ctx, cache, key, time.Minute, countingFetch(&calls, http.StatusOK))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, response.value)
+		response, err = clients.CachedResponse(ctx, cache, key, time.Minute, countingFetch(&calls, http.StatusOK))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, response.value)
+
+		otherKey := clients.CacheKey(clients.DeploymentsCacheKeyPrefix, "org", "deployment-3")
+		response, err = clients.CachedResponse(ctx, cache, otherKey, time.Millisecond, countingFetch(&calls, http.StatusOK))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, response.value)
+		time.Sleep(5 * time.Millisecond)
+		response, err = clients.CachedResponse(ctx, cache, otherKey, time.Millisecond, countingFetch(&calls, http.StatusOK))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, response.value)
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		cache := clients.NewResponseCache()
+		var calls int32
+
+		_, err := clients.CachedResponse(ctx, cache, key, time.Minute, func() (*testResponse, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, errors.New("connection refused")
+		})
+		assert.Error(t, err)
+		response, err := clients.CachedResponse(ctx, cache, key, time.Minute, countingFetch(&calls, http.StatusInternalServerError))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, response.StatusCode())
+		response, err = clients.CachedResponse(ctx, cache, key, time.Minute, countingFetch(&calls, http.StatusOK))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, response.value)
+	})
+
+	t.Run("coalesces concurrent requests", func(t *testing.T) {
+		cache := clients.NewResponseCache()
+		var calls int32
+		release := make(chan struct{})
+		fetch := func() (*testResponse, error) {
+			<-release
+			return countingFetch(&calls, http.StatusOK)()
+		}
+
+		var wg sync.WaitGroup
+		responses := make([]*testResponse, 10)
+		for i := range responses {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				responses[i], _ = clients.CachedResponse(ctx, cache, key, time.Minute, fetch)
+			}(i)
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		for _, response := range responses {
+			assert.Equal(t, 1, response.value)
+		}
+	})
+
+	t.Run("invalidates the keys of a prefix", func(t *testing.T) {
+		cache := clients.NewResponseCache()
+		var calls int32
+		workspacesKey := clients.CacheKey(clients.WorkspacesCacheKeyPrefix, "org")
+
+		clients.CachedResponse(ctx, cache, key, time.Minute, countingFetch(&calls, http.StatusOK))
+		clients.CachedResponse(ctx, cache, workspacesKey, time.Minute, countingFetch(&calls, http.StatusOK))
+		cache.Invalidate(ctx, clients.DeploymentsCacheKeyPrefix)
+
+		response, _ := clients.CachedResponse(ctx, cache, key, time.Minute, countingFetch(&calls, http.StatusOK))
+		assert.Equal(t, 3, response.value)
+		response, _ = clients.CachedResponse(ctx, cache, workspacesKey, time.Minute, countingFetch(&calls, http.StatusOK))
+		assert.Equal(t, 2, response.value)
+	})
+
+	t.Run("does not cache without a cache", func(t *testing.T) {
+		var cache *clients.ResponseCache
+		var calls int32
+
+		clients.CachedResponse(ctx, cache, key, time.Minute, countingFetch(&calls, http.StatusOK))
+		response, _ := clients.CachedResponse(ctx, cache, key, time.Minute, countingFetch(&calls, http.StatusOK))
+		assert.Equal(t, 2, response.value)
+		cache.Invalidate(ctx, clients.DeploymentsCacheKeyPrefix)
+	})
+}
@@ -0,0 +1,232 @@
+// Package cassette provides an HTTP client that records the requests made by the platform and IAM clients to a
+// cassette file and replays them later without network access.
+//
+// Recorded cassettes are sanitized: the authorization header is never recorded, and API token values, secret
+// environment variable values and email addresses are redacted from request and response bodies.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+)
+
+// Mode is the mode of a Recorder
+type Mode string
+
+const (
+	// ModeRecord sends requests to the API and records them
+	ModeRecord Mode = "record"
+	// ModeReplay replays recorded requests without sending them
+	ModeReplay Mode = "replay"
+)
+
+// RedactedEmail replaces the email addresses of recorded bodies
+const RedactedEmail = "redacted@example.com"
+
+var emailRegex = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// Cassette is the content of a cassette file
+type Cassette struct {
+	// Variables are the non-secret values the recording depends on, such as organization and object IDs
+	Variables    map[string]string `json:"variables,omitempty"`
+	Interactions []Interaction     `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request, its URL is relative to the API host
+type Request struct {
+	Method string `json:"method"`
+	Url    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode  int    `json:"statusCode"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// Recorder is an HTTP client that records or replays the interactions of a cassette file.
+// It implements the HttpRequestDoer interface of the platform and IAM clients, so it can be installed with
+// platform.WithHTTPClient and iam.WithHTTPClient.
+type Recorder struct {
+	mode     Mode
+	path     string
+	client   *http.Client
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// NewRecorder creates a recorder for the cassette file at path. In replay mode the cassette file must exist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	recorder := &Recorder{
+		mode:   mode,
+		path:   path,
+		client: http.DefaultClient,
+	}
+	switch mode {
+	case ModeRecord:
+		return recorder, nil
+	case ModeReplay:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err = json.Unmarshal(content, &recorder.cassette); err != nil {
+			return nil, fmt.Errorf("failed to decode cassette %v: %w", path, err)
+		}
+		recorder.replayed = make([]bool, len(recorder.cassette.Interactions))
+		return recorder, nil
+	default:
+		return nil, fmt.Errorf("invalid cassette mode '%v', must be one of %v, %v", mode, ModeRecord, ModeReplay)
+	}
+}
+
+// Variables returns the variables of the cassette
+func (r *Recorder) Variables() map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	variables := make(map[string]string, len(r.cassette.Variables))
+	for key, value := range r.cassette.Variables {
+		variables[key] = value
+	}
+	return variables
+}
+
+// SetVariable sets a variable of the cassette, it must not be used for secrets since variables are not redacted
+func (r *Recorder) SetVariable(key, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cassette.Variables == nil {
+		r.cassette.Variables = make(map[string]string)
+	}
+	r.cassette.Variables[key] = value
+}
+
+// Do records or replays a request
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+// Save writes the recorded interactions to the cassette file, it does nothing in replay mode
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	content, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err = os.WriteFile(r.path, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method: req.Method,
+			Url:    req.URL.RequestURI(),
+			Body:   string(Sanitize(requestBody)),
+		},
+		Response: Response{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        string(Sanitize(responseBody)),
+		},
+	})
+	return resp, nil
+}
+
+// replay returns the response of the first interaction that has not been replayed yet with the method and URL of req
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if _, err := readRequestBody(req); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	url := req.URL.RequestURI()
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || interaction.Request.Method != req.Method || interaction.Request.Url != url {
+			continue
+		}
+		r.replayed[i] = true
+		header := make(http.Header)
+		if interaction.Response.ContentType != "" {
+			header.Set("Content-Type", interaction.Response.ContentType)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette %v has no recorded interaction left for %v %v", r.path, req.Method, url)
+}
+
+// readRequestBody reads the body of req and replaces it so it can still be sent
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// Sanitize redacts the secrets and email addresses of a request or response body
+func Sanitize(body []byte) []byte {
+	return emailRegex.ReplaceAll(clients.RedactBody(body), []byte(RedactedEmail))
+}
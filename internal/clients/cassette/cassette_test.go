@@ -0,0 +1,82 @@
+package cassette_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/cassette"
+)
+
+func TestUnit_Recorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_, _ = w.Write([]byte(`{"path":"` + r.URL.Path + `","token":"secret-token","email":"user@astronomer.io"}`))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+
+	t.Run("records sanitized interactions", func(t *testing.T) {
+		recorder, err := cassette.NewRecorder(path, cassette.ModeRecord)
+		assert.NoError(t, err)
+		recorder.SetVariable("ORGANIZATION_ID", "organization-id")
+
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/organizations/organization-id/teams", strings.NewReader(`{"environmentVariables":[{"isSecret":true,"key":"SECRET","value":"secret"}]}`))
+		req.Header.Set("authorization", "Bearer api-token")
+		resp, err := recorder.Do(req)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), "secret-token")
+
+		req, _ = http.NewRequest(http.MethodGet, server.URL+"/organizations/organization-id/teams?limit=10", nil)
+		_, err = recorder.Do(req)
+		assert.NoError(t, err)
+		assert.NoError(t, recorder.Save())
+
+		content, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		for _, secret := range []string{"api-token", "secret-token", "user@astronomer.io", `\"secret\"`, server.URL} {
+			assert.NotContains(t, string(content), secret)
+		}
+		assert.Contains(t, string(content), cassette.RedactedEmail)
+		assert.Contains(t, string(content), "organization-id")
+	})
+
+	t.Run("replays interactions by method and url", func(t *testing.T) {
+		recorder, err := cassette.NewRecorder(path, cassette.ModeReplay)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"ORGANIZATION_ID": "organization-id"}, recorder.Variables())
+
+		req, _ := http.NewRequest(http.MethodGet, "https://api.astronomer.io/organizations/organization-id/teams?limit=10", nil)
+		resp, err := recorder.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), `"token":"REDACTED"`)
+
+		req, _ = http.NewRequest(http.MethodPost, "https://api.astronomer.io/organizations/organization-id/teams", strings.NewReader("{}"))
+		resp, err = recorder.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		_, err = recorder.Do(req)
+		assert.ErrorContains(t, err, "no recorded interaction left for POST /organizations/organization-id/teams")
+	})
+
+	t.Run("rejects invalid modes and missing cassettes", func(t *testing.T) {
+		_, err := cassette.NewRecorder(path, "invalid")
+		assert.Error(t, err)
+		_, err = cassette.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), cassette.ModeReplay)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,225 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gopkg.in/yaml.v3"
+)
+
+// TokenExpiryMargin is how long before their expiry tokens are refreshed, so that they do not expire during a request
+const TokenExpiryMargin = time.Minute
+
+// TokenSource provides the API token sent with the requests of a client
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a token that never changes, such as the token of the provider configuration
+type StaticToken string
+
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// cachedTokenSource caches the token of fetch until it expires
+type cachedTokenSource struct {
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	fetch     func(ctx context.Context) (token string, expiresAt time.Time, err error)
+	now       func() time.Time
+}
+
+func newCachedTokenSource(fetch func(ctx context.Context) (string, time.Time, error)) *cachedTokenSource {
+	return &cachedTokenSource{
+		fetch: fetch,
+		now:   time.Now,
+	}
+}
+
+func (s *cachedTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// tokens without an expiry are fetched once
+	if s.token != "" && (s.expiresAt.IsZero() || s.now().Add(TokenExpiryMargin).Before(s.expiresAt)) {
+		return s.token, nil
+	}
+	token, expiresAt, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	token = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(token), "Bearer "))
+	if token == "" {
+		return "", fmt.Errorf("token is empty")
+	}
+	if expiresAt.IsZero() {
+		expiresAt = JwtExpiry(token)
+	}
+	s.token = token
+	s.expiresAt = expiresAt
+	return token, nil
+}
+
+// JwtClaims are the claims of the JWT tokens of Astro read by the provider
+type JwtClaims struct {
+	Exp int64 `json:"exp"`
+	// ApiTokenId is the ID of the API token of the token, it is empty for the tokens of users
+	ApiTokenId string `json:"apiTokenId"`
+	// Permissions include the organization of API tokens as organizationId:<id>
+	Permissions []string `json:"permissions"`
+}
+
+// OrganizationIds returns the organizations of the permissions of the token
+func (c JwtClaims) OrganizationIds() []string {
+	var organizationIds []string
+	for _, permission := range c.Permissions {
+		if organizationId, ok := strings.CutPrefix(permission, "organizationId:"); ok {
+			organizationIds = append(organizationIds, organizationId)
+		}
+	}
+	return organizationIds
+}
+
+// ParseJwtClaims returns the claims of a JWT token, ok is false if the token is not a JWT.
+// The signature of the token is not verified.
+func ParseJwtClaims(token string) (claims JwtClaims, ok bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, false
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return JwtClaims{}, false
+	}
+	return claims, true
+}
+
+// JwtExpiry returns the expiry of a JWT token from its exp claim, or the zero time if the token is not a JWT or does not
+// expire
+func JwtExpiry(token string) time.Time {
+	claims, ok := ParseJwtClaims(token)
+	if !ok || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+// NewFileTokenSource creates a source reading the token of a file, the file is read again when the token expires
+func NewFileTokenSource(path string) TokenSource {
+	return newCachedTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		tflog.Debug(ctx, "reading API token file", map[string]any{"path": path})
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to read token file: %w", err)
+		}
+		return string(content), time.Time{}, nil
+	})
+}
+
+// ExecCredential is the output of an exec credential helper
+type ExecCredential struct {
+	Token string `json:"token"`
+	// ExpiresAt defaults to the exp claim of JWT tokens, the token is used until the helper exits if both are unset
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// NewExecTokenSource creates a source running an exec credential helper, a command printing an ExecCredential as JSON
+// such as `{"token":"...","expiresAt":"2024-01-01T00:00:00Z"}`. The command runs again when the token expires.
+// env is added to the environment of the provider.
+func NewExecTokenSource(command string, args []string, env map[string]string) TokenSource {
+	return newCachedTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		tflog.Debug(ctx, "running exec credential helper", map[string]any{"command": command})
+		cmd := exec.CommandContext(ctx, command, args...)
+		cmd.Env = os.Environ()
+		for key, value := range env {
+			cmd.Env = append(cmd.Env, fmt.Sprintf("%v=%v", key, value))
+		}
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return "", time.Time{}, fmt.Errorf("exec credential helper %v failed: %w: %v", command, err, strings.TrimSpace(stderr.String()))
+		}
+		var credential ExecCredential
+		if err := json.Unmarshal(stdout.Bytes(), &credential); err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to decode the output of exec credential helper %v: %w", command, err)
+		}
+		if credential.ExpiresAt == nil {
+			return credential.Token, time.Time{}, nil
+		}
+		return credential.Token, *credential.ExpiresAt, nil
+	})
+}
+
+// AstroCliContext is a context of the Astro CLI config file
+type AstroCliContext struct {
+	Domain       string `yaml:"domain"`
+	Organization string `yaml:"organization"`
+	Token        string `yaml:"token"`
+}
+
+type astroCliConfig struct {
+	Context  string                     `yaml:"context"`
+	Contexts map[string]AstroCliContext `yaml:"contexts"`
+}
+
+// DefaultAstroCliConfigPath returns the path of the config file of the Astro CLI, ~/.astro/config.yaml
+func DefaultAstroCliConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, ".astro", "config.yaml"), nil
+}
+
+// ReadAstroCliContext reads a context of the Astro CLI config file at path, contextName defaults to the current context
+// of the CLI. Contexts are named after their domain, such as astronomer_io or astronomer.io.
+func ReadAstroCliContext(path, contextName string) (AstroCliContext, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return AstroCliContext{}, fmt.Errorf("failed to read Astro CLI config: %w", err)
+	}
+	var config astroCliConfig
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return AstroCliContext{}, fmt.Errorf("failed to decode Astro CLI config %v: %w", path, err)
+	}
+	if contextName == "" {
+		contextName = config.Context
+	}
+	if contextName == "" {
+		return AstroCliContext{}, fmt.Errorf("Astro CLI config %v has no current context, run `astro login` first", path)
+	}
+	for _, name := range []string{contextName, strings.ReplaceAll(contextName, ".", "_")} {
+		if cliContext, ok := config.Contexts[name]; ok {
+			return cliContext, nil
+		}
+	}
+	return AstroCliContext{}, fmt.Errorf("context %v not found in Astro CLI config %v", contextName, path)
+}
+
+// NewAstroCliTokenSource creates a source reading the token of a context of the Astro CLI config file, the file is read
+// again when the token expires since the CLI refreshes it
+func NewAstroCliTokenSource(path, contextName string) TokenSource {
+	return newCachedTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		tflog.Debug(ctx, "reading API token of Astro CLI config", map[string]any{"path": path, "context": contextName})
+		cliContext, err := ReadAstroCliContext(path, contextName)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		return cliContext.Token, time.Time{}, nil
+	})
+}
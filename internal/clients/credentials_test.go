@@ -0,0 +1,134 @@
+package clients_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+)
+
+func jwt(expiresAt time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"token-id","exp":%v}`, expiresAt.Unix())))
+	return "eyJhbGciOiJSUzI1NiJ9." + payload + ".signature"
+}
+
+func TestUnit_TokenSources(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reads the claims of JWT tokens", func(t *testing.T) {
+		expiresAt := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+		assert.Equal(t, expiresAt, clients.JwtExpiry(jwt(expiresAt)))
+		assert.True(t, clients.JwtExpiry("not-a-jwt").IsZero())
+		assert.True(t, clients.JwtExpiry("a.b.c").IsZero())
+
+		payload := base64.RawURLEncoding.EncodeToString([]byte(`{"apiTokenId":"token-id","permissions":["workspaceId:workspace-id","organizationId:organization-id"]}`))
+		claims, ok := clients.ParseJwtClaims("header." + payload + ".signature")
+		assert.True(t, ok)
+		assert.Equal(t, "token-id", claims.ApiTokenId)
+		assert.Equal(t, []string{"organization-id"}, claims.OrganizationIds())
+		_, ok = clients.ParseJwtClaims("not-a-jwt")
+		assert.False(t, ok)
+	})
+
+	t.Run("reads the token file again when the token expires", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		source := clients.NewFileTokenSource(tokenFile)
+		_, err := source.Token(ctx)
+		assert.Error(t, err)
+
+		expiredToken := jwt(time.Now().Add(-time.Minute))
+		assert.NoError(t, os.WriteFile(tokenFile, []byte(expiredToken+"\n"), 0o600))
+		token, err := source.Token(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expiredToken, token)
+
+		validToken := jwt(time.Now().Add(time.Hour))
+		assert.NoError(t, os.WriteFile(tokenFile, []byte(validToken), 0o600))
+		token, err = source.Token(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, validToken, token)
+
+		assert.NoError(t, os.WriteFile(tokenFile, []byte("rotated-token"), 0o600))
+		token, err = source.Token(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, validToken, token)
+	})
+
+	t.Run("runs the exec credential helper again when the token expires", func(t *testing.T) {
+		runs := filepath.Join(t.TempDir(), "runs")
+		run := func(expiresAt time.Time) clients.TokenSource {
+			script := fmt.Sprintf(`echo run >> %v; echo '{"token":"exec-token","expiresAt":"%v"}'`, runs, expiresAt.Format(time.RFC3339))
+			return clients.NewExecTokenSource("sh", []string{"-c", script}, nil)
+		}
+		countRuns := func() int {
+			content, _ := os.ReadFile(runs)
+			return strings.Count(string(content), "run")
+		}
+
+		source := run(time.Now().Add(time.Hour))
+		for i := 0; i < 2; i++ {
+			token, err := source.Token(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, "exec-token", token)
+		}
+		assert.Equal(t, 1, countRuns())
+
+		source = run(time.Now().Add(30 * time.Second))
+		for i := 0; i < 2; i++ {
+			_, err := source.Token(ctx)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 3, countRuns())
+	})
+
+	t.Run("returns the errors of the exec credential helper", func(t *testing.T) {
+		source := clients.NewExecTokenSource("sh", []string{"-c", "echo $MESSAGE >&2; exit 1"}, map[string]string{"MESSAGE": "vault is sealed"})
+		_, err := source.Token(ctx)
+		assert.ErrorContains(t, err, "vault is sealed")
+
+		source = clients.NewExecTokenSource("sh", []string{"-c", "echo not json"}, nil)
+		_, err = source.Token(ctx)
+		assert.ErrorContains(t, err, "failed to decode")
+
+		source = clients.NewExecTokenSource("sh", []string{"-c", `echo '{"token":""}'`}, nil)
+		_, err = source.Token(ctx)
+		assert.ErrorContains(t, err, "token is empty")
+	})
+
+	t.Run("reads the contexts of the Astro CLI config", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		assert.NoError(t, os.WriteFile(configPath, []byte(`
+context: astronomer_io
+contexts:
+  astronomer_io:
+    domain: astronomer.io
+    organization: organization-id
+    token: Bearer cli-token
+  astronomer-dev_io:
+    domain: astronomer-dev.io
+    organization: dev-organization-id
+    token: Bearer dev-cli-token
+`), 0o600))
+
+		cliContext, err := clients.ReadAstroCliContext(configPath, "")
+		assert.NoError(t, err)
+		assert.Equal(t, clients.AstroCliContext{Domain: "astronomer.io", Organization: "organization-id", Token: "Bearer cli-token"}, cliContext)
+		cliContext, err = clients.ReadAstroCliContext(configPath, "astronomer-dev.io")
+		assert.NoError(t, err)
+		assert.Equal(t, "dev-organization-id", cliContext.Organization)
+		_, err = clients.ReadAstroCliContext(configPath, "astronomer-stage.io")
+		assert.ErrorContains(t, err, "not found")
+
+		token, err := clients.NewAstroCliTokenSource(configPath, "").Token(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "cli-token", token)
+	})
+}
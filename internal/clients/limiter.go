@@ -0,0 +1,90 @@
+package clients
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+)
+
+// RequestLimiter limits the number of concurrent requests and the rate of the requests sent to the Astro API.
+// A single limiter is shared by the platform and IAM clients of a provider so the limits apply to all of its requests.
+type RequestLimiter struct {
+	concurrency *semaphore.Weighted
+	rate        *rate.Limiter
+}
+
+// NewRequestLimiter creates a limiter allowing maxConcurrentRequests requests in flight and requestsPerSecond
+// requests per second, a limit lower than or equal to 0 is unlimited
+func NewRequestLimiter(maxConcurrentRequests int64, requestsPerSecond float64) *RequestLimiter {
+	limiter := &RequestLimiter{}
+	if maxConcurrentRequests > 0 {
+		limiter.concurrency = semaphore.NewWeighted(maxConcurrentRequests)
+	}
+	if requestsPerSecond > 0 {
+		// allow a burst of one second of requests so that requests are not needlessly delayed after an idle period
+		limiter.rate = rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Max(1, math.Ceil(requestsPerSecond))))
+	}
+	return limiter
+}
+
+// wait blocks until a request can be sent, the returned function must be called once the request is complete
+func (l *RequestLimiter) wait(ctx context.Context) (func(), error) {
+	release := func() {}
+	if l.concurrency != nil {
+		if err := l.concurrency.Acquire(ctx, 1); err != nil {
+			return nil, err
+		}
+		release = func() { l.concurrency.Release(1) }
+	}
+	if l.rate != nil {
+		if err := l.rate.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// LimitedHttpClient is an HTTP client that waits for a RequestLimiter before sending requests.
+// Requests that waited in the queue are logged at DEBUG level with their queue wait time.
+type LimitedHttpClient struct {
+	limiter *RequestLimiter
+	client  HttpRequestDoer
+}
+
+// NewLimitedHttpClient creates a client that sends the requests of client when limiter allows it, client defaults to
+// http.DefaultClient
+func NewLimitedHttpClient(limiter *RequestLimiter, client HttpRequestDoer) *LimitedHttpClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &LimitedHttpClient{
+		limiter: limiter,
+		client:  client,
+	}
+}
+
+func (c *LimitedHttpClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+	release, err := c.limiter.wait(ctx)
+	if err != nil {
+		tflog.Error(ctx, "failed to wait for API request limiter", map[string]any{"error": err})
+		return nil, err
+	}
+	defer release()
+
+	if queueWait := time.Since(start); queueWait >= time.Millisecond {
+		tflog.Debug(ctx, "API request queued by request limiter", map[string]any{
+			"method":        req.Method,
+			"path":          req.URL.Path,
+			"queue_wait_ms": queueWait.Milliseconds(),
+		})
+	}
+	return c.client.Do(req)
+}
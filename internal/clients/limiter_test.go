@@ -0,0 +1,100 @@
+package clients_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+)
+
+func TestUnit_LimitedHttpClient(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sendRequests := func(ctx context.Context, client *clients.LimitedHttpClient, count int) {
+		var wg sync.WaitGroup
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/deployments", nil)
+				resp, err := client.Do(req)
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				resp.Body.Close()
+			}()
+		}
+		wg.Wait()
+	}
+
+	t.Run("limits concurrent requests and logs their queue wait", func(t *testing.T) {
+		atomic.StoreInt32(&maxInFlight, 0)
+		var output bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &output)
+		client := clients.NewLimitedHttpClient(clients.NewRequestLimiter(2, 0), nil)
+
+		sendRequests(ctx, client, 6)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+
+		entries, err := tflogtest.MultilineJSONDecode(&output)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, entries)
+		for _, entry := range entries {
+			assert.Equal(t, "API request queued by request limiter", entry["@message"])
+			assert.Equal(t, "/deployments", entry["path"])
+			assert.Contains(t, entry, "queue_wait_ms")
+		}
+	})
+
+	t.Run("limits the rate of requests", func(t *testing.T) {
+		client := clients.NewLimitedHttpClient(clients.NewRequestLimiter(0, 10), http.DefaultClient)
+
+		start := time.Now()
+		// the first 10 requests are a burst, the next 5 wait for 100ms each
+		sendRequests(context.Background(), client, 15)
+		assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	})
+
+	t.Run("does not limit without limits", func(t *testing.T) {
+		atomic.StoreInt32(&maxInFlight, 0)
+		client := clients.NewLimitedHttpClient(clients.NewRequestLimiter(0, 0), nil)
+
+		sendRequests(context.Background(), client, 5)
+		assert.Equal(t, int32(5), atomic.LoadInt32(&maxInFlight))
+	})
+
+	t.Run("stops waiting when the request is canceled", func(t *testing.T) {
+		client := clients.NewLimitedHttpClient(clients.NewRequestLimiter(0, 1), nil)
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/deployments", nil)
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		req, _ = http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/deployments", nil)
+		_, err = client.Do(req)
+		assert.Error(t, err)
+	})
+}
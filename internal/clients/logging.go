@@ -0,0 +1,107 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tflog subsystems of the API clients, their logs are enabled with TF_LOG=DEBUG or TF_LOG=TRACE
+const (
+	PlatformLogSubsystem = "astro_platform"
+	IamLogSubsystem      = "astro_iam"
+)
+
+// LoggingHttpClient is an HTTP client that logs the requests it sends to a tflog subsystem.
+// The method, path, status, latency and requestId of every request are logged at DEBUG level, and the redacted
+// headers and bodies of the request and response are logged at TRACE level.
+type LoggingHttpClient struct {
+	subsystem string
+	client    HttpRequestDoer
+}
+
+// NewLoggingHttpClient creates a client that logs to subsystem the requests sent with client, client defaults to
+// http.DefaultClient
+func NewLoggingHttpClient(subsystem string, client HttpRequestDoer) *LoggingHttpClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &LoggingHttpClient{
+		subsystem: subsystem,
+		client:    client,
+	}
+}
+
+func (c *LoggingHttpClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), c.subsystem)
+	requestBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	fields := map[string]any{
+		"method":     req.Method,
+		"path":       req.URL.Path,
+		"latency_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, c.subsystem, "API request failed", fields)
+		return nil, err
+	}
+	responseBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	fields["status"] = resp.StatusCode
+	if requestId := responseRequestId(resp, responseBody); requestId != "" {
+		fields["requestId"] = requestId
+	}
+	tflog.SubsystemDebug(ctx, c.subsystem, "API request", fields)
+	tflog.SubsystemTrace(ctx, c.subsystem, "API request details", map[string]any{
+		"method":           req.Method,
+		"path":             req.URL.Path,
+		"query":            req.URL.RawQuery,
+		"request_headers":  RedactHeaders(req.Header),
+		"request_body":     string(RedactBody(requestBody)),
+		"response_headers": RedactHeaders(resp.Header),
+		"response_body":    string(RedactBody(responseBody)),
+	})
+	return resp, nil
+}
+
+// readBody reads a request or response body and replaces it so it can be read again
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	content, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	*body = io.NopCloser(bytes.NewReader(content))
+	return content, nil
+}
+
+// responseRequestId returns the requestId of an error response, or the x-request-id header of other responses
+func responseRequestId(resp *http.Response, body []byte) string {
+	if requestId := resp.Header.Get("x-request-id"); requestId != "" {
+		return requestId
+	}
+	var decode struct {
+		RequestId string `json:"requestId"`
+	}
+	if err := json.Unmarshal(body, &decode); err != nil {
+		return ""
+	}
+	return decode.RequestId
+}
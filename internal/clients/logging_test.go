@@ -0,0 +1,75 @@
+package clients_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+)
+
+func TestUnit_LoggingHttpClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found","requestId":"request-id","statusCode":404}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"id","token":"secret-token"}`))
+	}))
+	defer server.Close()
+
+	t.Run("logs requests with redacted secrets", func(t *testing.T) {
+		var output bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &output)
+		client := clients.NewLoggingHttpClient(clients.IamLogSubsystem, nil)
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/tokens", strings.NewReader(`{"environmentVariables":[{"isSecret":true,"key":"SECRET","value":"secret-value"}]}`))
+		req.Header.Set("authorization", "Bearer api-token")
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), "secret-token")
+
+		entries, err := tflogtest.MultilineJSONDecode(&output)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, "debug", entries[0]["@level"])
+		assert.Equal(t, "provider."+clients.IamLogSubsystem, entries[0]["@module"])
+		assert.Equal(t, "POST", entries[0]["method"])
+		assert.Equal(t, "/tokens", entries[0]["path"])
+		assert.Equal(t, float64(http.StatusOK), entries[0]["status"])
+		assert.Contains(t, entries[0], "latency_ms")
+		assert.Equal(t, "trace", entries[1]["@level"])
+		for _, secret := range []string{"api-token", "secret-token", "secret-value", "secret-cookie"} {
+			assert.NotContains(t, output.String(), secret)
+		}
+		assert.Contains(t, entries[1]["request_body"], clients.RedactedValue)
+		assert.Contains(t, entries[1]["response_body"], clients.RedactedValue)
+	})
+
+	t.Run("logs the requestId of error responses", func(t *testing.T) {
+		var output bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &output)
+		client := clients.NewLoggingHttpClient(clients.PlatformLogSubsystem, http.DefaultClient)
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/missing", nil)
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		entries, err := tflogtest.MultilineJSONDecode(&output)
+		assert.NoError(t, err)
+		assert.Equal(t, "request-id", entries[0]["requestId"])
+		assert.Equal(t, float64(http.StatusNotFound), entries[0]["status"])
+	})
+}
@@ -8,14 +8,26 @@ import (
 	"github.com/astronomer/terraform-provider-astro/internal/clients"
 )
 
-func NewPlatformClient(host, token, version string) (*ClientWithResponses, error) {
+// NewPlatformClient creates a client for the platform API of host.
+// The token of every request is read from token, so that tokens can be refreshed when they expire.
+// opts are applied after the request editor, for example WithHTTPClient to install a cassette recorder.
+// Requests are logged to the astro_platform tflog subsystem and traced in astro_platform OpenTelemetry spans.
+func NewPlatformClient(host string, token clients.TokenSource, version string, opts ...ClientOption) (*ClientWithResponses, error) {
 	// we append base url in request editor, so set to an empty string here
-	cl, err := NewClientWithResponses(
-		"",
-		WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
-			baseUrl := fmt.Sprintf("%s/platform/v1beta1", host)
-			return clients.CoreRequestEditor(ctx, req, baseUrl, token, version)
-		}),
-	)
+	requestEditor := WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		baseUrl := fmt.Sprintf("%s/platform/v1beta1", host)
+		apiToken, err := token.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get API token: %w", err)
+		}
+		return clients.CoreRequestEditor(ctx, req, baseUrl, apiToken, version)
+	})
+	// wrap the HTTP client last so the requests of a client installed by opts are logged and traced as well
+	instrument := func(c *Client) error {
+		c.Client = clients.NewTracingHttpClient(clients.PlatformLogSubsystem, clients.NewLoggingHttpClient(clients.PlatformLogSubsystem, c.Client))
+		return nil
+	}
+	opts = append(append([]ClientOption{requestEditor}, opts...), instrument)
+	cl, err := NewClientWithResponses("", opts...)
 	return cl, err
 }
@@ -0,0 +1,40 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ErrReadOnly is the error of the requests rejected by a ReadOnlyHttpClient
+var ErrReadOnly = errors.New("the provider is in read-only mode, set read_only to false and unset the ASTRO_READ_ONLY env var to allow changes")
+
+// ReadOnlyHttpClient is an HTTP client that only sends GET requests, other requests fail with ErrReadOnly without being
+// sent. It guarantees that a provider cannot mutate any object whatever the resource or data source sending requests.
+type ReadOnlyHttpClient struct {
+	client HttpRequestDoer
+}
+
+// NewReadOnlyHttpClient creates a client that only sends the GET requests of client, client defaults to
+// http.DefaultClient
+func NewReadOnlyHttpClient(client HttpRequestDoer) *ReadOnlyHttpClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &ReadOnlyHttpClient{
+		client: client,
+	}
+}
+
+func (c *ReadOnlyHttpClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		tflog.Error(req.Context(), "rejected API request in read-only mode", map[string]any{
+			"method": req.Method,
+			"path":   req.URL.Path,
+		})
+		return nil, fmt.Errorf("%v %v rejected: %w", req.Method, req.URL.Path, ErrReadOnly)
+	}
+	return c.client.Do(req)
+}
@@ -0,0 +1,42 @@
+package clients_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+)
+
+func TestUnit_ReadOnlyHttpClient(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := clients.NewReadOnlyHttpClient(nil)
+
+	t.Run("sends GET requests", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/deployments", nil)
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("rejects other requests without sending them", func(t *testing.T) {
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			req, _ := http.NewRequest(method, server.URL+"/deployments", strings.NewReader("{}"))
+			_, err := client.Do(req)
+			assert.ErrorIs(t, err, clients.ErrReadOnly)
+			assert.ErrorContains(t, err, method+" /deployments")
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+}
@@ -0,0 +1,75 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+)
+
+// RedactedValue replaces secrets in redacted headers and bodies
+const RedactedValue = "REDACTED"
+
+// redactedHeaders are the request and response headers holding credentials
+var redactedHeaders = []string{"authorization", "cookie", "set-cookie"}
+
+// RedactHeaders returns a copy of request or response headers with the headers holding credentials redacted
+func RedactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	for _, header := range redactedHeaders {
+		if redacted.Get(header) != "" {
+			redacted.Set(header, RedactedValue)
+		}
+	}
+	return redacted
+}
+
+// RedactBody redacts the secrets of a JSON request or response body: the token of API tokens and the value of
+// secret environment variables. Bodies that are not JSON are returned unchanged.
+func RedactBody(body []byte) []byte {
+	return TransformJSON(body, redactSecrets)
+}
+
+// TransformJSON decodes a JSON body, applies transform to every object in it and re-encodes it.
+// Bodies that are not JSON are returned unchanged.
+func TransformJSON(body []byte, transform func(object map[string]any)) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+	walkJSON(value, transform)
+	transformed, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return transformed
+}
+
+func walkJSON(value any, transform func(object map[string]any)) {
+	switch v := value.(type) {
+	case map[string]any:
+		transform(v)
+		for _, child := range v {
+			walkJSON(child, transform)
+		}
+	case []any:
+		for _, child := range v {
+			walkJSON(child, transform)
+		}
+	}
+}
+
+func redactSecrets(object map[string]any) {
+	if token, ok := object["token"].(string); ok && token != "" {
+		object["token"] = RedactedValue
+	}
+	if isSecret, ok := object["isSecret"].(bool); ok && isSecret {
+		if value, ok := object["value"].(string); ok && value != "" {
+			object["value"] = RedactedValue
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package clients_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+)
+
+func TestUnit_RedactHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("authorization", "Bearer token")
+	headers.Set("x-astro-client-identifier", "terraform-provider-astro")
+	headers.Add("set-cookie", "session=first")
+	headers.Add("set-cookie", "refresh=second")
+
+	redacted := clients.RedactHeaders(headers)
+	assert.Equal(t, clients.RedactedValue, redacted.Get("authorization"))
+	assert.Equal(t, []string{clients.RedactedValue}, redacted.Values("set-cookie"))
+	assert.Equal(t, "terraform-provider-astro", redacted.Get("x-astro-client-identifier"))
+	assert.Equal(t, "Bearer token", headers.Get("authorization"))
+}
+
+func TestUnit_RedactBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "api token",
+			body:     `{"id":"id","shortToken":"short","token":"secret-token"}`,
+			expected: `{"id":"id","shortToken":"short","token":"REDACTED"}`,
+		},
+		{
+			name:     "secret environment variables",
+			body:     `{"environmentVariables":[{"isSecret":true,"key":"SECRET","value":"secret"},{"isSecret":false,"key":"KEY","value":"value"}]}`,
+			expected: `{"environmentVariables":[{"isSecret":true,"key":"SECRET","value":"REDACTED"},{"isSecret":false,"key":"KEY","value":"value"}]}`,
+		},
+		{
+			name:     "numbers are preserved",
+			body:     `{"rolesCount":12345678901234567890}`,
+			expected: `{"rolesCount":12345678901234567890}`,
+		},
+		{
+			name:     "empty body",
+			body:     "",
+			expected: "",
+		},
+		{
+			name:     "not json",
+			body:     "token=secret",
+			expected: "token=secret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, string(clients.RedactBody([]byte(tt.body))))
+		})
+	}
+}
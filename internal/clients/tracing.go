@@ -0,0 +1,58 @@
+package clients
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingHttpClient is an HTTP client that traces every request it sends in a span, tagged with the requestId of
+// the response
+type TracingHttpClient struct {
+	name   string
+	client HttpRequestDoer
+}
+
+// NewTracingHttpClient creates a client that traces in spans called name the requests sent with client, client
+// defaults to http.DefaultClient
+func NewTracingHttpClient(name string, client HttpRequestDoer) *TracingHttpClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &TracingHttpClient{
+		name:   name,
+		client: client,
+	}
+}
+
+func (c *TracingHttpClient) Do(req *http.Request) (*http.Response, error) {
+	ctx, span := tracing.Tracer().Start(req.Context(), c.name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLPath(req.URL.Path),
+		semconv.ServerAddress(req.URL.Hostname()),
+	))
+	defer span.End()
+
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	body, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if requestId := responseRequestId(resp, body); requestId != "" {
+		span.SetAttributes(tracing.RequestIdAttribute.String(requestId))
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, fmt.Sprintf("status: %v", resp.StatusCode))
+	}
+	return resp, nil
+}
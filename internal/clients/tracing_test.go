@@ -0,0 +1,51 @@
+package clients_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
+)
+
+func TestUnit_TracingHttpClient(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"not found","requestId":"request-id","statusCode":404}`))
+	}))
+	defer server.Close()
+
+	ctx, parent := tracing.StartResourceOperation(context.Background(), "astro_workspace", tracing.OperationRead, "organization-id")
+	client := clients.NewTracingHttpClient(clients.PlatformLogSubsystem, nil)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/organizations/organization-id/workspaces/workspace-id", nil)
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, clients.PlatformLogSubsystem, span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	attributes := map[string]string{}
+	for _, kv := range span.Attributes() {
+		attributes[string(kv.Key)] = kv.Value.Emit()
+	}
+	assert.Equal(t, "GET", attributes["http.request.method"])
+	assert.Equal(t, "/organizations/organization-id/workspaces/workspace-id", attributes["url.path"])
+	assert.Equal(t, "404", attributes["http.response.status_code"])
+	assert.Equal(t, "request-id", attributes[string(tracing.RequestIdAttribute)])
+	assert.Equal(t, codes.Error, span.Status().Code)
+}
@@ -13,6 +13,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// HttpRequestDoer is the HTTP client interface of the platform and IAM clients
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
 func CoreRequestEditor(
 	ctx context.Context,
 	req *http.Request,
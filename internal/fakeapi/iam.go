@@ -0,0 +1,550 @@
+package fakeapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/lucsky/cuid"
+	"github.com/samber/lo"
+)
+
+func (s *Server) registerIamRoutes() {
+	org := iamPrefix + "/organizations/{organizationId}"
+	s.handle(http.MethodPost, org+"/invites", s.createUserInvite)
+	s.handle(http.MethodDelete, org+"/invites/{inviteId}", s.deleteUserInvite)
+	s.handle(http.MethodGet, org+"/teams", s.listTeams)
+	s.handle(http.MethodPost, org+"/teams", s.createTeam)
+	s.handle(http.MethodGet, org+"/teams/{teamId}", s.getTeam)
+	s.handle(http.MethodPost, org+"/teams/{teamId}", s.updateTeam)
+	s.handle(http.MethodDelete, org+"/teams/{teamId}", s.deleteTeam)
+	s.handle(http.MethodGet, org+"/teams/{teamId}/members", s.listTeamMembers)
+	s.handle(http.MethodPost, org+"/teams/{teamId}/members", s.addTeamMembers)
+	s.handle(http.MethodDelete, org+"/teams/{teamId}/members/{memberId}", s.removeTeamMember)
+	s.handle(http.MethodPost, org+"/teams/{teamId}/roles", s.updateTeamRoles)
+	s.handle(http.MethodGet, org+"/tokens", s.listApiTokens)
+	s.handle(http.MethodPost, org+"/tokens", s.createApiToken)
+	s.handle(http.MethodGet, org+"/tokens/{tokenId}", s.getApiToken)
+	s.handle(http.MethodPost, org+"/tokens/{tokenId}", s.updateApiToken)
+	s.handle(http.MethodDelete, org+"/tokens/{tokenId}", s.deleteApiToken)
+	s.handle(http.MethodPost, org+"/tokens/{tokenId}/roles", s.updateApiTokenRoles)
+	s.handle(http.MethodPost, org+"/tokens/{tokenId}/rotate", s.rotateApiToken)
+	s.handle(http.MethodGet, org+"/users", s.listUsers)
+	s.handle(http.MethodGet, org+"/users/{userId}", s.getUser)
+	s.handle(http.MethodPost, org+"/users/{userId}/roles", s.updateUserRoles)
+}
+
+// validateRoles checks that the workspaces and deployments of workspace and deployment roles exist
+func (o *organization) validateRoles(workspaceRoles *[]iam.WorkspaceRole, deploymentRoles *[]iam.DeploymentRole) error {
+	for _, role := range lo.FromPtr(workspaceRoles) {
+		if o.workspace(role.WorkspaceId) == nil {
+			return badRequest("workspace %v not found", role.WorkspaceId)
+		}
+	}
+	for _, role := range lo.FromPtr(deploymentRoles) {
+		if o.deployment(role.DeploymentId) == nil {
+			return badRequest("deployment %v not found", role.DeploymentId)
+		}
+	}
+	return nil
+}
+
+// Invites
+
+func (s *Server) createUserInvite(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	var req iam.CreateUserInviteRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.InviteeEmail == "" {
+		writeError(w, http.StatusBadRequest, "inviteeEmail is required")
+		return
+	}
+	if lo.SomeBy(org.users, func(user *iam.User) bool { return user.Username == req.InviteeEmail }) {
+		writeError(w, http.StatusConflict, fmt.Sprintf("user %v is already a member of or invited to the organization", req.InviteeEmail))
+		return
+	}
+	user := org.createUser(req.InviteeEmail, req.InviteeEmail, iam.PENDING, iam.UserOrganizationRole(req.Role))
+	invite := &iam.Invite{
+		ExpiresAt:        now().Add(7 * 24 * time.Hour).Format(time.RFC3339),
+		InviteId:         cuid.New(),
+		Invitee:          iam.BasicSubjectProfile{Id: user.Id, Username: lo.ToPtr(user.Username), SubjectType: lo.ToPtr(iam.USER)},
+		Inviter:          org.iamSubject(),
+		OrganizationId:   org.organization.Id,
+		OrganizationName: lo.ToPtr(org.organization.Name),
+		UserId:           lo.ToPtr(user.Id),
+	}
+	org.invites = append(org.invites, invite)
+	writeJSON(w, http.StatusOK, invite)
+}
+
+func (s *Server) deleteUserInvite(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	invite := org.invite(params["inviteId"])
+	if invite == nil {
+		writeNotFound(w, "invite", params["inviteId"])
+		return
+	}
+	org.invites = lo.Reject(org.invites, func(i *iam.Invite, _ int) bool { return i.InviteId == invite.InviteId })
+	org.users = lo.Reject(org.users, func(user *iam.User, _ int) bool {
+		return user.Id == lo.FromPtr(invite.UserId) && user.Status == iam.PENDING
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Users
+
+func (o *organization) createUser(username, fullName string, status iam.UserStatus, organizationRole iam.UserOrganizationRole) *iam.User {
+	createdAt := now()
+	user := &iam.User{
+		AvatarUrl:        fmt.Sprintf("https://avatars.astronomer.io/%v", username),
+		CreatedAt:        createdAt,
+		FullName:         fullName,
+		Id:               cuid.New(),
+		OrganizationRole: lo.ToPtr(organizationRole),
+		Status:           status,
+		UpdatedAt:        createdAt,
+		Username:         username,
+	}
+	o.users = append(o.users, user)
+	return user
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	workspaceId, deploymentId := r.URL.Query().Get("workspaceId"), r.URL.Query().Get("deploymentId")
+	users := lo.FilterMap(org.users, func(user *iam.User, _ int) (iam.User, bool) {
+		if workspaceId != "" && !lo.SomeBy(lo.FromPtr(user.WorkspaceRoles), func(role iam.WorkspaceRole) bool { return role.WorkspaceId == workspaceId }) {
+			return iam.User{}, false
+		}
+		if deploymentId != "" && !lo.SomeBy(lo.FromPtr(user.DeploymentRoles), func(role iam.DeploymentRole) bool { return role.DeploymentId == deploymentId }) {
+			return iam.User{}, false
+		}
+		return *user, true
+	})
+	items, offset, limit, total := paginate(r, users)
+	writeJSON(w, http.StatusOK, iam.UsersPaginated{Users: items, Offset: offset, Limit: limit, TotalCount: total})
+}
+
+func (s *Server) getUser(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	user := org.user(params["userId"])
+	if user == nil {
+		writeNotFound(w, "user", params["userId"])
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) updateUserRoles(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string) {
+	user := org.user(params["userId"])
+	if user == nil {
+		writeNotFound(w, "user", params["userId"])
+		return
+	}
+	var req iam.UpdateUserRolesRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if err := org.validateRoles(req.WorkspaceRoles, req.DeploymentRoles); err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	if req.OrganizationRole != nil {
+		user.OrganizationRole = lo.ToPtr(iam.UserOrganizationRole(*req.OrganizationRole))
+	}
+	user.WorkspaceRoles = lo.ToPtr(lo.FromPtr(req.WorkspaceRoles))
+	user.DeploymentRoles = lo.ToPtr(lo.FromPtr(req.DeploymentRoles))
+	user.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, iam.SubjectRoles{
+		DeploymentRoles:  user.DeploymentRoles,
+		OrganizationRole: (*iam.SubjectRolesOrganizationRole)(user.OrganizationRole),
+		WorkspaceRoles:   user.WorkspaceRoles,
+	})
+}
+
+// Teams
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	names := queryFilter(r, "names")
+	teams := lo.FilterMap(org.teams, func(t *team, _ int) (iam.Team, bool) {
+		return t.team, names(t.team.Name)
+	})
+	items, offset, limit, total := paginate(r, teams)
+	writeJSON(w, http.StatusOK, iam.TeamsPaginated{Teams: items, Offset: offset, Limit: limit, TotalCount: total})
+}
+
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	var req iam.CreateTeamRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	t, err := org.createTeam(req, false)
+	if err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t.team)
+}
+
+// createTeam creates a team, teams of SCIM organizations can only be created by the identity provider
+func (o *organization) createTeam(req iam.CreateTeamRequest, isIdpManaged bool) (*team, error) {
+	if o.organization.IsScimEnabled && !isIdpManaged {
+		return nil, badRequest("teams of organizations with SCIM enabled are managed by the identity provider")
+	}
+	if req.Name == "" {
+		return nil, badRequest("name is required")
+	}
+	for _, memberId := range lo.FromPtr(req.MemberIds) {
+		if o.user(memberId) == nil {
+			return nil, badRequest("user %v not found", memberId)
+		}
+	}
+	createdAt := now()
+	t := &team{
+		team: iam.Team{
+			CreatedAt:        createdAt,
+			CreatedBy:        lo.ToPtr(o.iamSubject()),
+			DeploymentRoles:  &[]iam.DeploymentRole{},
+			Description:      req.Description,
+			Id:               cuid.New(),
+			IsIdpManaged:     isIdpManaged,
+			Name:             req.Name,
+			OrganizationId:   o.organization.Id,
+			OrganizationRole: iam.TeamOrganizationRole(lo.FromPtrOr(req.OrganizationRole, iam.CreateTeamRequestOrganizationRoleORGANIZATIONMEMBER)),
+			RolesCount:       lo.ToPtr(1),
+			UpdatedAt:        createdAt,
+			UpdatedBy:        lo.ToPtr(o.iamSubject()),
+			WorkspaceRoles:   &[]iam.WorkspaceRole{},
+		},
+		memberIds: lo.Uniq(lo.FromPtr(req.MemberIds)),
+	}
+	o.teams = append(o.teams, t)
+	return t, nil
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	t := org.team(params["teamId"])
+	if t == nil {
+		writeNotFound(w, "team", params["teamId"])
+		return
+	}
+	writeJSON(w, http.StatusOK, t.team)
+}
+
+// mutableTeam returns a team that can be changed through the API, and writes an error if there is none
+func (o *organization) mutableTeam(w http.ResponseWriter, teamId string) *team {
+	t := o.team(teamId)
+	if t == nil {
+		writeNotFound(w, "team", teamId)
+		return nil
+	}
+	if t.team.IsIdpManaged {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("team %v is managed by the identity provider", teamId))
+		return nil
+	}
+	return t
+}
+
+func (s *Server) updateTeam(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string) {
+	t := org.mutableTeam(w, params["teamId"])
+	if t == nil {
+		return
+	}
+	var req iam.UpdateTeamRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	t.team.Name = req.Name
+	t.team.Description = req.Description
+	t.team.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, t.team)
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	if org.mutableTeam(w, params["teamId"]) == nil {
+		return
+	}
+	org.teams = lo.Reject(org.teams, func(t *team, _ int) bool { return t.team.Id == params["teamId"] })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listTeamMembers(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string) {
+	t := org.team(params["teamId"])
+	if t == nil {
+		writeNotFound(w, "team", params["teamId"])
+		return
+	}
+	items, offset, limit, total := paginate(r, org.teamMembers(t))
+	writeJSON(w, http.StatusOK, iam.TeamMembersPaginated{TeamMembers: items, Offset: offset, Limit: limit, TotalCount: total})
+}
+
+func (s *Server) addTeamMembers(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string) {
+	t := org.mutableTeam(w, params["teamId"])
+	if t == nil {
+		return
+	}
+	var req iam.AddTeamMembersRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	for _, memberId := range req.MemberIds {
+		if org.user(memberId) == nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("user %v not found", memberId))
+			return
+		}
+	}
+	t.memberIds = lo.Uniq(append(t.memberIds, req.MemberIds...))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) removeTeamMember(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	t := org.mutableTeam(w, params["teamId"])
+	if t == nil {
+		return
+	}
+	if !lo.Contains(t.memberIds, params["memberId"]) {
+		writeNotFound(w, "team member", params["memberId"])
+		return
+	}
+	t.memberIds = lo.Without(t.memberIds, params["memberId"])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) updateTeamRoles(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string) {
+	t := org.team(params["teamId"])
+	if t == nil {
+		writeNotFound(w, "team", params["teamId"])
+		return
+	}
+	var req iam.UpdateTeamRolesRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if err := org.validateRoles(req.WorkspaceRoles, req.DeploymentRoles); err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	t.team.OrganizationRole = iam.TeamOrganizationRole(req.OrganizationRole)
+	t.team.WorkspaceRoles = lo.ToPtr(lo.FromPtr(req.WorkspaceRoles))
+	t.team.DeploymentRoles = lo.ToPtr(lo.FromPtr(req.DeploymentRoles))
+	t.team.RolesCount = lo.ToPtr(1 + len(*t.team.WorkspaceRoles) + len(*t.team.DeploymentRoles))
+	t.team.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, iam.SubjectRoles{
+		DeploymentRoles:  t.team.DeploymentRoles,
+		OrganizationRole: lo.ToPtr(iam.SubjectRolesOrganizationRole(t.team.OrganizationRole)),
+		WorkspaceRoles:   t.team.WorkspaceRoles,
+	})
+}
+
+// API tokens
+
+func (s *Server) listApiTokens(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	query := r.URL.Query()
+	workspaceId, deploymentId := query.Get("workspaceId"), query.Get("deploymentId")
+	onlyOrganizationTokens := query.Get("includeOnlyOrganizationTokens") == "true"
+	apiTokens := lo.FilterMap(org.apiTokens, func(apiToken *iam.ApiToken, _ int) (iam.ApiToken, bool) {
+		hasRole := func(entityId string) bool {
+			return lo.SomeBy(lo.FromPtr(apiToken.Roles), func(role iam.ApiTokenRole) bool { return role.EntityId == entityId })
+		}
+		switch {
+		case onlyOrganizationTokens && apiToken.Type != iam.ApiTokenTypeORGANIZATION:
+			return iam.ApiToken{}, false
+		case workspaceId != "" && !hasRole(workspaceId):
+			return iam.ApiToken{}, false
+		case deploymentId != "" && !hasRole(deploymentId):
+			return iam.ApiToken{}, false
+		}
+		return withoutTokenValue(*apiToken), true
+	})
+	items, offset, limit, total := paginate(r, apiTokens)
+	writeJSON(w, http.StatusOK, iam.ApiTokensPaginated{Tokens: items, Offset: offset, Limit: limit, TotalCount: total})
+}
+
+// withoutTokenValue returns an API token without its value, the value is only returned when the token is created or rotated
+func withoutTokenValue(apiToken iam.ApiToken) iam.ApiToken {
+	apiToken.Token = nil
+	return apiToken
+}
+
+func (s *Server) createApiToken(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	var req iam.CreateApiTokenRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	apiToken, err := org.createApiToken(req)
+	if err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	s.tokens[*apiToken.Token] = org.organization.Id
+	writeJSON(w, http.StatusOK, apiToken)
+}
+
+func (o *organization) createApiToken(req iam.CreateApiTokenRequest) (*iam.ApiToken, error) {
+	if req.Name == "" || req.Role == "" {
+		return nil, badRequest("name and role are required")
+	}
+	entityId := lo.FromPtr(req.EntityId)
+	switch req.Type {
+	case iam.ORGANIZATION:
+		entityId = o.organization.Id
+	case iam.WORKSPACE:
+		if o.workspace(entityId) == nil {
+			return nil, badRequest("workspace %v not found", entityId)
+		}
+	case iam.DEPLOYMENT:
+		if o.deployment(entityId) == nil {
+			return nil, badRequest("deployment %v not found", entityId)
+		}
+	default:
+		return nil, badRequest("invalid type %v", req.Type)
+	}
+	createdAt := now()
+	id := cuid.New()
+	token := FakeApiToken(id, o.organization.Id)
+	apiToken := &iam.ApiToken{
+		CreatedAt:          createdAt,
+		CreatedBy:          lo.ToPtr(o.iamSubject()),
+		Description:        lo.FromPtr(req.Description),
+		ExpiryPeriodInDays: req.TokenExpiryPeriodInDays,
+		Id:                 id,
+		Name:               req.Name,
+		Roles: &[]iam.ApiTokenRole{{
+			EntityId:   entityId,
+			EntityType: iam.ApiTokenRoleEntityType(req.Type),
+			Role:       req.Role,
+		}},
+		ShortToken: token[len(token)-8:],
+		StartAt:    createdAt,
+		Token:      lo.ToPtr(token),
+		Type:       iam.ApiTokenType(req.Type),
+		UpdatedAt:  createdAt,
+		UpdatedBy:  lo.ToPtr(o.iamSubject()),
+	}
+	if req.TokenExpiryPeriodInDays != nil {
+		apiToken.EndAt = lo.ToPtr(createdAt.AddDate(0, 0, *req.TokenExpiryPeriodInDays))
+	}
+	o.apiTokens = append(o.apiTokens, apiToken)
+	return apiToken, nil
+}
+
+func (s *Server) getApiToken(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	apiToken := org.apiToken(params["tokenId"])
+	if apiToken == nil {
+		writeNotFound(w, "API token", params["tokenId"])
+		return
+	}
+	writeJSON(w, http.StatusOK, withoutTokenValue(*apiToken))
+}
+
+func (s *Server) updateApiToken(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string) {
+	apiToken := org.apiToken(params["tokenId"])
+	if apiToken == nil {
+		writeNotFound(w, "API token", params["tokenId"])
+		return
+	}
+	var req iam.UpdateApiTokenRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	apiToken.Name = req.Name
+	apiToken.Description = lo.FromPtr(req.Description)
+	apiToken.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, withoutTokenValue(*apiToken))
+}
+
+func (s *Server) deleteApiToken(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	apiToken := org.apiToken(params["tokenId"])
+	if apiToken == nil {
+		writeNotFound(w, "API token", params["tokenId"])
+		return
+	}
+	s.revokeApiToken(apiToken)
+	org.apiTokens = lo.Reject(org.apiTokens, func(t *iam.ApiToken, _ int) bool { return t.Id == apiToken.Id })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// revokeApiToken removes the value of an API token from the tokens the server accepts
+func (s *Server) revokeApiToken(apiToken *iam.ApiToken) {
+	if apiToken.Token != nil {
+		delete(s.tokens, *apiToken.Token)
+	}
+}
+
+func (s *Server) updateApiTokenRoles(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string) {
+	apiToken := org.apiToken(params["tokenId"])
+	if apiToken == nil {
+		writeNotFound(w, "API token", params["tokenId"])
+		return
+	}
+	var req iam.UpdateApiTokenRolesRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	subjectRoles := iam.SubjectRoles{DeploymentRoles: &[]iam.DeploymentRole{}, WorkspaceRoles: &[]iam.WorkspaceRole{}}
+	for _, role := range req.Roles {
+		switch role.EntityType {
+		case iam.ApiTokenRoleEntityTypeORGANIZATION:
+			if role.EntityId != org.organization.Id {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("organization %v not found", role.EntityId))
+				return
+			}
+			subjectRoles.OrganizationRole = lo.ToPtr(iam.SubjectRolesOrganizationRole(role.Role))
+		case iam.ApiTokenRoleEntityTypeWORKSPACE:
+			*subjectRoles.WorkspaceRoles = append(*subjectRoles.WorkspaceRoles, iam.WorkspaceRole{WorkspaceId: role.EntityId, Role: iam.WorkspaceRoleRole(role.Role)})
+		case iam.ApiTokenRoleEntityTypeDEPLOYMENT:
+			*subjectRoles.DeploymentRoles = append(*subjectRoles.DeploymentRoles, iam.DeploymentRole{DeploymentId: role.EntityId, Role: role.Role})
+		default:
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid entityType %v", role.EntityType))
+			return
+		}
+	}
+	if err := org.validateRoles(subjectRoles.WorkspaceRoles, subjectRoles.DeploymentRoles); err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	apiToken.Roles = lo.ToPtr(req.Roles)
+	apiToken.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, subjectRoles)
+}
+
+func (s *Server) rotateApiToken(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	apiToken := org.apiToken(params["tokenId"])
+	if apiToken == nil {
+		writeNotFound(w, "API token", params["tokenId"])
+		return
+	}
+	s.revokeApiToken(apiToken)
+	token := FakeApiToken(apiToken.Id, org.organization.Id)
+	apiToken.Token = lo.ToPtr(token)
+	apiToken.ShortToken = token[len(token)-8:]
+	apiToken.UpdatedAt = now()
+	s.tokens[token] = org.organization.Id
+	writeJSON(w, http.StatusOK, apiToken)
+}
+
+// FakeApiToken creates the value of an API token in the format of Astro, a JWT with the apiTokenId and permissions
+// claims read by the provider. organizationId is added to the permissions if it is set. The token is not signed.
+func FakeApiToken(apiTokenId, organizationId string) string {
+	claims := clients.JwtClaims{ApiTokenId: apiTokenId}
+	if organizationId != "" {
+		claims.Permissions = []string{"organizationId:" + organizationId}
+	}
+	payload, _ := json.Marshal(claims)
+	// the nonce makes the tokens of a rotation different
+	return fmt.Sprintf("%v.%v.%v",
+		base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)),
+		base64.RawURLEncoding.EncodeToString(payload),
+		cuid.New(),
+	)
+}
@@ -0,0 +1,112 @@
+package fakeapi
+
+import (
+	"time"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/samber/lo"
+)
+
+// clusterOptions are the cluster options of every cloud provider supported by the fake
+func clusterOptions() []platform.ClusterOptions {
+	return []platform.ClusterOptions{
+		newClusterOptions(platform.ClusterOptionsProviderAWS, "us-east-1", []string{"us-east-1", "us-west-2", "eu-central-1"},
+			[]platform.ProviderInstanceType{{Name: "m5.xlarge", Cpu: 4, Memory: "16Gi"}, {Name: "m5.2xlarge", Cpu: 8, Memory: "32Gi"}},
+			[]platform.ProviderInstanceType{{Name: "db.m6g.large", Cpu: 2, Memory: "8Gi"}, {Name: "db.m6g.xlarge", Cpu: 4, Memory: "16Gi"}}),
+		newClusterOptions(platform.ClusterOptionsProviderAZURE, "westus2", []string{"westus2", "eastus2", "westeurope"},
+			[]platform.ProviderInstanceType{{Name: "Standard_D4d_v5", Cpu: 4, Memory: "16Gi"}, {Name: "Standard_D8d_v5", Cpu: 8, Memory: "32Gi"}},
+			[]platform.ProviderInstanceType{{Name: "Standard_D2ds_v4", Cpu: 2, Memory: "8Gi"}, {Name: "Standard_D4ds_v4", Cpu: 4, Memory: "16Gi"}}),
+		newClusterOptions(platform.ClusterOptionsProviderGCP, "us-central1", []string{"us-central1", "us-east4", "europe-west1"},
+			[]platform.ProviderInstanceType{{Name: "e2-standard-4", Cpu: 4, Memory: "16Gi"}, {Name: "e2-standard-8", Cpu: 8, Memory: "32Gi"}},
+			[]platform.ProviderInstanceType{{Name: "Small General Purpose", Cpu: 2, Memory: "8Gi"}, {Name: "Medium General Purpose", Cpu: 4, Memory: "16Gi"}}),
+	}
+}
+
+func newClusterOptions(provider platform.ClusterOptionsProvider, defaultRegion string, regions []string, nodeInstances, databaseInstances []platform.ProviderInstanceType) platform.ClusterOptions {
+	return platform.ClusterOptions{
+		DatabaseInstances:          databaseInstances,
+		DefaultDatabaseInstance:    databaseInstances[0],
+		DefaultNodeInstance:        nodeInstances[0],
+		DefaultPodSubnetRange:      lo.ToPtr("172.21.0.0/19"),
+		DefaultRegion:              platform.ProviderRegion{Name: defaultRegion},
+		DefaultServicePeeringRange: lo.ToPtr("172.23.0.0/20"),
+		DefaultServiceSubnetRange:  lo.ToPtr("172.22.0.0/22"),
+		DefaultVpcSubnetRange:      "172.20.0.0/19",
+		NodeCountDefault:           20,
+		NodeCountMax:               100,
+		NodeCountMin:               2,
+		NodeInstances:              nodeInstances,
+		Provider:                   provider,
+		Regions: lo.Map(regions, func(region string, _ int) platform.ProviderRegion {
+			return platform.ProviderRegion{Name: region}
+		}),
+	}
+}
+
+// runtimeReleases are the Astro Runtime releases supported by the fake
+var runtimeReleases = []platform.RuntimeRelease{
+	{AirflowVersion: "2.9.2", Channel: "stable", ReleaseDate: time.Date(2024, 6, 19, 0, 0, 0, 0, time.UTC), Version: "11.5.0"},
+	{AirflowVersion: "2.9.1", Channel: "stable", ReleaseDate: time.Date(2024, 5, 7, 0, 0, 0, 0, time.UTC), Version: "11.3.0"},
+	{AirflowVersion: "2.8.4", Channel: "stable", ReleaseDate: time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), Version: "10.6.0"},
+	{AirflowVersion: "2.7.3", Channel: "stable", ReleaseDate: time.Date(2023, 11, 8, 0, 0, 0, 0, time.UTC), Version: "9.5.0"},
+}
+
+// airflowVersion returns the Airflow version of an Astro Runtime version
+func airflowVersion(runtimeVersion string) string {
+	release, ok := lo.Find(runtimeReleases, func(release platform.RuntimeRelease) bool {
+		return release.Version == runtimeVersion
+	})
+	if !ok {
+		return runtimeReleases[0].AirflowVersion
+	}
+	return release.AirflowVersion
+}
+
+// deploymentOptions are the deployment options of the fake, they do not depend on the deployment type, executor or cloud provider
+func deploymentOptions() platform.DeploymentOptions {
+	return platform.DeploymentOptions{
+		Executors: []string{string(platform.DeploymentExecutorCELERY), string(platform.DeploymentExecutorKUBERNETES)},
+		ResourceQuotas: platform.ResourceQuotaOptions{
+			DefaultPodSize: platform.ResourceOption{
+				Cpu:    platform.ResourceRange{Floor: "0.25", Default: "1", Ceiling: "6"},
+				Memory: platform.ResourceRange{Floor: "0.5Gi", Default: "2Gi", Ceiling: "12Gi"},
+			},
+			ResourceQuota: platform.ResourceOption{
+				Cpu:    platform.ResourceRange{Floor: "1", Default: "10", Ceiling: "1000"},
+				Memory: platform.ResourceRange{Floor: "2Gi", Default: "20Gi", Ceiling: "2000Gi"},
+			},
+		},
+		RuntimeReleases: runtimeReleases,
+		SchedulerMachines: []platform.SchedulerMachine{
+			{Name: platform.SchedulerMachineNameSMALL, Spec: platform.MachineSpec{Cpu: "1", Memory: "2Gi"}},
+			{Name: platform.SchedulerMachineNameMEDIUM, Spec: platform.MachineSpec{Cpu: "2", Memory: "4Gi"}},
+			{Name: platform.SchedulerMachineNameLARGE, Spec: platform.MachineSpec{Cpu: "4", Memory: "8Gi"}},
+			{Name: platform.SchedulerMachineNameEXTRALARGE, Spec: platform.MachineSpec{Cpu: "8", Memory: "16Gi"}},
+		},
+		WorkerMachines: []platform.WorkerMachine{
+			newWorkerMachine(platform.WorkerMachineNameA5, "1", "2Gi", 5),
+			newWorkerMachine(platform.WorkerMachineNameA10, "2", "4Gi", 10),
+			newWorkerMachine(platform.WorkerMachineNameA20, "4", "8Gi", 20),
+			newWorkerMachine(platform.WorkerMachineNameA40, "8", "16Gi", 40),
+			newWorkerMachine(platform.WorkerMachineNameA60, "12", "24Gi", 60),
+			newWorkerMachine(platform.WorkerMachineNameA120, "24", "48Gi", 120),
+			newWorkerMachine(platform.WorkerMachineNameA160, "32", "64Gi", 160),
+		},
+		WorkerQueues: platform.WorkerQueueOptions{
+			MaxWorkers:        platform.Range{Floor: 1, Default: 10, Ceiling: 30},
+			MinWorkers:        platform.Range{Floor: 0, Default: 1, Ceiling: 30},
+			WorkerConcurrency: platform.Range{Floor: 1, Default: 5, Ceiling: 64},
+		},
+		WorkloadIdentityOptions: &[]platform.WorkloadIdentityOption{
+			{Label: "Default", Role: "arn:aws:iam::123456789012:role/default"},
+		},
+	}
+}
+
+func newWorkerMachine(name platform.WorkerMachineName, cpu, memory string, concurrency float32) platform.WorkerMachine {
+	return platform.WorkerMachine{
+		Concurrency: platform.Range{Floor: 1, Default: concurrency, Ceiling: concurrency * 2},
+		Name:        name,
+		Spec:        platform.MachineSpec{Cpu: cpu, Memory: memory, Concurrency: lo.ToPtr(concurrency)},
+	}
+}
@@ -0,0 +1,759 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/lucsky/cuid"
+	"github.com/samber/lo"
+)
+
+func (s *Server) registerPlatformRoutes() {
+	org := platformPrefix + "/organizations/{organizationId}"
+	s.handle(http.MethodGet, org, s.getOrganization)
+	s.handle(http.MethodPost, org, s.updateOrganization)
+	s.handle(http.MethodGet, org+"/cluster-options", s.getClusterOptions)
+	s.handle(http.MethodGet, org+"/clusters", s.listClusters)
+	s.handle(http.MethodPost, org+"/clusters", s.createCluster)
+	s.handle(http.MethodGet, org+"/clusters/{clusterId}", s.getCluster)
+	s.handle(http.MethodPost, org+"/clusters/{clusterId}", s.updateCluster)
+	s.handle(http.MethodDelete, org+"/clusters/{clusterId}", s.deleteCluster)
+	s.handle(http.MethodGet, org+"/deployment-options", s.getDeploymentOptions)
+	s.handle(http.MethodGet, org+"/deployments", s.listDeployments)
+	s.handle(http.MethodPost, org+"/deployments", s.createDeployment)
+	s.handle(http.MethodGet, org+"/deployments/{deploymentId}", s.getDeployment)
+	s.handle(http.MethodPost, org+"/deployments/{deploymentId}", s.updateDeployment)
+	s.handle(http.MethodDelete, org+"/deployments/{deploymentId}", s.deleteDeployment)
+	s.handle(http.MethodPost, org+"/deployments/{deploymentId}/hibernation-override", s.overrideDeploymentHibernation)
+	s.handle(http.MethodDelete, org+"/deployments/{deploymentId}/hibernation-override", s.deleteDeploymentHibernationOverride)
+	s.handle(http.MethodGet, org+"/workspaces", s.listWorkspaces)
+	s.handle(http.MethodPost, org+"/workspaces", s.createWorkspace)
+	s.handle(http.MethodGet, org+"/workspaces/{workspaceId}", s.getWorkspace)
+	s.handle(http.MethodPost, org+"/workspaces/{workspaceId}", s.updateWorkspace)
+	s.handle(http.MethodDelete, org+"/workspaces/{workspaceId}", s.deleteWorkspace)
+}
+
+func (s *Server) getOrganization(w http.ResponseWriter, _ *http.Request, org *organization, _ map[string]string) {
+	writeJSON(w, http.StatusOK, org.organization)
+}
+
+func (s *Server) updateOrganization(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	var req platform.UpdateOrganizationRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	org.organization.BillingEmail = lo.ToPtr(req.BillingEmail)
+	org.organization.IsScimEnabled = req.IsScimEnabled
+	org.organization.Name = req.Name
+	org.organization.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, org.organization)
+}
+
+func (s *Server) getClusterOptions(w http.ResponseWriter, r *http.Request, _ *organization, _ map[string]string) {
+	provider := queryFilter(r, "provider")
+	writeJSON(w, http.StatusOK, lo.Filter(clusterOptions(), func(options platform.ClusterOptions, _ int) bool {
+		return provider(string(options.Provider))
+	}))
+}
+
+func (s *Server) getDeploymentOptions(w http.ResponseWriter, _ *http.Request, _ *organization, _ map[string]string) {
+	writeJSON(w, http.StatusOK, deploymentOptions())
+}
+
+// Workspaces
+
+func (s *Server) listWorkspaces(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	ids, names := queryFilter(r, "workspaceIds"), queryFilter(r, "names")
+	workspaces := lo.FilterMap(org.workspaces, func(workspace *platform.Workspace, _ int) (platform.Workspace, bool) {
+		return *workspace, ids(workspace.Id) && names(workspace.Name)
+	})
+	items, offset, limit, total := paginate(r, workspaces)
+	writeJSON(w, http.StatusOK, platform.WorkspacesPaginated{Workspaces: items, Offset: offset, Limit: limit, TotalCount: total})
+}
+
+func (s *Server) createWorkspace(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	var req platform.CreateWorkspaceRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	workspace := org.createWorkspace(req.Name, lo.FromPtr(req.Description), lo.FromPtr(req.CicdEnforcedDefault))
+	writeJSON(w, http.StatusOK, workspace)
+}
+
+func (o *organization) createWorkspace(name, description string, cicdEnforcedDefault bool) *platform.Workspace {
+	createdAt := now()
+	workspace := &platform.Workspace{
+		CicdEnforcedDefault: cicdEnforcedDefault,
+		CreatedAt:           createdAt,
+		CreatedBy:           lo.ToPtr(o.platformSubject()),
+		Description:         lo.ToPtr(description),
+		Id:                  cuid.New(),
+		Name:                name,
+		OrganizationId:      o.organization.Id,
+		OrganizationName:    lo.ToPtr(o.organization.Name),
+		UpdatedAt:           createdAt,
+		UpdatedBy:           lo.ToPtr(o.platformSubject()),
+	}
+	o.workspaces = append(o.workspaces, workspace)
+	return workspace
+}
+
+func (s *Server) getWorkspace(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	workspace := org.workspace(params["workspaceId"])
+	if workspace == nil {
+		writeNotFound(w, "workspace", params["workspaceId"])
+		return
+	}
+	writeJSON(w, http.StatusOK, workspace)
+}
+
+func (s *Server) updateWorkspace(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string) {
+	workspace := org.workspace(params["workspaceId"])
+	if workspace == nil {
+		writeNotFound(w, "workspace", params["workspaceId"])
+		return
+	}
+	var req platform.UpdateWorkspaceRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	workspace.Name = req.Name
+	workspace.Description = lo.ToPtr(req.Description)
+	workspace.CicdEnforcedDefault = req.CicdEnforcedDefault
+	workspace.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, workspace)
+}
+
+func (s *Server) deleteWorkspace(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	if org.workspace(params["workspaceId"]) == nil {
+		writeNotFound(w, "workspace", params["workspaceId"])
+		return
+	}
+	if lo.SomeBy(org.deployments, func(d *deployment) bool { return d.deployment.WorkspaceId == params["workspaceId"] }) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("workspace %v has deployments, delete them before deleting the workspace", params["workspaceId"]))
+		return
+	}
+	org.deleteWorkspace(params["workspaceId"])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Clusters
+
+// clusterRequest has the fields of every cluster create and update request, the create and update request bodies are unions
+type clusterRequest struct {
+	CloudProvider       string                            `json:"cloudProvider"`
+	ClusterType         string                            `json:"clusterType"`
+	DbInstanceType      *string                           `json:"dbInstanceType"`
+	K8sTags             *[]platform.ClusterK8sTag         `json:"k8sTags"`
+	Name                string                            `json:"name"`
+	NodePools           *[]platform.UpdateNodePoolRequest `json:"nodePools"`
+	PodSubnetRange      *string                           `json:"podSubnetRange"`
+	ProviderAccount     *string                           `json:"providerAccount"`
+	Region              string                            `json:"region"`
+	ServicePeeringRange *string                           `json:"servicePeeringRange"`
+	ServiceSubnetRange  *string                           `json:"serviceSubnetRange"`
+	TenantId            *string                           `json:"tenantId"`
+	Type                string                            `json:"type"`
+	VpcSubnetRange      string                            `json:"vpcSubnetRange"`
+	WorkspaceIds        *[]string                         `json:"workspaceIds"`
+}
+
+func (s *Server) listClusters(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	names, provider := queryFilter(r, "names"), queryFilter(r, "provider")
+	clusters := lo.FilterMap(org.clusters, func(c *cluster, _ int) (platform.Cluster, bool) {
+		return c.cluster, names(c.cluster.Name) && provider(string(c.cluster.CloudProvider))
+	})
+	items, offset, limit, total := paginate(r, clusters)
+	writeJSON(w, http.StatusOK, platform.ClustersPaginated{Clusters: items, Offset: offset, Limit: limit, TotalCount: total})
+}
+
+func (s *Server) createCluster(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	var req clusterRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	c, err := org.createCluster(req, s.options.ClusterPendingPolls)
+	if err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, c.cluster)
+}
+
+func (o *organization) createCluster(req clusterRequest, pendingPolls int) (*cluster, error) {
+	if req.Name == "" || req.Region == "" || req.VpcSubnetRange == "" {
+		return nil, badRequest("name, region and vpcSubnetRange are required")
+	}
+	options, ok := lo.Find(clusterOptions(), func(options platform.ClusterOptions) bool {
+		return string(options.Provider) == req.CloudProvider
+	})
+	if !ok {
+		return nil, badRequest("invalid cloudProvider %v", req.CloudProvider)
+	}
+	if req.Type != string(platform.ClusterTypeDEDICATED) && req.Type != string(platform.ClusterTypeHYBRID) {
+		return nil, badRequest("invalid type %v", req.Type)
+	}
+	if err := o.validateWorkspaceIds(req.WorkspaceIds); err != nil {
+		return nil, err
+	}
+	createdAt := now()
+	id := cuid.New()
+	c := &cluster{
+		cluster: platform.Cluster{
+			CloudProvider:       platform.ClusterCloudProvider(req.CloudProvider),
+			CreatedAt:           createdAt,
+			DbInstanceType:      lo.FromPtrOr(req.DbInstanceType, options.DefaultDatabaseInstance.Name),
+			Id:                  id,
+			IsLimited:           lo.ToPtr(false),
+			Metadata:            &platform.ClusterMetadata{ExternalIPs: &[]string{"35.100.100.1"}, OidcIssuerUrl: lo.ToPtr(fmt.Sprintf("https://oidc.%v.astronomer.run", id))},
+			Name:                req.Name,
+			OrganizationId:      o.organization.Id,
+			PodSubnetRange:      req.PodSubnetRange,
+			ProviderAccount:     req.ProviderAccount,
+			Region:              req.Region,
+			ServicePeeringRange: req.ServicePeeringRange,
+			ServiceSubnetRange:  req.ServiceSubnetRange,
+			Status:              platform.ClusterStatusCREATING,
+			Tags:                req.K8sTags,
+			TenantId:            req.TenantId,
+			Type:                platform.ClusterType(req.Type),
+			UpdatedAt:           createdAt,
+			VpcSubnetRange:      req.VpcSubnetRange,
+			WorkspaceIds:        lo.ToPtr(lo.FromPtr(req.WorkspaceIds)),
+		},
+		pendingPolls: pendingPolls,
+	}
+	nodePools := lo.FromPtr(req.NodePools)
+	if len(nodePools) == 0 {
+		nodePools = []platform.UpdateNodePoolRequest{{
+			IsDefault:        lo.ToPtr(true),
+			MaxNodeCount:     options.NodeCountDefault,
+			Name:             "default",
+			NodeInstanceType: options.DefaultNodeInstance.Name,
+		}}
+	}
+	c.setNodePools(nodePools)
+	o.clusters = append(o.clusters, c)
+	return c, nil
+}
+
+// setNodePools replaces the node pools of a cluster, node pools with an ID or the name of an existing node pool are updated
+func (c *cluster) setNodePools(requests []platform.UpdateNodePoolRequest) {
+	existing := lo.KeyBy(lo.FromPtr(c.cluster.NodePools), func(nodePool platform.NodePool) string { return nodePool.Name })
+	nodePools := make([]platform.NodePool, len(requests))
+	for i, req := range requests {
+		nodePool, ok := existing[req.Name]
+		if req.Id != nil {
+			nodePool, ok = lo.Find(lo.FromPtr(c.cluster.NodePools), func(n platform.NodePool) bool { return n.Id == *req.Id })
+		}
+		if !ok {
+			nodePool = platform.NodePool{
+				CloudProvider: platform.NodePoolCloudProvider(c.cluster.CloudProvider),
+				ClusterId:     c.cluster.Id,
+				CreatedAt:     now(),
+				Id:            cuid.New(),
+			}
+		}
+		nodePool.IsDefault = lo.FromPtr(req.IsDefault)
+		nodePool.MaxNodeCount = req.MaxNodeCount
+		nodePool.Name = req.Name
+		nodePool.NodeInstanceType = req.NodeInstanceType
+		nodePool.SupportedAstroMachines = &[]string{"A5", "A10", "A20"}
+		nodePool.UpdatedAt = now()
+		nodePools[i] = nodePool
+	}
+	c.cluster.NodePools = &nodePools
+}
+
+// settle moves a pending cluster to CREATED once it has been read ClusterPendingPolls times
+func (c *cluster) settle() {
+	if c.cluster.Status != platform.ClusterStatusCREATING && c.cluster.Status != platform.ClusterStatusUPDATING {
+		return
+	}
+	if c.pendingPolls > 0 {
+		c.pendingPolls--
+		return
+	}
+	c.cluster.Status = platform.ClusterStatusCREATED
+}
+
+func (s *Server) getCluster(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	c := org.cluster(params["clusterId"])
+	if c == nil {
+		writeNotFound(w, "cluster", params["clusterId"])
+		return
+	}
+	c.settle()
+	writeJSON(w, http.StatusOK, c.cluster)
+}
+
+func (s *Server) updateCluster(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string) {
+	c := org.cluster(params["clusterId"])
+	if c == nil {
+		writeNotFound(w, "cluster", params["clusterId"])
+		return
+	}
+	var req clusterRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.ClusterType != string(c.cluster.Type) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("clusterType %v does not match the type %v of cluster %v", req.ClusterType, c.cluster.Type, c.cluster.Id))
+		return
+	}
+	if err := org.validateWorkspaceIds(req.WorkspaceIds); err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	if req.WorkspaceIds != nil {
+		c.cluster.WorkspaceIds = lo.ToPtr(*req.WorkspaceIds)
+	}
+	if c.cluster.Type == platform.ClusterTypeDEDICATED {
+		if req.Name == "" {
+			writeError(w, http.StatusBadRequest, "name is required")
+			return
+		}
+		c.cluster.Name = req.Name
+		c.cluster.Tags = req.K8sTags
+		if req.DbInstanceType != nil {
+			c.cluster.DbInstanceType = *req.DbInstanceType
+		}
+		if req.NodePools != nil {
+			c.setNodePools(*req.NodePools)
+		}
+	}
+	c.cluster.Status = platform.ClusterStatusUPDATING
+	c.cluster.UpdatedAt = now()
+	c.pendingPolls = s.options.ClusterPendingPolls
+	writeJSON(w, http.StatusOK, c.cluster)
+}
+
+func (s *Server) deleteCluster(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	if org.cluster(params["clusterId"]) == nil {
+		writeNotFound(w, "cluster", params["clusterId"])
+		return
+	}
+	if lo.SomeBy(org.deployments, func(d *deployment) bool { return lo.FromPtr(d.deployment.ClusterId) == params["clusterId"] }) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("cluster %v has deployments and cannot be deleted", params["clusterId"]))
+		return
+	}
+	org.clusters = lo.Reject(org.clusters, func(c *cluster, _ int) bool { return c.cluster.Id == params["clusterId"] })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (o *organization) validateWorkspaceIds(workspaceIds *[]string) error {
+	for _, workspaceId := range lo.FromPtr(workspaceIds) {
+		if o.workspace(workspaceId) == nil {
+			return badRequest("workspace %v not found", workspaceId)
+		}
+	}
+	return nil
+}
+
+// Deployments
+
+// deploymentRequest has the fields of every deployment create and update request, the create and update request bodies are unions
+type deploymentRequest struct {
+	AstroRuntimeVersion  string                                           `json:"astroRuntimeVersion"`
+	CloudProvider        *string                                          `json:"cloudProvider"`
+	ClusterId            *string                                          `json:"clusterId"`
+	ContactEmails        *[]string                                        `json:"contactEmails"`
+	DefaultTaskPodCpu    *string                                          `json:"defaultTaskPodCpu"`
+	DefaultTaskPodMemory *string                                          `json:"defaultTaskPodMemory"`
+	Description          *string                                          `json:"description"`
+	EnvironmentVariables *[]platform.DeploymentEnvironmentVariableRequest `json:"environmentVariables"`
+	Executor             string                                           `json:"executor"`
+	IsCicdEnforced       bool                                             `json:"isCicdEnforced"`
+	IsDagDeployEnabled   bool                                             `json:"isDagDeployEnabled"`
+	IsDevelopmentMode    *bool                                            `json:"isDevelopmentMode"`
+	IsHighAvailability   *bool                                            `json:"isHighAvailability"`
+	Name                 string                                           `json:"name"`
+	Region               *string                                          `json:"region"`
+	ResourceQuotaCpu     *string                                          `json:"resourceQuotaCpu"`
+	ResourceQuotaMemory  *string                                          `json:"resourceQuotaMemory"`
+	ScalingSpec          *platform.DeploymentScalingSpecRequest           `json:"scalingSpec"`
+	Scheduler            *platform.DeploymentInstanceSpecRequest          `json:"scheduler"`
+	SchedulerSize        *string                                          `json:"schedulerSize"`
+	TaskPodNodePoolId    *string                                          `json:"taskPodNodePoolId"`
+	Type                 string                                           `json:"type"`
+	WorkerQueues         *[]workerQueueRequest                            `json:"workerQueues"`
+	WorkloadIdentity     *string                                          `json:"workloadIdentity"`
+	WorkspaceId          string                                           `json:"workspaceId"`
+}
+
+// workerQueueRequest has the fields of hosted and hybrid worker queue requests
+type workerQueueRequest struct {
+	AstroMachine      *string `json:"astroMachine"`
+	Id                *string `json:"id"`
+	IsDefault         bool    `json:"isDefault"`
+	MaxWorkerCount    int     `json:"maxWorkerCount"`
+	MinWorkerCount    int     `json:"minWorkerCount"`
+	Name              string  `json:"name"`
+	NodePoolId        *string `json:"nodePoolId"`
+	WorkerConcurrency int     `json:"workerConcurrency"`
+}
+
+func (s *Server) listDeployments(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	ids, names, workspaceIds := queryFilter(r, "deploymentIds"), queryFilter(r, "names"), queryFilter(r, "workspaceIds")
+	deployments := lo.FilterMap(org.deployments, func(d *deployment, _ int) (platform.Deployment, bool) {
+		return d.deployment, ids(d.deployment.Id) && names(d.deployment.Name) && workspaceIds(d.deployment.WorkspaceId)
+	})
+	items, offset, limit, total := paginate(r, deployments)
+	writeJSON(w, http.StatusOK, platform.DeploymentsPaginated{Deployments: items, Offset: offset, Limit: limit, TotalCount: total})
+}
+
+func (s *Server) createDeployment(w http.ResponseWriter, r *http.Request, org *organization, _ map[string]string) {
+	var req deploymentRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	d, err := org.createDeployment(req, s.options.DeploymentPendingPolls)
+	if err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, d.deployment)
+}
+
+func (o *organization) createDeployment(req deploymentRequest, pendingPolls int) (*deployment, error) {
+	if req.AstroRuntimeVersion == "" {
+		return nil, badRequest("astroRuntimeVersion is required")
+	}
+	createdAt := now()
+	id := cuid.New()
+	namespace := fmt.Sprintf("fake-%v", strings.ToLower(id[len(id)-8:]))
+	d := &deployment{
+		deployment: platform.Deployment{
+			AirflowVersion:           airflowVersion(req.AstroRuntimeVersion),
+			AstroRuntimeVersion:      req.AstroRuntimeVersion,
+			CreatedAt:                createdAt,
+			CreatedBy:                o.platformSubject(),
+			ExternalIPs:              &[]string{"35.100.100.2"},
+			Id:                       id,
+			ImageRepository:          "quay.io/astronomer/astro-runtime",
+			ImageTag:                 req.AstroRuntimeVersion,
+			ImageVersion:             lo.ToPtr(req.AstroRuntimeVersion),
+			Namespace:                namespace,
+			OidcIssuerUrl:            lo.ToPtr(fmt.Sprintf("https://oidc.%v.astronomer.run", namespace)),
+			OrganizationId:           o.organization.Id,
+			RuntimeVersion:           req.AstroRuntimeVersion,
+			Status:                   platform.DeploymentStatusCREATING,
+			Type:                     lo.ToPtr(platform.DeploymentType(req.Type)),
+			WebServerAirflowApiUrl:   fmt.Sprintf("%v.astronomer.run/%v/api/v1", o.organization.Id, namespace),
+			WebServerCpu:             "0.5",
+			WebServerIngressHostname: fmt.Sprintf("%v.astronomer.run", o.organization.Id),
+			WebServerMemory:          "2Gi",
+			WebServerReplicas:        lo.ToPtr(1),
+			WebServerUrl:             fmt.Sprintf("%v.astronomer.run/%v", o.organization.Id, namespace),
+		},
+		pendingPolls: pendingPolls,
+	}
+	switch req.Type {
+	case string(platform.DeploymentTypeSTANDARD):
+		if req.CloudProvider == nil || req.Region == nil {
+			return nil, badRequest("cloudProvider and region are required for STANDARD deployments")
+		}
+		d.deployment.CloudProvider = lo.ToPtr(platform.DeploymentCloudProvider(*req.CloudProvider))
+		d.deployment.Region = req.Region
+	case string(platform.DeploymentTypeDEDICATED), string(platform.DeploymentTypeHYBRID):
+		c := o.cluster(lo.FromPtr(req.ClusterId))
+		if c == nil {
+			return nil, badRequest("cluster %v not found", lo.FromPtr(req.ClusterId))
+		}
+		if string(c.cluster.Type) != req.Type {
+			return nil, badRequest("cluster %v is not a %v cluster", c.cluster.Id, req.Type)
+		}
+		d.deployment.CloudProvider = lo.ToPtr(platform.DeploymentCloudProvider(c.cluster.CloudProvider))
+		d.deployment.ClusterId = lo.ToPtr(c.cluster.Id)
+		d.deployment.ClusterName = lo.ToPtr(c.cluster.Name)
+		d.deployment.Region = lo.ToPtr(c.cluster.Region)
+	default:
+		return nil, badRequest("invalid type %v", req.Type)
+	}
+	if err := o.updateDeployment(d, req); err != nil {
+		return nil, err
+	}
+	o.deployments = append(o.deployments, d)
+	return d, nil
+}
+
+// updateDeployment sets the fields of a deployment that can be set by both create and update requests
+func (o *organization) updateDeployment(d *deployment, req deploymentRequest) error {
+	if req.Name == "" {
+		return badRequest("name is required")
+	}
+	if req.Type != string(lo.FromPtr(d.deployment.Type)) {
+		return badRequest("type %v does not match the type %v of deployment %v", req.Type, lo.FromPtr(d.deployment.Type), d.deployment.Id)
+	}
+	if req.Executor != string(platform.DeploymentExecutorCELERY) && req.Executor != string(platform.DeploymentExecutorKUBERNETES) {
+		return badRequest("invalid executor %v", req.Executor)
+	}
+	if req.Executor == string(platform.DeploymentExecutorKUBERNETES) && len(lo.FromPtr(req.WorkerQueues)) > 0 {
+		return badRequest("worker queues are not supported for the KUBERNETES executor")
+	}
+	workspace := o.workspace(req.WorkspaceId)
+	if workspace == nil {
+		return badRequest("workspace %v not found", req.WorkspaceId)
+	}
+	if c := o.cluster(lo.FromPtr(d.deployment.ClusterId)); c != nil {
+		workspaceIds := lo.FromPtr(c.cluster.WorkspaceIds)
+		if len(workspaceIds) > 0 && !lo.Contains(workspaceIds, workspace.Id) {
+			return badRequest("workspace %v is not authorized on cluster %v", workspace.Id, c.cluster.Id)
+		}
+	}
+	workerQueues, err := o.workerQueues(d, lo.FromPtr(req.WorkerQueues))
+	if err != nil {
+		return err
+	}
+
+	d.deployment.ContactEmails = req.ContactEmails
+	d.deployment.Description = req.Description
+	d.deployment.EnvironmentVariables = lo.ToPtr(environmentVariables(lo.FromPtr(req.EnvironmentVariables)))
+	d.deployment.Executor = lo.ToPtr(platform.DeploymentExecutor(req.Executor))
+	d.deployment.IsCicdEnforced = req.IsCicdEnforced
+	d.deployment.IsDagDeployEnabled = req.IsDagDeployEnabled
+	d.deployment.Name = req.Name
+	d.deployment.UpdatedAt = now()
+	d.deployment.UpdatedBy = o.platformSubject()
+	d.deployment.WorkerQueues = workerQueues
+	d.deployment.WorkloadIdentity = lo.ToPtr(lo.FromPtrOr(req.WorkloadIdentity, fmt.Sprintf("arn:aws:iam::123456789012:role/%v", d.deployment.Namespace)))
+	d.deployment.WorkspaceId = workspace.Id
+	d.deployment.WorkspaceName = lo.ToPtr(workspace.Name)
+
+	if lo.FromPtr(d.deployment.Type) == platform.DeploymentTypeHYBRID {
+		scheduler := lo.FromPtr(req.Scheduler)
+		d.deployment.SchedulerAu = lo.ToPtr(scheduler.Au)
+		d.deployment.SchedulerCpu = fmt.Sprintf("%g", float64(scheduler.Au)/10)
+		d.deployment.SchedulerMemory = fmt.Sprintf("%gGi", float64(scheduler.Au)*0.375)
+		d.deployment.SchedulerReplicas = scheduler.Replicas
+		d.deployment.TaskPodNodePoolId = req.TaskPodNodePoolId
+		return nil
+	}
+
+	schedulerSize := lo.FromPtr(req.SchedulerSize)
+	schedulerMachine, ok := lo.Find(deploymentOptions().SchedulerMachines, func(machine platform.SchedulerMachine) bool {
+		return string(machine.Name) == schedulerSize
+	})
+	if !ok {
+		return badRequest("invalid schedulerSize %v", schedulerSize)
+	}
+	d.deployment.DefaultTaskPodCpu = req.DefaultTaskPodCpu
+	d.deployment.DefaultTaskPodMemory = req.DefaultTaskPodMemory
+	d.deployment.IsDevelopmentMode = lo.ToPtr(lo.FromPtr(req.IsDevelopmentMode))
+	d.deployment.IsHighAvailability = lo.ToPtr(lo.FromPtr(req.IsHighAvailability))
+	d.deployment.ResourceQuotaCpu = req.ResourceQuotaCpu
+	d.deployment.ResourceQuotaMemory = req.ResourceQuotaMemory
+	d.deployment.SchedulerCpu = schedulerMachine.Spec.Cpu
+	d.deployment.SchedulerMemory = schedulerMachine.Spec.Memory
+	d.deployment.SchedulerReplicas = lo.Ternary(lo.FromPtr(req.IsHighAvailability), 2, 1)
+	d.deployment.SchedulerSize = lo.ToPtr(platform.DeploymentSchedulerSize(schedulerSize))
+	d.deployment.ScalingSpec = nil
+	if req.ScalingSpec != nil && req.ScalingSpec.HibernationSpec != nil {
+		if !lo.FromPtr(req.IsDevelopmentMode) {
+			return badRequest("hibernation is only supported for development mode deployments")
+		}
+		d.deployment.ScalingSpec = &platform.DeploymentScalingSpec{
+			HibernationSpec: &platform.DeploymentHibernationSpec{
+				Schedules: req.ScalingSpec.HibernationSpec.Schedules,
+			},
+		}
+		if override := req.ScalingSpec.HibernationSpec.Override; override != nil {
+			d.hibernationOverride = &platform.DeploymentHibernationOverride{IsHibernating: override.IsHibernating}
+			if override.OverrideUntil != nil {
+				overrideUntil, err := time.Parse(time.RFC3339, *override.OverrideUntil)
+				if err != nil {
+					return badRequest("invalid overrideUntil %v", *override.OverrideUntil)
+				}
+				d.hibernationOverride.OverrideUntil = &overrideUntil
+			}
+		}
+	}
+	d.settleHibernation()
+	return nil
+}
+
+// workerQueues converts worker queue requests to the worker queues of a deployment, worker queues keep their ID when updated
+func (o *organization) workerQueues(d *deployment, requests []workerQueueRequest) (*[]platform.WorkerQueue, error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	existing := lo.KeyBy(lo.FromPtr(d.deployment.WorkerQueues), func(workerQueue platform.WorkerQueue) string { return workerQueue.Name })
+	workerMachines := lo.KeyBy(deploymentOptions().WorkerMachines, func(machine platform.WorkerMachine) string { return string(machine.Name) })
+	workerQueues := make([]platform.WorkerQueue, len(requests))
+	for i, req := range requests {
+		workerQueue := platform.WorkerQueue{
+			AstroMachine:      req.AstroMachine,
+			Id:                lo.FromPtrOr(req.Id, existing[req.Name].Id),
+			IsDefault:         req.IsDefault,
+			MaxWorkerCount:    req.MaxWorkerCount,
+			MinWorkerCount:    req.MinWorkerCount,
+			Name:              req.Name,
+			NodePoolId:        req.NodePoolId,
+			PodCpu:            "1",
+			PodMemory:         "2Gi",
+			WorkerConcurrency: req.WorkerConcurrency,
+		}
+		if workerQueue.Id == "" {
+			workerQueue.Id = cuid.New()
+		}
+		if req.AstroMachine != nil {
+			machine, ok := workerMachines[*req.AstroMachine]
+			if !ok {
+				return nil, badRequest("invalid astroMachine %v", *req.AstroMachine)
+			}
+			workerQueue.PodCpu = machine.Spec.Cpu
+			workerQueue.PodMemory = machine.Spec.Memory
+		}
+		if req.NodePoolId != nil && d.deployment.ClusterId != nil {
+			c := o.cluster(*d.deployment.ClusterId)
+			if c == nil || !lo.SomeBy(lo.FromPtr(c.cluster.NodePools), func(nodePool platform.NodePool) bool { return nodePool.Id == *req.NodePoolId }) {
+				return nil, badRequest("node pool %v not found", *req.NodePoolId)
+			}
+		}
+		workerQueues[i] = workerQueue
+	}
+	return &workerQueues, nil
+}
+
+// environmentVariables converts environment variable requests to environment variables, the values of secrets are not returned by the API
+func environmentVariables(requests []platform.DeploymentEnvironmentVariableRequest) []platform.DeploymentEnvironmentVariable {
+	updatedAt := now().Format(time.RFC3339)
+	return lo.Map(requests, func(req platform.DeploymentEnvironmentVariableRequest, _ int) platform.DeploymentEnvironmentVariable {
+		envVar := platform.DeploymentEnvironmentVariable{
+			IsSecret:  req.IsSecret,
+			Key:       req.Key,
+			UpdatedAt: updatedAt,
+			Value:     req.Value,
+		}
+		if req.IsSecret {
+			envVar.Value = nil
+		}
+		return envVar
+	})
+}
+
+// settle moves a creating deployment to HEALTHY once it has been read DeploymentPendingPolls times
+func (d *deployment) settle() {
+	if d.deployment.Status == platform.DeploymentStatusCREATING {
+		if d.pendingPolls > 0 {
+			d.pendingPolls--
+			return
+		}
+		d.deployment.Status = platform.DeploymentStatusHEALTHY
+	}
+	d.settleHibernation()
+}
+
+// settleHibernation sets the hibernation status of a development mode deployment from its hibernation override
+func (d *deployment) settleHibernation() {
+	if !lo.FromPtr(d.deployment.IsDevelopmentMode) {
+		d.hibernationOverride = nil
+		d.deployment.ScalingStatus = nil
+		return
+	}
+	if d.hibernationOverride != nil && d.hibernationOverride.OverrideUntil != nil && d.hibernationOverride.OverrideUntil.Before(time.Now()) {
+		d.hibernationOverride = nil
+	}
+	isHibernating := d.hibernationOverride != nil && lo.FromPtr(d.hibernationOverride.IsHibernating)
+	if d.hibernationOverride != nil {
+		d.hibernationOverride.IsActive = lo.ToPtr(true)
+	}
+	if d.deployment.ScalingSpec != nil && d.deployment.ScalingSpec.HibernationSpec != nil {
+		d.deployment.ScalingSpec.HibernationSpec.Override = d.hibernationOverride
+	}
+	hibernationStatus := &platform.DeploymentHibernationStatus{IsHibernating: isHibernating}
+	if d.hibernationOverride != nil {
+		hibernationStatus.Reason = lo.ToPtr("Hibernation override is active")
+	}
+	d.deployment.ScalingStatus = &platform.DeploymentScalingStatus{HibernationStatus: hibernationStatus}
+	if d.deployment.Status == platform.DeploymentStatusHEALTHY && isHibernating {
+		d.deployment.Status = platform.DeploymentStatusHIBERNATING
+	}
+	if d.deployment.Status == platform.DeploymentStatusHIBERNATING && !isHibernating {
+		d.deployment.Status = platform.DeploymentStatusHEALTHY
+	}
+}
+
+func (s *Server) getDeployment(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	d := org.deployment(params["deploymentId"])
+	if d == nil {
+		writeNotFound(w, "deployment", params["deploymentId"])
+		return
+	}
+	d.settle()
+	writeJSON(w, http.StatusOK, d.deployment)
+}
+
+func (s *Server) updateDeployment(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string) {
+	d := org.deployment(params["deploymentId"])
+	if d == nil {
+		writeNotFound(w, "deployment", params["deploymentId"])
+		return
+	}
+	var req deploymentRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	// validate the request on a copy so a rejected update leaves the deployment unchanged
+	updated := *d
+	if err := org.updateDeployment(&updated, req); err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	*d = updated
+	writeJSON(w, http.StatusOK, d.deployment)
+}
+
+func (s *Server) deleteDeployment(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	if org.deployment(params["deploymentId"]) == nil {
+		writeNotFound(w, "deployment", params["deploymentId"])
+		return
+	}
+	org.deleteDeployment(params["deploymentId"])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) overrideDeploymentHibernation(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string) {
+	d := org.deployment(params["deploymentId"])
+	if d == nil {
+		writeNotFound(w, "deployment", params["deploymentId"])
+		return
+	}
+	if !lo.FromPtr(d.deployment.IsDevelopmentMode) {
+		writeError(w, http.StatusBadRequest, "hibernation is only supported for development mode deployments")
+		return
+	}
+	var req platform.OverrideDeploymentHibernationBody
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	d.hibernationOverride = &platform.DeploymentHibernationOverride{
+		IsActive:      lo.ToPtr(true),
+		IsHibernating: lo.ToPtr(req.IsHibernating),
+		OverrideUntil: req.OverrideUntil,
+	}
+	d.settleHibernation()
+	writeJSON(w, http.StatusOK, d.hibernationOverride)
+}
+
+func (s *Server) deleteDeploymentHibernationOverride(w http.ResponseWriter, _ *http.Request, org *organization, params map[string]string) {
+	d := org.deployment(params["deploymentId"])
+	if d == nil {
+		writeNotFound(w, "deployment", params["deploymentId"])
+		return
+	}
+	d.hibernationOverride = nil
+	d.settleHibernation()
+	w.WriteHeader(http.StatusNoContent)
+}
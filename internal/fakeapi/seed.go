@@ -0,0 +1,145 @@
+package fakeapi
+
+import (
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/samber/lo"
+)
+
+// IDs of the API tokens of the organizations created by Seed
+const (
+	HostedOrganizationApiTokenId     = "clfakehostedorgapitoken01"
+	HybridOrganizationApiTokenId     = "clfakehybridorgapitoken01"
+	HostedScimOrganizationApiTokenId = "clfakescimorgapitoken0001"
+)
+
+// Tokens of the organizations created by Seed
+var (
+	HostedOrganizationToken     = FakeApiToken(HostedOrganizationApiTokenId, "")
+	HybridOrganizationToken     = FakeApiToken(HybridOrganizationApiTokenId, "")
+	HostedScimOrganizationToken = FakeApiToken(HostedScimOrganizationApiTokenId, "")
+)
+
+// Fixtures are the IDs of the objects created by Seed that the acceptance tests expect to exist
+type Fixtures struct {
+	HostedOrganizationId       string
+	HostedWorkspaceId          string
+	HostedDeploymentId         string
+	HostedStandardDeploymentId string
+	HostedTeamId               string
+	HostedUserId               string
+	HostedDummyUserId          string
+	HostedApiTokenId           string
+	HybridOrganizationId       string
+	HybridClusterId            string
+	HybridNodePoolId           string
+	HybridDryRunClusterId      string
+	HostedScimOrganizationId   string
+}
+
+// Seed creates a hosted, a hybrid and a hosted SCIM organization with the objects the acceptance tests expect to exist
+func (s *Server) Seed() Fixtures {
+	var fixtures Fixtures
+	fixtures.HostedOrganizationId = s.AddOrganization("Hosted Organization", HostedOrganizationToken, platform.OrganizationProductHOSTED, false)
+	fixtures.HybridOrganizationId = s.AddOrganization("Hybrid Organization", HybridOrganizationToken, platform.OrganizationProductHYBRID, false)
+	fixtures.HostedScimOrganizationId = s.AddOrganization("Hosted SCIM Organization", HostedScimOrganizationToken, platform.OrganizationProductHOSTED, true)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hosted := s.organizations[fixtures.HostedOrganizationId]
+	user := hosted.createUser("user@astronomer.io", "Test User", iam.ACTIVE, iam.ORGANIZATIONOWNER)
+	dummyUser := hosted.createUser("dummy-user@astronomer.io", "Dummy User", iam.ACTIVE, iam.ORGANIZATIONMEMBER)
+	workspace := hosted.createWorkspace("Acceptance Test Workspace", "Workspace used by the acceptance tests", false)
+	dedicatedCluster := lo.Must(hosted.createCluster(clusterRequest{
+		CloudProvider:  string(platform.ClusterCloudProviderAWS),
+		Name:           "Acceptance Test Dedicated Cluster",
+		Region:         "us-east-1",
+		Type:           string(platform.ClusterTypeDEDICATED),
+		VpcSubnetRange: "172.20.0.0/20",
+	}, 0))
+	dedicatedCluster.settle()
+	dedicatedDeployment := lo.Must(hosted.createDeployment(hostedDeploymentRequest("Acceptance Test Dedicated Deployment", workspace.Id, platform.DeploymentTypeDEDICATED, func(req *deploymentRequest) {
+		req.ClusterId = lo.ToPtr(dedicatedCluster.cluster.Id)
+	}), 0))
+	dedicatedDeployment.settle()
+	standardDeployment := lo.Must(hosted.createDeployment(hostedDeploymentRequest("Acceptance Test Standard Deployment", workspace.Id, platform.DeploymentTypeSTANDARD, func(req *deploymentRequest) {
+		req.CloudProvider = lo.ToPtr(string(platform.DeploymentCloudProviderAWS))
+		req.Region = lo.ToPtr("us-east-1")
+	}), 0))
+	standardDeployment.settle()
+	hostedTeam := lo.Must(hosted.createTeam(iam.CreateTeamRequest{
+		Description: lo.ToPtr("Team used by the acceptance tests"),
+		MemberIds:   &[]string{user.Id},
+		Name:        "Acceptance Test Team",
+	}, false))
+	apiToken := lo.Must(hosted.createApiToken(iam.CreateApiTokenRequest{
+		Description: lo.ToPtr("API token used by the acceptance tests"),
+		Name:        "Acceptance Test API Token",
+		Role:        string(iam.ORGANIZATIONMEMBER),
+		Type:        iam.ORGANIZATION,
+	}))
+	s.tokens[*apiToken.Token] = hosted.organization.Id
+
+	hybrid := s.organizations[fixtures.HybridOrganizationId]
+	hybrid.createUser("user@astronomer.io", "Test User", iam.ACTIVE, iam.ORGANIZATIONOWNER)
+	hybridCluster := lo.Must(hybrid.createCluster(hybridClusterRequest("Acceptance Test Hybrid Cluster"), 0))
+	hybridCluster.settle()
+	dryRunCluster := lo.Must(hybrid.createCluster(hybridClusterRequest("Acceptance Test Dry Run Cluster"), 0))
+	dryRunCluster.settle()
+
+	scim := s.organizations[fixtures.HostedScimOrganizationId]
+	scimUser := scim.createUser("user@astronomer.io", "Test User", iam.ACTIVE, iam.ORGANIZATIONOWNER)
+	lo.Must(scim.createTeam(iam.CreateTeamRequest{
+		MemberIds: &[]string{scimUser.Id},
+		Name:      "Identity Provider Team",
+	}, true))
+
+	fixtures.HostedWorkspaceId = workspace.Id
+	fixtures.HostedDeploymentId = dedicatedDeployment.deployment.Id
+	fixtures.HostedStandardDeploymentId = standardDeployment.deployment.Id
+	fixtures.HostedTeamId = hostedTeam.team.Id
+	fixtures.HostedUserId = user.Id
+	fixtures.HostedDummyUserId = dummyUser.Id
+	fixtures.HostedApiTokenId = apiToken.Id
+	fixtures.HybridClusterId = hybridCluster.cluster.Id
+	fixtures.HybridNodePoolId = (*hybridCluster.cluster.NodePools)[0].Id
+	fixtures.HybridDryRunClusterId = dryRunCluster.cluster.Id
+	return fixtures
+}
+
+func hostedDeploymentRequest(name, workspaceId string, deploymentType platform.DeploymentType, configure func(*deploymentRequest)) deploymentRequest {
+	req := deploymentRequest{
+		AstroRuntimeVersion:  runtimeReleases[0].Version,
+		DefaultTaskPodCpu:    lo.ToPtr("0.25"),
+		DefaultTaskPodMemory: lo.ToPtr("0.5Gi"),
+		Description:          lo.ToPtr("Deployment used by the acceptance tests"),
+		Executor:             string(platform.DeploymentExecutorCELERY),
+		IsDagDeployEnabled:   true,
+		Name:                 name,
+		ResourceQuotaCpu:     lo.ToPtr("10"),
+		ResourceQuotaMemory:  lo.ToPtr("20Gi"),
+		SchedulerSize:        lo.ToPtr(string(platform.SchedulerMachineNameSMALL)),
+		Type:                 string(deploymentType),
+		WorkerQueues: &[]workerQueueRequest{{
+			AstroMachine:      lo.ToPtr(string(platform.WorkerMachineNameA5)),
+			IsDefault:         true,
+			MaxWorkerCount:    10,
+			Name:              "default",
+			WorkerConcurrency: 5,
+		}},
+		WorkspaceId: workspaceId,
+	}
+	configure(&req)
+	return req
+}
+
+func hybridClusterRequest(name string) clusterRequest {
+	return clusterRequest{
+		CloudProvider:  string(platform.ClusterCloudProviderAWS),
+		Name:           name,
+		Region:         "us-east-1",
+		Type:           string(platform.ClusterTypeHYBRID),
+		VpcSubnetRange: "172.20.0.0/20",
+	}
+}
@@ -0,0 +1,252 @@
+// Package fakeapi implements an in-memory fake of the Astro platform and IAM APIs used by the provider,
+// so acceptance tests can run hermetically without an Astronomer organization or credentials.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/lucsky/cuid"
+)
+
+const (
+	platformPrefix = "/platform/v1beta1"
+	iamPrefix      = "/iam/v1beta1"
+)
+
+// Options configure the behaviour of the fake server
+type Options struct {
+	// ClusterPendingPolls is the number of reads of a cluster that return CREATING or UPDATING before the cluster is CREATED
+	ClusterPendingPolls int
+	// DeploymentPendingPolls is the number of reads of a deployment that return CREATING before the deployment is HEALTHY
+	DeploymentPendingPolls int
+}
+
+// Server is an in-memory fake of the Astro API served over HTTP
+type Server struct {
+	options Options
+	server  *httptest.Server
+	routes  []route
+
+	mu            sync.Mutex
+	organizations map[string]*organization
+	// tokens maps a bearer token to the ID of the organization it belongs to
+	tokens map[string]string
+}
+
+// NewServer starts a fake Astro API server, the server must be closed by the caller
+func NewServer(options Options) *Server {
+	s := &Server{
+		options:       options,
+		organizations: map[string]*organization{},
+		tokens:        map[string]string{},
+	}
+	s.registerPlatformRoutes()
+	s.registerIamRoutes()
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL is the host of the fake server to use as the provider host
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the fake server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddOrganization creates an empty organization that can be accessed with the given token and returns its ID.
+// If the token is a JWT with an apiTokenId claim, such as the tokens of FakeApiToken, the organization has an
+// ORGANIZATION_OWNER API token with this ID.
+func (s *Server) AddOrganization(name, token string, product platform.OrganizationProduct, isScimEnabled bool) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	claims, _ := clients.ParseJwtClaims(token)
+	org := newOrganization(name, product, isScimEnabled, claims.ApiTokenId, token)
+	s.organizations[org.organization.Id] = org
+	s.tokens[token] = org.organization.Id
+	return org.organization.Id
+}
+
+// route is a handler for a method and a path pattern, where path segments in braces are parameters
+type route struct {
+	method   string
+	segments []string
+	handler  func(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string)
+}
+
+func (s *Server) handle(method, pattern string, handler func(w http.ResponseWriter, r *http.Request, org *organization, params map[string]string)) {
+	s.routes = append(s.routes, route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  handler,
+	})
+}
+
+func (r route) match(segments []string) (map[string]string, bool) {
+	if len(r.segments) != len(segments) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[strings.Trim(segment, "{}")] = segments[i]
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	methodNotAllowed := false
+	for _, rt := range s.routes {
+		params, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		if rt.method != r.Method {
+			methodNotAllowed = true
+			continue
+		}
+		s.serveRoute(w, r, rt, params)
+		return
+	}
+	if methodNotAllowed {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %v is not allowed for %v", r.Method, r.URL.Path))
+		return
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("route %v %v not found", r.Method, r.URL.Path))
+}
+
+// serveRoute authenticates the request and calls the route handler with the organization of the request
+func (s *Server) serveRoute(w http.ResponseWriter, r *http.Request, rt route, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token := strings.TrimPrefix(r.Header.Get("authorization"), "Bearer ")
+	orgId, ok := s.tokens[token]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid or missing API token")
+		return
+	}
+	if orgId != params["organizationId"] {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("API token does not have access to organization %v", params["organizationId"]))
+		return
+	}
+	rt.handler(w, r, s.organizations[orgId], params)
+}
+
+// apiError is the error body returned by the Astro API
+type apiError struct {
+	Message    string `json:"message"`
+	RequestId  string `json:"requestId"`
+	StatusCode int    `json:"statusCode"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, apiError{
+		Message:    message,
+		RequestId:  cuid.New(),
+		StatusCode: status,
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("%v %v not found", kind, id))
+}
+
+// decodeBody decodes the JSON request body and writes a bad request error if it is invalid
+func decodeBody(w http.ResponseWriter, r *http.Request, body any) bool {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+// page returns the offset and limit of a list request
+func page(r *http.Request) (int, int) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	return offset, limit
+}
+
+// paginate returns the page of items requested and the total count of items
+func paginate[T any](r *http.Request, items []T) ([]T, int, int, int) {
+	offset, limit := page(r)
+	total := len(items)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return append([]T{}, items[offset:end]...), offset, limit, total
+}
+
+// queryFilter returns a function reporting whether a value matches the values of a query parameter,
+// every value matches if the query parameter is not set
+func queryFilter(r *http.Request, name string) func(string) bool {
+	values := r.URL.Query()[name]
+	return func(value string) bool {
+		if len(values) == 0 {
+			return true
+		}
+		for _, v := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// requestError is an error that is returned to the client with a status code
+type requestError struct {
+	status  int
+	message string
+}
+
+func (e requestError) Error() string {
+	return e.message
+}
+
+func badRequest(format string, args ...any) error {
+	return requestError{status: http.StatusBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+func writeRequestError(w http.ResponseWriter, err error) {
+	if reqErr, ok := err.(requestError); ok {
+		writeError(w, reqErr.status, reqErr.message)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
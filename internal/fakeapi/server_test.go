@@ -0,0 +1,394 @@
+package fakeapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/fakeapi"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClients(t *testing.T, server *fakeapi.Server, token string) (*platform.ClientWithResponses, *iam.ClientWithResponses) {
+	platformClient, err := platform.NewPlatformClient(server.URL(), clients.StaticToken(token), "test")
+	assert.NoError(t, err)
+	iamClient, err := iam.NewIamClient(server.URL(), clients.StaticToken(token), "test")
+	assert.NoError(t, err)
+	return platformClient, iamClient
+}
+
+func hostedDeploymentRequest(t *testing.T, workspaceId string, isDevelopmentMode bool) platform.CreateDeploymentRequest {
+	var req platform.CreateDeploymentRequest
+	err := req.FromCreateStandardDeploymentRequest(platform.CreateStandardDeploymentRequest{
+		AstroRuntimeVersion:  "11.5.0",
+		CloudProvider:        lo.ToPtr(platform.CreateStandardDeploymentRequestCloudProviderAWS),
+		DefaultTaskPodCpu:    "0.25",
+		DefaultTaskPodMemory: "0.5Gi",
+		EnvironmentVariables: &[]platform.DeploymentEnvironmentVariableRequest{
+			{Key: "KEY", Value: lo.ToPtr("value")},
+			{Key: "SECRET", Value: lo.ToPtr("secret"), IsSecret: true},
+		},
+		Executor:            platform.CreateStandardDeploymentRequestExecutorCELERY,
+		IsDevelopmentMode:   lo.ToPtr(isDevelopmentMode),
+		Name:                "deployment",
+		Region:              lo.ToPtr("us-east-1"),
+		ResourceQuotaCpu:    "10",
+		ResourceQuotaMemory: "20Gi",
+		SchedulerSize:       platform.CreateStandardDeploymentRequestSchedulerSizeSMALL,
+		Type:                platform.CreateStandardDeploymentRequestTypeSTANDARD,
+		WorkerQueues: &[]platform.WorkerQueueRequest{{
+			AstroMachine:      platform.WorkerQueueRequestAstroMachineA5,
+			IsDefault:         true,
+			MaxWorkerCount:    10,
+			Name:              "default",
+			WorkerConcurrency: 5,
+		}},
+		WorkspaceId: workspaceId,
+	})
+	assert.NoError(t, err)
+	return req
+}
+
+func TestUnit_FakeApi(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("authenticates requests", func(t *testing.T) {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		defer server.Close()
+		fixtures := server.Seed()
+
+		platformClient, _ := newClients(t, server, "invalid-token")
+		resp, err := platformClient.GetOrganizationWithResponse(ctx, fixtures.HostedOrganizationId, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode())
+
+		platformClient, _ = newClients(t, server, fakeapi.HostedOrganizationToken)
+		resp, err = platformClient.GetOrganizationWithResponse(ctx, fixtures.HybridOrganizationId, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode())
+
+		resp, err = platformClient.GetOrganizationWithResponse(ctx, fixtures.HostedOrganizationId, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
+		assert.Equal(t, fixtures.HostedOrganizationId, resp.JSON200.Id)
+	})
+
+	t.Run("seeds the fixtures of the acceptance tests", func(t *testing.T) {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		defer server.Close()
+		fixtures := server.Seed()
+
+		platformClient, iamClient := newClients(t, server, fakeapi.HostedOrganizationToken)
+		deployment, err := platformClient.GetDeploymentWithResponse(ctx, fixtures.HostedOrganizationId, fixtures.HostedDeploymentId)
+		assert.NoError(t, err)
+		assert.Equal(t, fixtures.HostedWorkspaceId, deployment.JSON200.WorkspaceId)
+		assert.Equal(t, platform.DeploymentTypeDEDICATED, *deployment.JSON200.Type)
+		team, err := iamClient.GetTeamWithResponse(ctx, fixtures.HostedOrganizationId, fixtures.HostedTeamId)
+		assert.NoError(t, err)
+		assert.False(t, team.JSON200.IsIdpManaged)
+		apiToken, err := iamClient.GetApiTokenWithResponse(ctx, fixtures.HostedOrganizationId, fixtures.HostedApiTokenId)
+		assert.NoError(t, err)
+		assert.Nil(t, apiToken.JSON200.Token)
+
+		platformClient, _ = newClients(t, server, fakeapi.HybridOrganizationToken)
+		cluster, err := platformClient.GetClusterWithResponse(ctx, fixtures.HybridOrganizationId, fixtures.HybridClusterId)
+		assert.NoError(t, err)
+		assert.Equal(t, platform.ClusterStatusCREATED, cluster.JSON200.Status)
+		assert.Equal(t, fixtures.HybridNodePoolId, (*cluster.JSON200.NodePools)[0].Id)
+
+		platformClient, _ = newClients(t, server, fakeapi.HostedScimOrganizationToken)
+		organization, err := platformClient.GetOrganizationWithResponse(ctx, fixtures.HostedScimOrganizationId, nil)
+		assert.NoError(t, err)
+		assert.True(t, organization.JSON200.IsScimEnabled)
+	})
+
+	t.Run("creates, lists, updates and deletes workspaces", func(t *testing.T) {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		defer server.Close()
+		orgId := server.AddOrganization("organization", "token", platform.OrganizationProductHOSTED, false)
+		platformClient, _ := newClients(t, server, "token")
+
+		var workspaceIds []string
+		for _, name := range []string{"first", "second", "third"} {
+			resp, err := platformClient.CreateWorkspaceWithResponse(ctx, orgId, platform.CreateWorkspaceRequest{Name: name})
+			assert.NoError(t, err)
+			workspaceIds = append(workspaceIds, resp.JSON200.Id)
+		}
+
+		list, err := platformClient.ListWorkspacesWithResponse(ctx, orgId, &platform.ListWorkspacesParams{Offset: lo.ToPtr(1), Limit: lo.ToPtr(1)})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, list.JSON200.TotalCount)
+		assert.Equal(t, []string{"second"}, lo.Map(list.JSON200.Workspaces, func(w platform.Workspace, _ int) string { return w.Name }))
+
+		list, err = platformClient.ListWorkspacesWithResponse(ctx, orgId, &platform.ListWorkspacesParams{WorkspaceIds: &[]string{workspaceIds[0], workspaceIds[2]}})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, list.JSON200.TotalCount)
+
+		updated, err := platformClient.UpdateWorkspaceWithResponse(ctx, orgId, workspaceIds[0], platform.UpdateWorkspaceRequest{Name: "renamed", Description: "description", CicdEnforcedDefault: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "renamed", updated.JSON200.Name)
+		assert.True(t, updated.JSON200.CicdEnforcedDefault)
+
+		deleted, err := platformClient.DeleteWorkspaceWithResponse(ctx, orgId, workspaceIds[0])
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, deleted.StatusCode())
+		workspace, err := platformClient.GetWorkspaceWithResponse(ctx, orgId, workspaceIds[0])
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, workspace.StatusCode())
+	})
+
+	t.Run("moves clusters from CREATING to CREATED", func(t *testing.T) {
+		server := fakeapi.NewServer(fakeapi.Options{ClusterPendingPolls: 1})
+		defer server.Close()
+		orgId := server.AddOrganization("organization", "token", platform.OrganizationProductHOSTED, false)
+		platformClient, _ := newClients(t, server, "token")
+
+		var req platform.CreateClusterRequest
+		assert.NoError(t, req.FromCreateAwsClusterRequest(platform.CreateAwsClusterRequest{
+			CloudProvider:  platform.CreateAwsClusterRequestCloudProviderAWS,
+			Name:           "cluster",
+			Region:         "us-east-1",
+			Type:           platform.CreateAwsClusterRequestTypeDEDICATED,
+			VpcSubnetRange: "172.20.0.0/20",
+		}))
+		created, err := platformClient.CreateClusterWithResponse(ctx, orgId, req)
+		assert.NoError(t, err)
+		assert.Equal(t, platform.ClusterStatusCREATING, created.JSON200.Status)
+		assert.Len(t, *created.JSON200.NodePools, 1)
+
+		cluster, err := platformClient.GetClusterWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, platform.ClusterStatusCREATING, cluster.JSON200.Status)
+		cluster, err = platformClient.GetClusterWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, platform.ClusterStatusCREATED, cluster.JSON200.Status)
+
+		var update platform.UpdateClusterRequest
+		assert.NoError(t, update.FromUpdateDedicatedClusterRequest(platform.UpdateDedicatedClusterRequest{
+			ClusterType: lo.ToPtr(platform.UpdateDedicatedClusterRequestClusterTypeDEDICATED),
+			Name:        "renamed",
+			K8sTags:     []platform.ClusterK8sTag{},
+		}))
+		updated, err := platformClient.UpdateClusterWithResponse(ctx, orgId, created.JSON200.Id, update)
+		assert.NoError(t, err)
+		assert.Equal(t, platform.ClusterStatusUPDATING, updated.JSON200.Status)
+		assert.Equal(t, "renamed", updated.JSON200.Name)
+
+		deleted, err := platformClient.DeleteClusterWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, deleted.StatusCode())
+		cluster, err = platformClient.GetClusterWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, cluster.StatusCode())
+	})
+
+	t.Run("creates, updates and deletes deployments", func(t *testing.T) {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		defer server.Close()
+		orgId := server.AddOrganization("organization", "token", platform.OrganizationProductHOSTED, false)
+		platformClient, _ := newClients(t, server, "token")
+		workspace, err := platformClient.CreateWorkspaceWithResponse(ctx, orgId, platform.CreateWorkspaceRequest{Name: "workspace"})
+		assert.NoError(t, err)
+
+		created, err := platformClient.CreateDeploymentWithResponse(ctx, orgId, hostedDeploymentRequest(t, workspace.JSON200.Id, false))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, created.StatusCode(), string(created.Body))
+		assert.Equal(t, platform.DeploymentStatusCREATING, created.JSON200.Status)
+		assert.Equal(t, "2.9.2", created.JSON200.AirflowVersion)
+		secret, _ := lo.Find(*created.JSON200.EnvironmentVariables, func(envVar platform.DeploymentEnvironmentVariable) bool { return envVar.IsSecret })
+		assert.Nil(t, secret.Value)
+		workerQueueId := (*created.JSON200.WorkerQueues)[0].Id
+
+		deployment, err := platformClient.GetDeploymentWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, platform.DeploymentStatusHEALTHY, deployment.JSON200.Status)
+
+		var update platform.UpdateDeploymentRequest
+		assert.NoError(t, update.FromUpdateStandardDeploymentRequest(platform.UpdateStandardDeploymentRequest{
+			DefaultTaskPodCpu:    "0.25",
+			DefaultTaskPodMemory: "0.5Gi",
+			EnvironmentVariables: []platform.DeploymentEnvironmentVariableRequest{},
+			Executor:             platform.UpdateStandardDeploymentRequestExecutorCELERY,
+			Name:                 "renamed",
+			ResourceQuotaCpu:     "10",
+			ResourceQuotaMemory:  "20Gi",
+			SchedulerSize:        platform.UpdateStandardDeploymentRequestSchedulerSizeMEDIUM,
+			Type:                 platform.UpdateStandardDeploymentRequestTypeSTANDARD,
+			WorkerQueues: &[]platform.WorkerQueueRequest{{
+				AstroMachine:      platform.WorkerQueueRequestAstroMachineA10,
+				IsDefault:         true,
+				MaxWorkerCount:    10,
+				Name:              "default",
+				WorkerConcurrency: 5,
+			}},
+			WorkspaceId: workspace.JSON200.Id,
+		}))
+		updated, err := platformClient.UpdateDeploymentWithResponse(ctx, orgId, created.JSON200.Id, update)
+		assert.NoError(t, err)
+		assert.Equal(t, "renamed", updated.JSON200.Name)
+		assert.Equal(t, "2", updated.JSON200.SchedulerCpu)
+		assert.Equal(t, workerQueueId, (*updated.JSON200.WorkerQueues)[0].Id)
+		assert.Equal(t, "2", (*updated.JSON200.WorkerQueues)[0].PodCpu)
+
+		list, err := platformClient.ListDeploymentsWithResponse(ctx, orgId, &platform.ListDeploymentsParams{WorkspaceIds: &[]string{workspace.JSON200.Id}})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, list.JSON200.TotalCount)
+
+		workspaceDeleted, err := platformClient.DeleteWorkspaceWithResponse(ctx, orgId, workspace.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, workspaceDeleted.StatusCode())
+
+		deleted, err := platformClient.DeleteDeploymentWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, deleted.StatusCode())
+		deployment, err = platformClient.GetDeploymentWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, deployment.StatusCode())
+	})
+
+	t.Run("overrides the hibernation of development deployments", func(t *testing.T) {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		defer server.Close()
+		orgId := server.AddOrganization("organization", "token", platform.OrganizationProductHOSTED, false)
+		platformClient, _ := newClients(t, server, "token")
+		workspace, err := platformClient.CreateWorkspaceWithResponse(ctx, orgId, platform.CreateWorkspaceRequest{Name: "workspace"})
+		assert.NoError(t, err)
+		created, err := platformClient.CreateDeploymentWithResponse(ctx, orgId, hostedDeploymentRequest(t, workspace.JSON200.Id, true))
+		assert.NoError(t, err)
+
+		override, err := platformClient.UpdateDeploymentHibernationOverrideWithResponse(ctx, orgId, created.JSON200.Id, platform.OverrideDeploymentHibernationBody{
+			IsHibernating: true,
+			OverrideUntil: lo.ToPtr(time.Now().Add(time.Hour)),
+		})
+		assert.NoError(t, err)
+		assert.True(t, *override.JSON200.IsActive)
+		deployment, err := platformClient.GetDeploymentWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, platform.DeploymentStatusHIBERNATING, deployment.JSON200.Status)
+		assert.True(t, deployment.JSON200.ScalingStatus.HibernationStatus.IsHibernating)
+
+		deleted, err := platformClient.DeleteDeploymentHibernationOverrideWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, deleted.StatusCode())
+		deployment, err = platformClient.GetDeploymentWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, platform.DeploymentStatusHEALTHY, deployment.JSON200.Status)
+	})
+
+	t.Run("manages teams, members and roles", func(t *testing.T) {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		defer server.Close()
+		fixtures := server.Seed()
+		orgId := fixtures.HostedOrganizationId
+		_, iamClient := newClients(t, server, fakeapi.HostedOrganizationToken)
+
+		created, err := iamClient.CreateTeamWithResponse(ctx, orgId, iam.CreateTeamRequest{Name: "team", MemberIds: &[]string{fixtures.HostedUserId}})
+		assert.NoError(t, err)
+		assert.Equal(t, iam.TeamOrganizationRoleORGANIZATIONMEMBER, created.JSON200.OrganizationRole)
+
+		added, err := iamClient.AddTeamMembersWithResponse(ctx, orgId, created.JSON200.Id, iam.AddTeamMembersRequest{MemberIds: []string{fixtures.HostedDummyUserId}})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, added.StatusCode())
+		removed, err := iamClient.RemoveTeamMemberWithResponse(ctx, orgId, created.JSON200.Id, fixtures.HostedUserId)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, removed.StatusCode())
+		members, err := iamClient.ListTeamMembersWithResponse(ctx, orgId, created.JSON200.Id, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{fixtures.HostedDummyUserId}, lo.Map(members.JSON200.TeamMembers, func(m iam.TeamMember, _ int) string { return m.UserId }))
+
+		roles, err := iamClient.UpdateTeamRolesWithResponse(ctx, orgId, created.JSON200.Id, iam.UpdateTeamRolesRequest{
+			OrganizationRole: iam.UpdateTeamRolesRequestOrganizationRoleORGANIZATIONBILLINGADMIN,
+			WorkspaceRoles:   &[]iam.WorkspaceRole{{WorkspaceId: fixtures.HostedWorkspaceId, Role: iam.WORKSPACEOWNER}},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, *roles.JSON200.WorkspaceRoles, 1)
+		team, err := iamClient.GetTeamWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, iam.TeamOrganizationRoleORGANIZATIONBILLINGADMIN, team.JSON200.OrganizationRole)
+
+		roles, err = iamClient.UpdateTeamRolesWithResponse(ctx, orgId, created.JSON200.Id, iam.UpdateTeamRolesRequest{
+			OrganizationRole: iam.UpdateTeamRolesRequestOrganizationRoleORGANIZATIONMEMBER,
+			WorkspaceRoles:   &[]iam.WorkspaceRole{{WorkspaceId: "missing", Role: iam.WORKSPACEOWNER}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, roles.StatusCode())
+
+		_, iamClient = newClients(t, server, fakeapi.HostedScimOrganizationToken)
+		scimTeam, err := iamClient.CreateTeamWithResponse(ctx, fixtures.HostedScimOrganizationId, iam.CreateTeamRequest{Name: "team"})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, scimTeam.StatusCode())
+	})
+
+	t.Run("creates API tokens that authenticate requests", func(t *testing.T) {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		defer server.Close()
+		fixtures := server.Seed()
+		orgId := fixtures.HostedOrganizationId
+		_, iamClient := newClients(t, server, fakeapi.HostedOrganizationToken)
+
+		created, err := iamClient.CreateApiTokenWithResponse(ctx, orgId, iam.CreateApiTokenRequest{
+			Name:     "token",
+			Role:     "WORKSPACE_MEMBER",
+			Type:     iam.WORKSPACE,
+			EntityId: lo.ToPtr(fixtures.HostedWorkspaceId),
+		})
+		assert.NoError(t, err)
+		token := *created.JSON200.Token
+
+		tokenPlatformClient, _ := newClients(t, server, token)
+		workspace, err := tokenPlatformClient.GetWorkspaceWithResponse(ctx, orgId, fixtures.HostedWorkspaceId)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, workspace.StatusCode())
+
+		list, err := iamClient.ListApiTokensWithResponse(ctx, orgId, &iam.ListApiTokensParams{WorkspaceId: lo.ToPtr(fixtures.HostedWorkspaceId)})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{created.JSON200.Id}, lo.Map(list.JSON200.Tokens, func(t iam.ApiToken, _ int) string { return t.Id }))
+
+		rotated, err := iamClient.RotateApiTokenWithResponse(ctx, orgId, created.JSON200.Id)
+		assert.NoError(t, err)
+		assert.NotEqual(t, token, *rotated.JSON200.Token)
+		workspace, err = tokenPlatformClient.GetWorkspaceWithResponse(ctx, orgId, fixtures.HostedWorkspaceId)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, workspace.StatusCode())
+	})
+
+	t.Run("invites users and updates their roles", func(t *testing.T) {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		defer server.Close()
+		fixtures := server.Seed()
+		orgId := fixtures.HostedOrganizationId
+		_, iamClient := newClients(t, server, fakeapi.HostedOrganizationToken)
+
+		invite, err := iamClient.CreateUserInviteWithResponse(ctx, orgId, iam.CreateUserInviteRequest{
+			InviteeEmail: "invitee@astronomer.io",
+			Role:         iam.CreateUserInviteRequestRoleORGANIZATIONMEMBER,
+		})
+		assert.NoError(t, err)
+		user, err := iamClient.GetUserWithResponse(ctx, orgId, *invite.JSON200.UserId)
+		assert.NoError(t, err)
+		assert.Equal(t, iam.PENDING, user.JSON200.Status)
+
+		deleted, err := iamClient.DeleteUserInviteWithResponse(ctx, orgId, invite.JSON200.InviteId)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, deleted.StatusCode())
+		user, err = iamClient.GetUserWithResponse(ctx, orgId, *invite.JSON200.UserId)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, user.StatusCode())
+
+		roles, err := iamClient.UpdateUserRolesWithResponse(ctx, orgId, fixtures.HostedDummyUserId, iam.UpdateUserRolesRequest{
+			OrganizationRole: lo.ToPtr(iam.UpdateUserRolesRequestOrganizationRoleORGANIZATIONMEMBER),
+			WorkspaceRoles:   &[]iam.WorkspaceRole{{WorkspaceId: fixtures.HostedWorkspaceId, Role: iam.WORKSPACEMEMBER}},
+			DeploymentRoles:  &[]iam.DeploymentRole{{DeploymentId: fixtures.HostedDeploymentId, Role: "DEPLOYMENT_ADMIN"}},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, *roles.JSON200.DeploymentRoles, 1)
+		users, err := iamClient.ListUsersWithResponse(ctx, orgId, &iam.ListUsersParams{DeploymentId: lo.ToPtr(fixtures.HostedDeploymentId)})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{fixtures.HostedDummyUserId}, lo.Map(users.JSON200.Users, func(u iam.User, _ int) string { return u.Id }))
+	})
+}
@@ -0,0 +1,206 @@
+package fakeapi
+
+import (
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/lucsky/cuid"
+	"github.com/samber/lo"
+)
+
+// fakeApiTokenName is the name of the API token every object of the fake is created and updated by
+const fakeApiTokenName = "Fake API Token"
+
+// organization holds the objects of an organization, objects are kept in creation order
+type organization struct {
+	organization platform.Organization
+	workspaces   []*platform.Workspace
+	clusters     []*cluster
+	deployments  []*deployment
+	teams        []*team
+	users        []*iam.User
+	apiTokens    []*iam.ApiToken
+	invites      []*iam.Invite
+	// subjectId is the ID of the API token the organization is accessed with
+	subjectId string
+}
+
+// cluster is a cluster and the number of reads left before a pending cluster is CREATED
+type cluster struct {
+	cluster      platform.Cluster
+	pendingPolls int
+}
+
+// deployment is a deployment and the number of reads left before a creating deployment is HEALTHY
+type deployment struct {
+	deployment   platform.Deployment
+	pendingPolls int
+	// hibernationOverride is the hibernation override of the deployment, if any
+	hibernationOverride *platform.DeploymentHibernationOverride
+}
+
+// team is a team and the IDs of its members
+type team struct {
+	team      iam.Team
+	memberIds []string
+}
+
+// newOrganization creates an organization accessed with the API token apiTokenId, a token is created for the organization
+// if apiTokenId is set
+func newOrganization(name string, product platform.OrganizationProduct, isScimEnabled bool, apiTokenId, token string) *organization {
+	subjectId := apiTokenId
+	if subjectId == "" {
+		subjectId = cuid.New()
+	}
+	createdAt := now()
+	org := &organization{subjectId: subjectId}
+	org.organization = platform.Organization{
+		CreatedAt:     createdAt,
+		CreatedBy:     org.platformSubject(),
+		Id:            cuid.New(),
+		IsScimEnabled: isScimEnabled,
+		Name:          name,
+		Product:       lo.ToPtr(product),
+		Status:        lo.ToPtr(platform.ACTIVE),
+		SupportPlan:   platform.OrganizationSupportPlanBUSINESSCRITICAL,
+		UpdatedAt:     createdAt,
+		UpdatedBy:     org.platformSubject(),
+	}
+	if apiTokenId != "" {
+		org.apiTokens = append(org.apiTokens, &iam.ApiToken{
+			CreatedAt:   createdAt,
+			CreatedBy:   lo.ToPtr(org.iamSubject()),
+			Description: "API token the organization is accessed with",
+			Id:          apiTokenId,
+			Name:        fakeApiTokenName,
+			Roles: &[]iam.ApiTokenRole{{
+				EntityId:   org.organization.Id,
+				EntityType: iam.ApiTokenRoleEntityTypeORGANIZATION,
+				Role:       string(iam.ORGANIZATIONOWNER),
+			}},
+			ShortToken: token[len(token)-8:],
+			StartAt:    createdAt,
+			Token:      lo.ToPtr(token),
+			Type:       iam.ApiTokenTypeORGANIZATION,
+			UpdatedAt:  createdAt,
+			UpdatedBy:  lo.ToPtr(org.iamSubject()),
+		})
+	}
+	return org
+}
+
+// platformSubject is the profile of the API token the organization is accessed with
+func (o *organization) platformSubject() platform.BasicSubjectProfile {
+	return platform.BasicSubjectProfile{
+		ApiTokenName: lo.ToPtr(fakeApiTokenName),
+		Id:           o.subjectId,
+		SubjectType:  lo.ToPtr(platform.SERVICEKEY),
+	}
+}
+
+// iamSubject is the profile of the API token the organization is accessed with
+func (o *organization) iamSubject() iam.BasicSubjectProfile {
+	return iam.BasicSubjectProfile{
+		ApiTokenName: lo.ToPtr(fakeApiTokenName),
+		Id:           o.subjectId,
+		SubjectType:  lo.ToPtr(iam.SERVICEKEY),
+	}
+}
+
+func (o *organization) workspace(id string) *platform.Workspace {
+	workspace, _ := lo.Find(o.workspaces, func(w *platform.Workspace) bool { return w.Id == id })
+	return workspace
+}
+
+func (o *organization) cluster(id string) *cluster {
+	c, _ := lo.Find(o.clusters, func(c *cluster) bool { return c.cluster.Id == id })
+	return c
+}
+
+func (o *organization) deployment(id string) *deployment {
+	d, _ := lo.Find(o.deployments, func(d *deployment) bool { return d.deployment.Id == id })
+	return d
+}
+
+func (o *organization) team(id string) *team {
+	t, _ := lo.Find(o.teams, func(t *team) bool { return t.team.Id == id })
+	return t
+}
+
+func (o *organization) user(id string) *iam.User {
+	user, _ := lo.Find(o.users, func(u *iam.User) bool { return u.Id == id })
+	return user
+}
+
+func (o *organization) apiToken(id string) *iam.ApiToken {
+	apiToken, _ := lo.Find(o.apiTokens, func(t *iam.ApiToken) bool { return t.Id == id })
+	return apiToken
+}
+
+func (o *organization) invite(id string) *iam.Invite {
+	invite, _ := lo.Find(o.invites, func(i *iam.Invite) bool { return i.InviteId == id })
+	return invite
+}
+
+// deleteWorkspace deletes a workspace without deployments and every role granted on it
+func (o *organization) deleteWorkspace(id string) {
+	o.workspaces = lo.Reject(o.workspaces, func(w *platform.Workspace, _ int) bool { return w.Id == id })
+	for _, c := range o.clusters {
+		if c.cluster.WorkspaceIds != nil {
+			c.cluster.WorkspaceIds = lo.ToPtr(lo.Without(*c.cluster.WorkspaceIds, id))
+		}
+	}
+	isWorkspaceRole := func(role iam.WorkspaceRole, _ int) bool { return role.WorkspaceId == id }
+	for _, user := range o.users {
+		user.WorkspaceRoles = rejectRoles(user.WorkspaceRoles, isWorkspaceRole)
+	}
+	for _, t := range o.teams {
+		t.team.WorkspaceRoles = rejectRoles(t.team.WorkspaceRoles, isWorkspaceRole)
+	}
+	o.deleteApiTokenRoles(id)
+}
+
+// deleteDeployment deletes a deployment and every role granted on it
+func (o *organization) deleteDeployment(id string) {
+	o.deployments = lo.Reject(o.deployments, func(d *deployment, _ int) bool { return d.deployment.Id == id })
+	isDeploymentRole := func(role iam.DeploymentRole, _ int) bool { return role.DeploymentId == id }
+	for _, user := range o.users {
+		user.DeploymentRoles = rejectRoles(user.DeploymentRoles, isDeploymentRole)
+	}
+	for _, t := range o.teams {
+		t.team.DeploymentRoles = rejectRoles(t.team.DeploymentRoles, isDeploymentRole)
+	}
+	o.deleteApiTokenRoles(id)
+}
+
+// deleteApiTokenRoles deletes the roles of API tokens on an entity
+func (o *organization) deleteApiTokenRoles(entityId string) {
+	for _, apiToken := range o.apiTokens {
+		apiToken.Roles = rejectRoles(apiToken.Roles, func(role iam.ApiTokenRole, _ int) bool { return role.EntityId == entityId })
+	}
+}
+
+func rejectRoles[T any](roles *[]T, predicate func(T, int) bool) *[]T {
+	if roles == nil {
+		return nil
+	}
+	return lo.ToPtr(lo.Reject(*roles, predicate))
+}
+
+// teamMembers returns the users that are members of a team
+func (o *organization) teamMembers(t *team) []iam.TeamMember {
+	var members []iam.TeamMember
+	for _, memberId := range t.memberIds {
+		user := o.user(memberId)
+		if user == nil {
+			continue
+		}
+		members = append(members, iam.TeamMember{
+			AvatarUrl: lo.ToPtr(user.AvatarUrl),
+			CreatedAt: lo.ToPtr(user.CreatedAt),
+			FullName:  lo.ToPtr(user.FullName),
+			UserId:    user.Id,
+			Username:  user.Username,
+		})
+	}
+	return members
+}
@@ -83,6 +83,7 @@ func ValidateRoleMatchesEntityType(role string, scopeType string) bool {
 type ValidateWorkspaceDeploymentRolesInput struct {
 	PlatformClient  *platform.ClientWithResponses
 	OrganizationId  string
+	Cache           *clients.ResponseCache
 	DeploymentRoles []iam.DeploymentRole
 	WorkspaceRoles  []iam.WorkspaceRole
 }
@@ -101,8 +102,11 @@ func ValidateWorkspaceDeploymentRoles(ctx context.Context, input ValidateWorkspa
 	deploymentRoleIds = lo.Uniq(deploymentRoleIds)
 
 	// get list of deployments
-	listDeployments, err := input.PlatformClient.ListDeploymentsWithResponse(ctx, input.OrganizationId, &platform.ListDeploymentsParams{
-		DeploymentIds: &deploymentRoleIds,
+	cacheKey := clients.CacheKey(clients.DeploymentsCacheKeyPrefix, input.OrganizationId, strings.Join(deploymentRoleIds, ","))
+	listDeployments, err := clients.CachedResponse(ctx, input.Cache, cacheKey, clients.ListCacheTTL, func() (*platform.ListDeploymentsResponse, error) {
+		return input.PlatformClient.ListDeploymentsWithResponse(ctx, input.OrganizationId, &platform.ListDeploymentsParams{
+			DeploymentIds: &deploymentRoleIds,
+		})
 	})
 	if err != nil {
 		tflog.Error(ctx, "failed to mutate roles", map[string]interface{}{"error": err})
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/samber/lo"
+)
+
+// tokenSource returns the source of the API token of the provider, in order of precedence token, token_file, exec, the
+// ASTRO_API_TOKEN env var and astro_cli_config. astro_cli_config also sets organization_id and host if they are not set.
+// The token is read once so that invalid credentials are reported when the provider is configured.
+func tokenSource(ctx context.Context, data *models.AstroProviderModel) (clients.TokenSource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var cliConfig *models.ProviderAstroCliConfig
+	var cliConfigPath string
+	if !data.AstroCliConfig.IsNull() {
+		cliConfig = &models.ProviderAstroCliConfig{}
+		diags = data.AstroCliConfig.As(ctx, cliConfig, basetypes.ObjectAsOptions{})
+		if diags.HasError() {
+			return nil, diags
+		}
+		cliConfigPath = cliConfig.Path.ValueString()
+		if cliConfigPath == "" {
+			defaultPath, err := clients.DefaultAstroCliConfigPath()
+			if err != nil {
+				diags.AddAttributeError(path.Root("astro_cli_config"), "Failed to read Astro CLI config", err.Error())
+				return nil, diags
+			}
+			cliConfigPath = defaultPath
+		}
+		cliContext, err := clients.ReadAstroCliContext(cliConfigPath, cliConfig.Context.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("astro_cli_config"), "Failed to read Astro CLI config", err.Error())
+			return nil, diags
+		}
+		if data.OrganizationId.IsNull() {
+			data.OrganizationId = types.StringValue(cliContext.Organization)
+		}
+		if data.Host.IsNull() && cliContext.Domain != "" {
+			data.Host = types.StringValue(fmt.Sprintf("https://api.%v", cliContext.Domain))
+		}
+	}
+
+	var source clients.TokenSource
+	attribute := path.Root("token")
+	switch {
+	case !data.Token.IsNull():
+		source = clients.StaticToken(data.Token.ValueString())
+	case !data.TokenFile.IsNull():
+		source = clients.NewFileTokenSource(data.TokenFile.ValueString())
+		attribute = path.Root("token_file")
+	case !data.Exec.IsNull():
+		var exec models.ProviderExec
+		diags = data.Exec.As(ctx, &exec, basetypes.ObjectAsOptions{})
+		var args []string
+		diags.Append(exec.Args.ElementsAs(ctx, &args, false)...)
+		var env map[string]string
+		diags.Append(exec.Env.ElementsAs(ctx, &env, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		source = clients.NewExecTokenSource(exec.Command.ValueString(), args, env)
+		attribute = path.Root("exec")
+	case os.Getenv("ASTRO_API_TOKEN") != "":
+		source = clients.StaticToken(os.Getenv("ASTRO_API_TOKEN"))
+	case cliConfig != nil:
+		source = clients.NewAstroCliTokenSource(cliConfigPath, cliConfig.Context.ValueString())
+		attribute = path.Root("astro_cli_config")
+	default:
+		source = clients.StaticToken("")
+	}
+
+	token, err := source.Token(ctx)
+	if err != nil {
+		diags.AddAttributeError(attribute, "Failed to get Astro API Token", err.Error())
+		return nil, diags
+	}
+	if len(token) == 0 {
+		diags.AddAttributeError(
+			attribute,
+			"Missing Astro API Token",
+			"Astro API Token must be set in the configuration with 'token', 'token_file', 'exec' or 'astro_cli_config', or the 'ASTRO_API_TOKEN' environment variable",
+		)
+		return nil, diags
+	}
+	return source, diags
+}
+
+// callerIdentity returns the identity of the API token the provider is authenticated with, and checks that it belongs
+// to the organization of the provider. It returns nil for tokens that are not API tokens, such as the tokens of users.
+func callerIdentity(ctx context.Context, iamClient *iam.ClientWithResponses, organizationId, token string) (*models.CallerIdentity, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	claims, _ := clients.ParseJwtClaims(token)
+	if claims.ApiTokenId == "" {
+		tflog.Debug(ctx, "provider token is not an API token, skipping API token validation")
+		return nil, diags
+	}
+	if organizationIds := claims.OrganizationIds(); len(organizationIds) > 0 && !lo.Contains(organizationIds, organizationId) {
+		diags.AddAttributeError(
+			path.Root("organization_id"),
+			"Astro API Token does not belong to the organization",
+			fmt.Sprintf("The API token %v belongs to organization %v, not to organization %v", claims.ApiTokenId, strings.Join(organizationIds, ", "), organizationId),
+		)
+		return nil, diags
+	}
+
+	identity := &models.CallerIdentity{ApiTokenId: claims.ApiTokenId}
+	apiToken, err := iamClient.GetApiTokenWithResponse(ctx, organizationId, claims.ApiTokenId)
+	if err != nil {
+		tflog.Warn(ctx, "failed to get provider API token", map[string]interface{}{"error": err})
+		diags.AddWarning("Unable to validate Astro API Token", fmt.Sprintf("Unable to get API token %v, got error: %s", claims.ApiTokenId, err))
+		return identity, diags
+	}
+	switch apiToken.StatusCode() {
+	case http.StatusOK:
+		identity.ApiToken = apiToken.JSON200
+		tflog.Debug(ctx, "validated provider API token", map[string]interface{}{"id": identity.ApiToken.Id, "type": identity.ApiToken.Type})
+	case http.StatusUnauthorized:
+		_, diagnostic := clients.NormalizeAPIError(ctx, apiToken.HTTPResponse, apiToken.Body)
+		diags.AddAttributeError(path.Root("token"), "Invalid Astro API Token", diagnostic.Detail())
+	case http.StatusNotFound:
+		diags.AddAttributeError(
+			path.Root("organization_id"),
+			"Astro API Token does not belong to the organization",
+			fmt.Sprintf("The API token %v was not found in organization %v", claims.ApiTokenId, organizationId),
+		)
+	default:
+		// tokens with a narrow scope may not be allowed to read themselves
+		_, diagnostic := clients.NormalizeAPIError(ctx, apiToken.HTTPResponse, apiToken.Body)
+		diags.AddWarning("Unable to validate Astro API Token", diagnostic.Detail())
+	}
+	return identity, diags
+}
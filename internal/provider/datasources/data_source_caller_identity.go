@@ -0,0 +1,120 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &callerIdentityDataSource{}
+var _ datasource.DataSourceWithConfigure = &callerIdentityDataSource{}
+
+func NewCallerIdentityDataSource() datasource.DataSource {
+	return &callerIdentityDataSource{}
+}
+
+// callerIdentityDataSource defines the data source implementation.
+type callerIdentityDataSource struct {
+	IamClient      iam.ClientWithResponsesInterface
+	OrganizationId string
+	CallerIdentity *models.CallerIdentity
+}
+
+func (d *callerIdentityDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_caller_identity"
+}
+
+func (d *callerIdentityDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Caller identity data source, the API token the provider is authenticated with",
+		Attributes:          schemas.CallerIdentityDataSourceSchemaAttributes(),
+	}
+}
+
+func (d *callerIdentityDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClients, ok := req.ProviderData.(models.ApiClientsModel)
+	if !ok {
+		utils.DataSourceApiClientConfigureError(ctx, req, resp)
+		return
+	}
+
+	d.IamClient = apiClients.IamClient
+	d.OrganizationId = apiClients.OrganizationId
+	d.CallerIdentity = apiClients.CallerIdentity
+}
+
+func (d *callerIdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.CallerIdentityDataSource
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.CallerIdentity == nil {
+		tflog.Error(ctx, "failed to get caller identity", map[string]interface{}{"error": "provider token is not an API token"})
+		resp.Diagnostics.AddError(
+			"Caller identity unavailable",
+			"The provider is not authenticated with an Astro API token, the caller identity is only available for API tokens",
+		)
+		return
+	}
+
+	apiToken, err := d.IamClient.GetApiTokenWithResponse(ctx, d.OrganizationId, d.CallerIdentity.ApiTokenId)
+	if err != nil {
+		tflog.Error(ctx, "failed to get caller identity", map[string]interface{}{"error": err})
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read caller identity, got error: %s", err),
+		)
+		return
+	}
+	_, diagnostic := clients.NormalizeAPIError(ctx, apiToken.HTTPResponse, apiToken.Body)
+	if diagnostic != nil {
+		resp.Diagnostics.Append(diagnostic)
+		return
+	}
+	if apiToken.JSON200 == nil {
+		tflog.Error(ctx, "failed to get caller identity", map[string]interface{}{"error": "nil response"})
+		resp.Diagnostics.AddError("Client Error", "Unable to read caller identity, got nil response")
+		return
+	}
+
+	// Populate the model with the response data
+	diags := data.ReadFromResponse(ctx, d.OrganizationId, apiToken.JSON200)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,48 @@
+package datasources_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	astronomerprovider "github.com/astronomer/terraform-provider-astro/internal/provider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSource_CallerIdentity(t *testing.T) {
+	// the caller identity is only available for API tokens, such as the tokens of the fake API but not the redacted
+	// tokens of cassettes
+	if claims, _ := clients.ParseJwtClaims(os.Getenv("HOSTED_ORGANIZATION_API_TOKEN")); claims.ApiTokenId == "" {
+		t.Skip("HOSTED_ORGANIZATION_API_TOKEN is not an API token")
+	}
+	tfVarName := "test_data_caller_identity"
+	resourceVar := fmt.Sprintf("data.astro_caller_identity.%v", tfVarName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			astronomerprovider.TestAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: astronomerprovider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + callerIdentity(tfVarName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceVar, "id"),
+					resource.TestCheckResourceAttr(resourceVar, "organization_id", os.Getenv("HOSTED_ORGANIZATION_ID")),
+					resource.TestCheckResourceAttrSet(resourceVar, "name"),
+					resource.TestCheckResourceAttr(resourceVar, "type", "ORGANIZATION"),
+					resource.TestCheckResourceAttrWith(resourceVar, "roles.#", CheckAttributeLengthIsNotEmpty),
+					resource.TestCheckResourceAttr(resourceVar, "roles.0.entity_id", os.Getenv("HOSTED_ORGANIZATION_ID")),
+					resource.TestCheckResourceAttr(resourceVar, "roles.0.entity_type", "ORGANIZATION"),
+					resource.TestCheckResourceAttrSet(resourceVar, "roles.0.role"),
+				),
+			},
+		},
+	})
+}
+
+func callerIdentity(tfVarName string) string {
+	return fmt.Sprintf(`
+data astro_caller_identity "%v" {}`, tfVarName)
+}
@@ -26,6 +26,7 @@ func NewDeploymentOptionsDataSource() datasource.DataSource {
 type deploymentOptionsDataSource struct {
 	PlatformClient platform.ClientWithResponsesInterface
 	OrganizationId string
+	Cache          *clients.ResponseCache
 }
 
 func (d *deploymentOptionsDataSource) Metadata(
@@ -66,6 +67,7 @@ func (d *deploymentOptionsDataSource) Configure(
 
 	d.PlatformClient = apiClients.PlatformClient
 	d.OrganizationId = apiClients.OrganizationId
+	d.Cache = apiClients.Cache
 }
 
 func (d *deploymentOptionsDataSource) Read(
@@ -100,11 +102,14 @@ func (d *deploymentOptionsDataSource) Read(
 		params.CloudProvider = (*platform.GetDeploymentOptionsParamsCloudProvider)(&cloudProviderParam)
 	}
 
-	options, err := d.PlatformClient.GetDeploymentOptionsWithResponse(
-		ctx,
-		d.OrganizationId,
-		&params,
-	)
+	cacheKey := clients.CacheKey(clients.DeploymentOptionsCacheKeyPrefix, d.OrganizationId, deploymentTypeParam, executorParam, cloudProviderParam, deploymentIdParam)
+	options, err := clients.CachedResponse(ctx, d.Cache, cacheKey, clients.DeploymentOptionsCacheTTL, func() (*platform.GetDeploymentOptionsResponse, error) {
+		return d.PlatformClient.GetDeploymentOptionsWithResponse(
+			ctx,
+			d.OrganizationId,
+			&params,
+		)
+	})
 	if err != nil {
 		tflog.Error(ctx, "failed to get deployment options", map[string]interface{}{"error": err})
 		resp.Diagnostics.AddError(
@@ -0,0 +1,117 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &deploymentTemplateDataSource{}
+var _ datasource.DataSourceWithConfigure = &deploymentTemplateDataSource{}
+
+func NewDeploymentTemplateDataSource() datasource.DataSource {
+	return &deploymentTemplateDataSource{}
+}
+
+// deploymentTemplateDataSource defines the data source implementation.
+type deploymentTemplateDataSource struct {
+	PlatformClient platform.ClientWithResponsesInterface
+	OrganizationId string
+}
+
+func (d *deploymentTemplateDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_deployment_template"
+}
+
+func (d *deploymentTemplateDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Deployment template data source, the configuration of an existing deployment to copy into a new `astro_deployment` resource. The attributes have the types of the attributes of the resource with the same names, attributes set explicitly in the resource override the template.",
+		Attributes:          schemas.DeploymentTemplateDataSourceSchemaAttributes(),
+	}
+}
+
+func (d *deploymentTemplateDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClients, ok := req.ProviderData.(models.ApiClientsModel)
+	if !ok {
+		utils.DataSourceApiClientConfigureError(ctx, req, resp)
+		return
+	}
+
+	d.PlatformClient = apiClients.PlatformClient
+	d.OrganizationId = apiClients.OrganizationId
+}
+
+func (d *deploymentTemplateDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data models.DeploymentTemplateDataSource
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deployment, err := d.PlatformClient.GetDeploymentWithResponse(
+		ctx,
+		d.OrganizationId,
+		data.Id.ValueString(),
+	)
+	if err != nil {
+		tflog.Error(ctx, "failed to get deployment", map[string]interface{}{"error": err})
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read deployment, got error: %s", err),
+		)
+		return
+	}
+	_, diagnostic := clients.NormalizeAPIError(ctx, deployment.HTTPResponse, deployment.Body)
+	if diagnostic != nil {
+		resp.Diagnostics.Append(diagnostic)
+		return
+	}
+	if deployment.JSON200 == nil {
+		tflog.Error(ctx, "failed to get deployment", map[string]interface{}{"error": "nil response"})
+		resp.Diagnostics.AddError("Client Error", "Unable to read deployment, got nil response")
+		return
+	}
+
+	// Populate the model with the response data
+	diags := data.ReadFromResponse(ctx, deployment.JSON200)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
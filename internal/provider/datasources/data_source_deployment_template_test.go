@@ -0,0 +1,134 @@
+package datasources_test
+
+import (
+	"fmt"
+	"testing"
+
+	astronomerprovider "github.com/astronomer/terraform-provider-astro/internal/provider"
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceDeploymentTemplate(t *testing.T) {
+	deploymentName := utils.GenerateTestResourceName(10)
+	templateVar := "data.astro_deployment_template.test_template"
+	cloneVar := "astro_deployment.test_clone"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			astronomerprovider.TestAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: astronomerprovider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + deploymentTemplate(deploymentName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(templateVar, "id", "astro_deployment.test_source", "id"),
+					resource.TestCheckResourceAttr(templateVar, "type", "STANDARD"),
+					resource.TestCheckResourceAttr(templateVar, "cloud_provider", "AWS"),
+					resource.TestCheckResourceAttr(templateVar, "region", "us-east-1"),
+					resource.TestCheckNoResourceAttr(templateVar, "cluster_id"),
+					resource.TestCheckResourceAttr(templateVar, "executor", "CELERY"),
+					resource.TestCheckResourceAttr(templateVar, "contact_emails.0", "preview@astronomer.test"),
+					resource.TestCheckResourceAttr(templateVar, "environment_variables_map.key1", "value1"),
+					resource.TestCheckNoResourceAttr(templateVar, "environment_variables_map.secret1"),
+					resource.TestCheckResourceAttr(templateVar, "secret_environment_variable_keys.0", "secret1"),
+					resource.TestCheckResourceAttr(templateVar, "worker_queues.0.name", "default"),
+					resource.TestCheckResourceAttr(templateVar, "worker_queues.0.astro_machine", "A5"),
+					resource.TestCheckNoResourceAttr(templateVar, "worker_queues.0.pod_cpu"),
+					resource.TestCheckResourceAttr(templateVar, "scaling_spec.hibernation_spec.schedules.0.hibernate_at_cron", "0 19 * * *"),
+					resource.TestCheckNoResourceAttr(templateVar, "scaling_spec.hibernation_spec.override"),
+
+					// the clone has the configuration of the source, except for the attributes set explicitly
+					resource.TestCheckResourceAttr(cloneVar, "name", fmt.Sprintf("%v-clone", deploymentName)),
+					resource.TestCheckResourceAttr(cloneVar, "executor", "CELERY"),
+					resource.TestCheckResourceAttr(cloneVar, "scheduler_size", "SMALL"),
+					resource.TestCheckResourceAttr(cloneVar, "worker_queues.0.max_worker_count", "10"),
+					resource.TestCheckResourceAttr(cloneVar, "environment_variables_map.key1", "value1"),
+					resource.TestCheckResourceAttr(cloneVar, "environment_variables_map.key2", "clone"),
+					resource.TestCheckResourceAttr(cloneVar, "scaling_spec.hibernation_spec.schedules.0.wake_at_cron", "0 7 * * *"),
+				),
+			},
+		},
+	})
+}
+
+func deploymentTemplate(name string) string {
+	return fmt.Sprintf(`
+resource "astro_workspace" "test_workspace" {
+	name = "%v"
+	description = "%v"
+	cicd_enforced_default = true
+}
+
+resource "astro_deployment" "test_source" {
+	name = "%v-source"
+	description = "%v"
+	type = "STANDARD"
+	region = "us-east-1"
+	cloud_provider = "AWS"
+	contact_emails = ["preview@astronomer.test"]
+	default_task_pod_cpu = "0.25"
+	default_task_pod_memory = "0.5Gi"
+	executor = "CELERY"
+	is_cicd_enforced = true
+	is_dag_deploy_enabled = true
+	is_development_mode = true
+	is_high_availability = false
+	resource_quota_cpu = "10"
+	resource_quota_memory = "20Gi"
+	scheduler_size = "SMALL"
+	workspace_id = astro_workspace.test_workspace.id
+	environment_variables_map = { key1 = "value1" }
+	secret_environment_variables = { secret1 = "secret1" }
+	worker_queues = [{
+		name = "default"
+		is_default = true
+		astro_machine = "A5"
+		max_worker_count = 10
+		min_worker_count = 0
+		worker_concurrency = 1
+	}]
+	scaling_spec = {
+		hibernation_spec = {
+			schedules = [{
+				hibernate_at_cron = "0 19 * * *"
+				is_enabled = true
+				wake_at_cron = "0 7 * * *"
+			}]
+			override = {
+				is_hibernating = true
+			}
+		}
+	}
+}
+
+data "astro_deployment_template" "test_template" {
+	id = astro_deployment.test_source.id
+}
+
+resource "astro_deployment" "test_clone" {
+	name = "%v-clone"
+	description = data.astro_deployment_template.test_template.description
+	workspace_id = data.astro_deployment_template.test_template.workspace_id
+	type = data.astro_deployment_template.test_template.type
+	region = data.astro_deployment_template.test_template.region
+	cloud_provider = data.astro_deployment_template.test_template.cloud_provider
+	contact_emails = data.astro_deployment_template.test_template.contact_emails
+	default_task_pod_cpu = data.astro_deployment_template.test_template.default_task_pod_cpu
+	default_task_pod_memory = data.astro_deployment_template.test_template.default_task_pod_memory
+	executor = data.astro_deployment_template.test_template.executor
+	is_cicd_enforced = data.astro_deployment_template.test_template.is_cicd_enforced
+	is_dag_deploy_enabled = data.astro_deployment_template.test_template.is_dag_deploy_enabled
+	is_development_mode = data.astro_deployment_template.test_template.is_development_mode
+	is_high_availability = data.astro_deployment_template.test_template.is_high_availability
+	resource_quota_cpu = data.astro_deployment_template.test_template.resource_quota_cpu
+	resource_quota_memory = data.astro_deployment_template.test_template.resource_quota_memory
+	scheduler_size = data.astro_deployment_template.test_template.scheduler_size
+	worker_queues = data.astro_deployment_template.test_template.worker_queues
+	scaling_spec = data.astro_deployment_template.test_template.scaling_spec
+	environment_variables_map = merge(data.astro_deployment_template.test_template.environment_variables_map, { key2 = "clone" })
+	secret_environment_variables = { for key in data.astro_deployment_template.test_template.secret_environment_variable_keys : key => "clone-${key}" }
+}
+`, name, utils.TestResourceDescription, name, utils.TestResourceDescription, name)
+}
@@ -1,12 +1,58 @@
 package models
 
 import (
+	"fmt"
+	"sync"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
 	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 )
 
 type ApiClientsModel struct {
 	OrganizationId string
 	PlatformClient *platform.ClientWithResponses
 	IamClient      *iam.ClientWithResponses
+	// Cache caches the responses of read-only lookups for the lifetime of the provider
+	Cache *clients.ResponseCache
+	// CallerIdentity is the API token the provider is authenticated with, it is nil for other tokens such as the tokens
+	// of users
+	CallerIdentity *CallerIdentity
+	// ReadOnly is true when the provider must not change any object
+	ReadOnly bool
+}
+
+// apiTokenScopes ranks the types of API tokens from the narrowest to the widest scope
+var apiTokenScopes = map[iam.ApiTokenType]int{
+	iam.ApiTokenTypeDEPLOYMENT:   0,
+	iam.ApiTokenTypeWORKSPACE:    1,
+	iam.ApiTokenTypeORGANIZATION: 2,
+}
+
+// CallerIdentity describes the API token the provider is authenticated with
+type CallerIdentity struct {
+	ApiTokenId string
+	// ApiToken is nil if the API token is not allowed to read itself
+	ApiToken *iam.ApiToken
+	// warnedResourceTypes are the resource types whose scope warning was already reported
+	warnedResourceTypes sync.Map
+}
+
+// CheckScope warns once per resource type if the API token has a narrower scope than the scope required to manage
+// resources of resourceType
+func (c *CallerIdentity) CheckScope(resourceType string, requiredScope iam.ApiTokenType) diag.Diagnostics {
+	if c == nil || c.ApiToken == nil || apiTokenScopes[c.ApiToken.Type] >= apiTokenScopes[requiredScope] {
+		return nil
+	}
+	if _, warned := c.warnedResourceTypes.LoadOrStore(resourceType, true); warned {
+		return nil
+	}
+	return diag.Diagnostics{diag.NewWarningDiagnostic(
+		fmt.Sprintf("Astro API Token scope is narrower than %v", resourceType),
+		fmt.Sprintf(
+			"The provider is authenticated with the %v API token %v (%v), but managing %v resources requires an API token of type %v. Operations on these resources will likely fail with a permission error.",
+			c.ApiToken.Type, c.ApiToken.Name, c.ApiToken.Id, resourceType, requiredScope,
+		),
+	)}
 }
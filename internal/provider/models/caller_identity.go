@@ -0,0 +1,30 @@
+package models
+
+import (
+	"context"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// CallerIdentityDataSource describes the data source data model.
+type CallerIdentityDataSource struct {
+	Id             types.String `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+	Roles          types.Set    `tfsdk:"roles"`
+}
+
+func (data *CallerIdentityDataSource) ReadFromResponse(ctx context.Context, organizationId string, apiToken *iam.ApiToken) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data.Id = types.StringValue(apiToken.Id)
+	data.OrganizationId = types.StringValue(organizationId)
+	data.Name = types.StringValue(apiToken.Name)
+	data.Type = types.StringValue(string(apiToken.Type))
+	data.Roles, diags = utils.ObjectSet(ctx, apiToken.Roles, schemas.ApiTokenRoleAttributeTypes(), ApiTokenRoleTypesObject)
+	return diags
+}
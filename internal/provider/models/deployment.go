@@ -2,6 +2,8 @@ package models
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/samber/lo"
@@ -9,50 +11,55 @@ import (
 	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
 	"github.com/astronomer/terraform-provider-astro/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 type DeploymentResource struct {
 	// Common fields
-	Id                          types.String `tfsdk:"id"`
-	Name                        types.String `tfsdk:"name"`
-	Description                 types.String `tfsdk:"description"`
-	CreatedAt                   types.String `tfsdk:"created_at"`
-	UpdatedAt                   types.String `tfsdk:"updated_at"`
-	CreatedBy                   types.Object `tfsdk:"created_by"`
-	UpdatedBy                   types.Object `tfsdk:"updated_by"`
-	WorkspaceId                 types.String `tfsdk:"workspace_id"`
-	Type                        types.String `tfsdk:"type"`
-	Region                      types.String `tfsdk:"region"`
-	CloudProvider               types.String `tfsdk:"cloud_provider"`
-	OriginalAstroRuntimeVersion types.String `tfsdk:"original_astro_runtime_version"`
-	AstroRuntimeVersion         types.String `tfsdk:"astro_runtime_version"`
-	AirflowVersion              types.String `tfsdk:"airflow_version"`
-	Namespace                   types.String `tfsdk:"namespace"`
-	ContactEmails               types.Set    `tfsdk:"contact_emails"`
-	Executor                    types.String `tfsdk:"executor"`
-	SchedulerCpu                types.String `tfsdk:"scheduler_cpu"`
-	SchedulerMemory             types.String `tfsdk:"scheduler_memory"`
-	SchedulerAu                 types.Int64  `tfsdk:"scheduler_au"`
-	SchedulerReplicas           types.Int64  `tfsdk:"scheduler_replicas"`
-	ImageTag                    types.String `tfsdk:"image_tag"`
-	ImageRepository             types.String `tfsdk:"image_repository"`
-	ImageVersion                types.String `tfsdk:"image_version"`
-	EnvironmentVariables        types.Set    `tfsdk:"environment_variables"`
-	WebserverIngressHostname    types.String `tfsdk:"webserver_ingress_hostname"`
-	WebserverUrl                types.String `tfsdk:"webserver_url"`
-	WebserverAirflowApiUrl      types.String `tfsdk:"webserver_airflow_api_url"`
-	Status                      types.String `tfsdk:"status"`
-	StatusReason                types.String `tfsdk:"status_reason"`
-	DagTarballVersion           types.String `tfsdk:"dag_tarball_version"`
-	DesiredDagTarballVersion    types.String `tfsdk:"desired_dag_tarball_version"`
-	IsCicdEnforced              types.Bool   `tfsdk:"is_cicd_enforced"`
-	IsDagDeployEnabled          types.Bool   `tfsdk:"is_dag_deploy_enabled"`
-	WorkloadIdentity            types.String `tfsdk:"workload_identity"`
-	ExternalIps                 types.Set    `tfsdk:"external_ips"`
-	OidcIssuerUrl               types.String `tfsdk:"oidc_issuer_url"`
-	WorkerQueues                types.Set    `tfsdk:"worker_queues"`
+	Id                               types.String `tfsdk:"id"`
+	Name                             types.String `tfsdk:"name"`
+	Description                      types.String `tfsdk:"description"`
+	CreatedAt                        types.String `tfsdk:"created_at"`
+	UpdatedAt                        types.String `tfsdk:"updated_at"`
+	CreatedBy                        types.Object `tfsdk:"created_by"`
+	UpdatedBy                        types.Object `tfsdk:"updated_by"`
+	WorkspaceId                      types.String `tfsdk:"workspace_id"`
+	Type                             types.String `tfsdk:"type"`
+	Region                           types.String `tfsdk:"region"`
+	CloudProvider                    types.String `tfsdk:"cloud_provider"`
+	OriginalAstroRuntimeVersion      types.String `tfsdk:"original_astro_runtime_version"`
+	AstroRuntimeVersion              types.String `tfsdk:"astro_runtime_version"`
+	AirflowVersion                   types.String `tfsdk:"airflow_version"`
+	Namespace                        types.String `tfsdk:"namespace"`
+	ContactEmails                    types.Set    `tfsdk:"contact_emails"`
+	Executor                         types.String `tfsdk:"executor"`
+	SchedulerCpu                     types.String `tfsdk:"scheduler_cpu"`
+	SchedulerMemory                  types.String `tfsdk:"scheduler_memory"`
+	SchedulerAu                      types.Int64  `tfsdk:"scheduler_au"`
+	SchedulerReplicas                types.Int64  `tfsdk:"scheduler_replicas"`
+	ImageTag                         types.String `tfsdk:"image_tag"`
+	ImageRepository                  types.String `tfsdk:"image_repository"`
+	ImageVersion                     types.String `tfsdk:"image_version"`
+	EnvironmentVariables             types.Set    `tfsdk:"environment_variables"`
+	EnvironmentVariablesMap          types.Map    `tfsdk:"environment_variables_map"`
+	SecretEnvironmentVariables       types.Map    `tfsdk:"secret_environment_variables"`
+	SecretEnvironmentVariablesHashes types.Map    `tfsdk:"secret_environment_variables_hashes"`
+	WebserverIngressHostname         types.String `tfsdk:"webserver_ingress_hostname"`
+	WebserverUrl                     types.String `tfsdk:"webserver_url"`
+	WebserverAirflowApiUrl           types.String `tfsdk:"webserver_airflow_api_url"`
+	Status                           types.String `tfsdk:"status"`
+	StatusReason                     types.String `tfsdk:"status_reason"`
+	DagTarballVersion                types.String `tfsdk:"dag_tarball_version"`
+	DesiredDagTarballVersion         types.String `tfsdk:"desired_dag_tarball_version"`
+	IsCicdEnforced                   types.Bool   `tfsdk:"is_cicd_enforced"`
+	IsDagDeployEnabled               types.Bool   `tfsdk:"is_dag_deploy_enabled"`
+	WorkloadIdentity                 types.String `tfsdk:"workload_identity"`
+	ExternalIps                      types.Set    `tfsdk:"external_ips"`
+	OidcIssuerUrl                    types.String `tfsdk:"oidc_issuer_url"`
+	WorkerQueues                     types.Set    `tfsdk:"worker_queues"`
 
 	// Hybrid and dedicated specific fields
 	ClusterId types.String `tfsdk:"cluster_id"`
@@ -70,6 +77,9 @@ type DeploymentResource struct {
 	IsHighAvailability   types.Bool   `tfsdk:"is_high_availability"`
 	ScalingStatus        types.Object `tfsdk:"scaling_status"`
 	ScalingSpec          types.Object `tfsdk:"scaling_spec"`
+
+	// Provider only fields
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
 }
 
 type DeploymentDataSource struct {
@@ -188,7 +198,8 @@ func (data *DeploymentResource) ReadFromResponse(
 	// Since terraform wants to know the values of the secret values in the request at all times, and our API does not send back the secret values in the response
 	// We must use the request value and set it in the Terraform response to keep Terraform from emitting errors
 	// Since the value is marked as sensitive, Terraform will not output the actual value in the plan/apply output
-	envVars := *deployment.EnvironmentVariables
+	// The response is copied so that the secret values are not written to it
+	envVars := slices.Clone(lo.FromPtr(deployment.EnvironmentVariables))
 	if requestEnvVars != nil && deployment.EnvironmentVariables != nil {
 		requestEnvVarsMap := lo.SliceToMap(*requestEnvVars, func(envVar platform.DeploymentEnvironmentVariableRequest) (string, platform.DeploymentEnvironmentVariable) {
 			return envVar.Key, platform.DeploymentEnvironmentVariable{
@@ -206,10 +217,28 @@ func (data *DeploymentResource) ReadFromResponse(
 			}
 		}
 	}
-	data.EnvironmentVariables, diags = utils.ObjectSet(ctx, &envVars, schemas.DeploymentEnvironmentVariableAttributeTypes(), DeploymentEnvironmentVariableTypesObject)
-	if diags.HasError() {
-		return diags
+	switch {
+	case data.EnvironmentVariables.IsNull() && (!data.EnvironmentVariablesMap.IsNull() || !data.SecretEnvironmentVariables.IsNull() || !data.SecretEnvironmentVariablesHashes.IsNull()):
+		// The environment variables are configured by key, secrets are write-only and only their hashes are stored
+		data.EnvironmentVariablesMap, diags = environmentVariablesMap(envVars, data.EnvironmentVariablesMap)
+		if diags.HasError() {
+			return diags
+		}
+		data.SecretEnvironmentVariablesHashes, diags = secretEnvironmentVariablesHashes(envVars, data.SecretEnvironmentVariablesHashes)
+		if diags.HasError() {
+			return diags
+		}
+	case data.EnvironmentVariables.IsNull() && len(envVars) == 0:
+		// No environment variables are configured
+		data.SecretEnvironmentVariablesHashes = types.MapNull(types.StringType)
+	default:
+		data.EnvironmentVariables, diags = utils.ObjectSet(ctx, &envVars, schemas.DeploymentEnvironmentVariableAttributeTypes(), DeploymentEnvironmentVariableTypesObject)
+		if diags.HasError() {
+			return diags
+		}
+		data.SecretEnvironmentVariablesHashes = types.MapNull(types.StringType)
 	}
+	data.SecretEnvironmentVariables = types.MapNull(types.StringType)
 	data.WebserverIngressHostname = types.StringValue(deployment.WebServerIngressHostname)
 	data.WebserverUrl = types.StringValue(deployment.WebServerUrl)
 	data.WebserverAirflowApiUrl = types.StringValue(deployment.WebServerAirflowApiUrl)
@@ -244,11 +273,11 @@ func (data *DeploymentResource) ReadFromResponse(
 	data.SchedulerSize = types.StringPointerValue((*string)(deployment.SchedulerSize))
 	data.IsDevelopmentMode = types.BoolPointerValue(deployment.IsDevelopmentMode)
 	data.IsHighAvailability = types.BoolPointerValue(deployment.IsHighAvailability)
-	data.ScalingStatus, diags = ScalingStatusTypesObject(ctx, deployment.ScalingStatus)
+	data.ScalingStatus, diags = ScalingStatusTypesObject(ctx, deployment.ScalingStatus, deployment.ScalingSpec)
 	if diags.HasError() {
 		return diags
 	}
-	data.ScalingSpec, diags = ScalingSpecTypesObject(ctx, deployment.ScalingSpec)
+	data.ScalingSpec, diags = ScalingSpecResourceTypesObject(ctx, deployment.ScalingSpec, data.ScalingSpec)
 	if diags.HasError() {
 		return diags
 	}
@@ -341,7 +370,7 @@ func (data *DeploymentDataSource) ReadFromResponse(
 	data.SchedulerSize = types.StringPointerValue((*string)(deployment.SchedulerSize))
 	data.IsDevelopmentMode = types.BoolPointerValue(deployment.IsDevelopmentMode)
 	data.IsHighAvailability = types.BoolPointerValue(deployment.IsHighAvailability)
-	data.ScalingStatus, diags = ScalingStatusTypesObject(ctx, deployment.ScalingStatus)
+	data.ScalingStatus, diags = ScalingStatusTypesObject(ctx, deployment.ScalingStatus, deployment.ScalingSpec)
 	if diags.HasError() {
 		return diags
 	}
@@ -385,6 +414,62 @@ type WorkerQueueResource struct {
 	WorkerConcurrency types.Int64  `tfsdk:"worker_concurrency"`
 }
 
+// environmentVariablesMap returns the values of the environment variables that are not secret by key, it is null when
+// there are none and the prior value is null
+func environmentVariablesMap(
+	envVars []platform.DeploymentEnvironmentVariable,
+	priorValue types.Map,
+) (types.Map, diag.Diagnostics) {
+	values := make(map[string]attr.Value)
+	for _, envVar := range envVars {
+		if !envVar.IsSecret && envVar.Value != nil {
+			values[envVar.Key] = types.StringValue(*envVar.Value)
+		}
+	}
+	if len(values) == 0 && priorValue.IsNull() {
+		return types.MapNull(types.StringType), nil
+	}
+	return types.MapValue(types.StringType, values)
+}
+
+// secretEnvironmentVariablesHashes returns the hashes of the secret environment variables by key. The prior hash of a
+// secret is kept when its value is not known, as the API does not return secret values, or when it still matches its
+// value. The hashes are null when there are no secrets and the prior hashes are null.
+func secretEnvironmentVariablesHashes(
+	envVars []platform.DeploymentEnvironmentVariable,
+	priorHashes types.Map,
+) (types.Map, diag.Diagnostics) {
+	values := make(map[string]attr.Value)
+	for _, envVar := range envVars {
+		if !envVar.IsSecret {
+			continue
+		}
+		priorHash, _ := priorHashes.Elements()[envVar.Key].(types.String)
+		hasPriorHash := !priorHash.IsNull() && !priorHash.IsUnknown()
+		switch {
+		case envVar.Value == nil:
+			if hasPriorHash {
+				values[envVar.Key] = priorHash
+			}
+		case hasPriorHash && utils.SecretMatchesHash(*envVar.Value, priorHash.ValueString()):
+			values[envVar.Key] = priorHash
+		default:
+			hash, err := utils.HashSecret(*envVar.Value)
+			if err != nil {
+				return types.MapNull(types.StringType), diag.Diagnostics{diag.NewErrorDiagnostic(
+					"Unable to hash secret environment variable",
+					fmt.Sprintf("Unable to hash the secret environment variable %v, got error: %s", envVar.Key, err),
+				)}
+			}
+			values[envVar.Key] = types.StringValue(hash)
+		}
+	}
+	if len(values) == 0 && priorHashes.IsNull() {
+		return types.MapNull(types.StringType), nil
+	}
+	return types.MapValue(types.StringType, values)
+}
+
 func DeploymentEnvironmentVariableTypesObject(
 	ctx context.Context,
 	envVar platform.DeploymentEnvironmentVariable,
@@ -447,10 +532,12 @@ type DeploymentStatus struct {
 }
 
 type HibernationStatus struct {
-	IsHibernating types.Bool   `tfsdk:"is_hibernating"`
-	NextEventType types.String `tfsdk:"next_event_type"`
-	NextEventAt   types.String `tfsdk:"next_event_at"`
-	Reason        types.String `tfsdk:"reason"`
+	IsHibernating   types.Bool   `tfsdk:"is_hibernating"`
+	NextEventType   types.String `tfsdk:"next_event_type"`
+	NextEventAt     types.String `tfsdk:"next_event_at"`
+	NextHibernateAt types.String `tfsdk:"next_hibernate_at"`
+	NextWakeAt      types.String `tfsdk:"next_wake_at"`
+	Reason          types.String `tfsdk:"reason"`
 }
 
 type HibernationSpec struct {
@@ -458,6 +545,14 @@ type HibernationSpec struct {
 	Schedules types.Set    `tfsdk:"schedules"`
 }
 
+// HibernationSpecResource is the hibernation_spec of the resource
+type HibernationSpecResource struct {
+	Override  types.Object `tfsdk:"override"`
+	Schedules types.Set    `tfsdk:"schedules"`
+	// Provider only fields
+	Timezone types.String `tfsdk:"timezone"`
+}
+
 type HibernationSpecOverride struct {
 	IsHibernating types.Bool   `tfsdk:"is_hibernating"`
 	OverrideUntil types.String `tfsdk:"override_until"`
@@ -471,23 +566,55 @@ type HibernationSchedule struct {
 	WakeAtCron      types.String `tfsdk:"wake_at_cron"`
 }
 
+// HibernationStatusTypesObject also sets the next hibernate and wake times of the enabled schedules
 func HibernationStatusTypesObject(
 	ctx context.Context,
 	hibernationStatus *platform.DeploymentHibernationStatus,
+	schedules []platform.DeploymentHibernationSchedule,
 ) (types.Object, diag.Diagnostics) {
 	if hibernationStatus == nil {
 		return types.ObjectNull(schemas.HibernationStatusAttributeTypes()), nil
 	}
 
 	obj := HibernationStatus{
-		IsHibernating: types.BoolValue(hibernationStatus.IsHibernating),
-		NextEventType: types.StringPointerValue((*string)(hibernationStatus.NextEventType)),
-		NextEventAt:   types.StringPointerValue(hibernationStatus.NextEventAt),
-		Reason:        types.StringPointerValue(hibernationStatus.Reason),
+		IsHibernating:   types.BoolValue(hibernationStatus.IsHibernating),
+		NextEventType:   types.StringPointerValue((*string)(hibernationStatus.NextEventType)),
+		NextEventAt:     types.StringPointerValue(hibernationStatus.NextEventAt),
+		NextHibernateAt: types.StringNull(),
+		NextWakeAt:      types.StringNull(),
+		Reason:          types.StringPointerValue(hibernationStatus.Reason),
+	}
+	now := time.Now()
+	var nextHibernateAt, nextWakeAt time.Time
+	for _, schedule := range schedules {
+		if !schedule.IsEnabled {
+			continue
+		}
+		// the API only accepts valid cron expressions
+		if hibernateAt, err := utils.ParseCron(schedule.HibernateAtCron); err == nil {
+			nextHibernateAt = earliest(nextHibernateAt, hibernateAt.Next(now))
+		}
+		if wakeAt, err := utils.ParseCron(schedule.WakeAtCron); err == nil {
+			nextWakeAt = earliest(nextWakeAt, wakeAt.Next(now))
+		}
+	}
+	if !nextHibernateAt.IsZero() {
+		obj.NextHibernateAt = types.StringValue(nextHibernateAt.Format(time.RFC3339))
+	}
+	if !nextWakeAt.IsZero() {
+		obj.NextWakeAt = types.StringValue(nextWakeAt.Format(time.RFC3339))
 	}
 	return types.ObjectValueFrom(ctx, schemas.HibernationStatusAttributeTypes(), obj)
 }
 
+// earliest returns the earliest of two times, ignoring zero times
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
 func HibernationOverrideTypesObject(
 	ctx context.Context,
 	hibernationOverride *platform.DeploymentHibernationOverride,
@@ -544,12 +671,17 @@ func HibernationSpecTypesObject(
 func ScalingStatusTypesObject(
 	ctx context.Context,
 	scalingStatus *platform.DeploymentScalingStatus,
+	scalingSpec *platform.DeploymentScalingSpec,
 ) (types.Object, diag.Diagnostics) {
 	if scalingStatus == nil {
 		return types.ObjectNull(schemas.ScalingStatusAttributeTypes()), nil
 	}
 
-	hibernationStatus, diags := HibernationStatusTypesObject(ctx, scalingStatus.HibernationStatus)
+	var schedules []platform.DeploymentHibernationSchedule
+	if scalingSpec != nil && scalingSpec.HibernationSpec != nil && scalingSpec.HibernationSpec.Schedules != nil {
+		schedules = *scalingSpec.HibernationSpec.Schedules
+	}
+	hibernationStatus, diags := HibernationStatusTypesObject(ctx, scalingStatus.HibernationStatus, schedules)
 	if diags.HasError() {
 		return types.ObjectNull(schemas.ScalingStatusAttributeTypes()), diags
 	}
@@ -576,3 +708,97 @@ func ScalingSpecTypesObject(
 	}
 	return types.ObjectValueFrom(ctx, schemas.ScalingSpecAttributeTypes(), obj)
 }
+
+// ScalingSpecResourceTypesObject returns the scaling_spec of the resource. The API only has the cron expressions in
+// UTC, so the expressions of the prior scaling_spec are kept with its timezone when they translate to the same
+// expressions in UTC.
+func ScalingSpecResourceTypesObject(
+	ctx context.Context,
+	scalingSpec *platform.DeploymentScalingSpec,
+	priorScalingSpec types.Object,
+) (types.Object, diag.Diagnostics) {
+	if scalingSpec == nil {
+		return types.ObjectNull(schemas.ScalingSpecResourceAttributeTypes()), nil
+	}
+	hibernationSpec := scalingSpec.HibernationSpec
+	if hibernationSpec == nil || (hibernationSpec.Override == nil && hibernationSpec.Schedules == nil) {
+		obj := DeploymentScalingSpec{
+			HibernationSpec: types.ObjectNull(schemas.HibernationSpecResourceAttributeTypes()),
+		}
+		return types.ObjectValueFrom(ctx, schemas.ScalingSpecResourceAttributeTypes(), obj)
+	}
+
+	timezone := types.StringNull()
+	var priorSchedules []HibernationSchedule
+	if !priorScalingSpec.IsNull() && !priorScalingSpec.IsUnknown() {
+		var priorSpec DeploymentScalingSpec
+		diags := priorScalingSpec.As(ctx, &priorSpec, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: true, UnhandledUnknownAsEmpty: true})
+		if diags.HasError() {
+			return types.ObjectNull(schemas.ScalingSpecResourceAttributeTypes()), diags
+		}
+		var priorHibernationSpec HibernationSpecResource
+		diags = priorSpec.HibernationSpec.As(ctx, &priorHibernationSpec, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: true, UnhandledUnknownAsEmpty: true})
+		if diags.HasError() {
+			return types.ObjectNull(schemas.ScalingSpecResourceAttributeTypes()), diags
+		}
+		timezone = priorHibernationSpec.Timezone
+		if !priorHibernationSpec.Schedules.IsNull() && !priorHibernationSpec.Schedules.IsUnknown() {
+			diags = priorHibernationSpec.Schedules.ElementsAs(ctx, &priorSchedules, false)
+			if diags.HasError() {
+				return types.ObjectNull(schemas.ScalingSpecResourceAttributeTypes()), diags
+			}
+		}
+	}
+
+	override, diags := HibernationOverrideTypesObject(ctx, hibernationSpec.Override)
+	if diags.HasError() {
+		return types.ObjectNull(schemas.ScalingSpecResourceAttributeTypes()), diags
+	}
+	var schedules *[]platform.DeploymentHibernationSchedule
+	if hibernationSpec.Schedules != nil {
+		localSchedules := lo.Map(*hibernationSpec.Schedules, func(schedule platform.DeploymentHibernationSchedule, _ int) platform.DeploymentHibernationSchedule {
+			return localHibernationSchedule(schedule, priorSchedules, timezone.ValueString())
+		})
+		schedules = &localSchedules
+	}
+	schedulesSet, diags := utils.ObjectSet(ctx, schedules, schemas.HibernationScheduleAttributeTypes(), HibernationScheduleTypesObject)
+	if diags.HasError() {
+		return types.ObjectNull(schemas.ScalingSpecResourceAttributeTypes()), diags
+	}
+	hibernationSpecObj, diags := types.ObjectValueFrom(ctx, schemas.HibernationSpecResourceAttributeTypes(), HibernationSpecResource{
+		Override:  override,
+		Schedules: schedulesSet,
+		Timezone:  timezone,
+	})
+	if diags.HasError() {
+		return types.ObjectNull(schemas.ScalingSpecResourceAttributeTypes()), diags
+	}
+	obj := DeploymentScalingSpec{
+		HibernationSpec: hibernationSpecObj,
+	}
+	return types.ObjectValueFrom(ctx, schemas.ScalingSpecResourceAttributeTypes(), obj)
+}
+
+// localHibernationSchedule returns the schedule with the cron expressions of the prior schedule in timezone that
+// translates to the schedule of the API
+func localHibernationSchedule(
+	schedule platform.DeploymentHibernationSchedule,
+	priorSchedules []HibernationSchedule,
+	timezone string,
+) platform.DeploymentHibernationSchedule {
+	location, err := time.LoadLocation(timezone)
+	if timezone == "" || err != nil {
+		return schedule
+	}
+	now := time.Now()
+	for _, priorSchedule := range priorSchedules {
+		hibernateAtCron, hibernateErr := utils.CronToUTC(priorSchedule.HibernateAtCron.ValueString(), location, now)
+		wakeAtCron, wakeErr := utils.CronToUTC(priorSchedule.WakeAtCron.ValueString(), location, now)
+		if hibernateErr == nil && wakeErr == nil && hibernateAtCron == schedule.HibernateAtCron && wakeAtCron == schedule.WakeAtCron {
+			schedule.HibernateAtCron = priorSchedule.HibernateAtCron.ValueString()
+			schedule.WakeAtCron = priorSchedule.WakeAtCron.ValueString()
+			return schedule
+		}
+	}
+	return schedule
+}
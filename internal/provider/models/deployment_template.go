@@ -0,0 +1,171 @@
+package models
+
+import (
+	"context"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+)
+
+// DeploymentTemplateDataSource describes the data source data model of a deployment template, the configuration of a
+// deployment to copy into a new deployment
+type DeploymentTemplateDataSource struct {
+	Id                            types.String `tfsdk:"id"`
+	Description                   types.String `tfsdk:"description"`
+	WorkspaceId                   types.String `tfsdk:"workspace_id"`
+	Type                          types.String `tfsdk:"type"`
+	CloudProvider                 types.String `tfsdk:"cloud_provider"`
+	Region                        types.String `tfsdk:"region"`
+	ClusterId                     types.String `tfsdk:"cluster_id"`
+	AstroRuntimeVersion           types.String `tfsdk:"astro_runtime_version"`
+	ContactEmails                 types.Set    `tfsdk:"contact_emails"`
+	Executor                      types.String `tfsdk:"executor"`
+	IsCicdEnforced                types.Bool   `tfsdk:"is_cicd_enforced"`
+	IsDagDeployEnabled            types.Bool   `tfsdk:"is_dag_deploy_enabled"`
+	EnvironmentVariablesMap       types.Map    `tfsdk:"environment_variables_map"`
+	SecretEnvironmentVariableKeys types.Set    `tfsdk:"secret_environment_variable_keys"`
+	WorkerQueues                  types.Set    `tfsdk:"worker_queues"`
+
+	// Hybrid deployment specific fields
+	SchedulerAu       types.Int64  `tfsdk:"scheduler_au"`
+	SchedulerReplicas types.Int64  `tfsdk:"scheduler_replicas"`
+	TaskPodNodePoolId types.String `tfsdk:"task_pod_node_pool_id"`
+
+	// Hosted (standard and dedicated) deployment specific fields
+	SchedulerSize        types.String `tfsdk:"scheduler_size"`
+	IsDevelopmentMode    types.Bool   `tfsdk:"is_development_mode"`
+	IsHighAvailability   types.Bool   `tfsdk:"is_high_availability"`
+	ResourceQuotaCpu     types.String `tfsdk:"resource_quota_cpu"`
+	ResourceQuotaMemory  types.String `tfsdk:"resource_quota_memory"`
+	DefaultTaskPodCpu    types.String `tfsdk:"default_task_pod_cpu"`
+	DefaultTaskPodMemory types.String `tfsdk:"default_task_pod_memory"`
+	ScalingSpec          types.Object `tfsdk:"scaling_spec"`
+}
+
+func (data *DeploymentTemplateDataSource) ReadFromResponse(
+	ctx context.Context,
+	deployment *platform.Deployment,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data.Id = types.StringValue(deployment.Id)
+	data.Description = types.StringValue(lo.FromPtr(deployment.Description))
+	data.WorkspaceId = types.StringValue(deployment.WorkspaceId)
+	data.Type = types.StringPointerValue((*string)(deployment.Type))
+	data.AstroRuntimeVersion = types.StringValue(deployment.AstroRuntimeVersion)
+	data.ContactEmails, diags = utils.StringSet(deployment.ContactEmails)
+	if diags.HasError() {
+		return diags
+	}
+	data.Executor = types.StringPointerValue((*string)(deployment.Executor))
+	data.IsCicdEnforced = types.BoolValue(deployment.IsCicdEnforced)
+	data.IsDagDeployEnabled = types.BoolValue(deployment.IsDagDeployEnabled)
+
+	// Secret values are not returned by the API, only the keys of the secrets are copied
+	envVars := lo.FromPtr(deployment.EnvironmentVariables)
+	data.EnvironmentVariablesMap, diags = environmentVariablesMap(envVars, types.MapValueMust(types.StringType, nil))
+	if diags.HasError() {
+		return diags
+	}
+	secretKeys := lo.FilterMap(envVars, func(envVar platform.DeploymentEnvironmentVariable, _ int) (string, bool) {
+		return envVar.Key, envVar.IsSecret
+	})
+	data.SecretEnvironmentVariableKeys, diags = utils.StringSet(&secretKeys)
+	if diags.HasError() {
+		return diags
+	}
+	isHybrid := lo.FromPtr(deployment.Type) == platform.DeploymentTypeHYBRID
+	data.WorkerQueues, diags = utils.ObjectSet(ctx, deployment.WorkerQueues, schemas.WorkerQueueResourceAttributeTypes(), func(ctx context.Context, workerQueue platform.WorkerQueue) (types.Object, diag.Diagnostics) {
+		return WorkerQueueTemplateTypesObject(ctx, workerQueue, isHybrid)
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	// Each type of deployment only has the fields of its resource configuration
+	data.CloudProvider = types.StringNull()
+	data.Region = types.StringNull()
+	data.ClusterId = types.StringNull()
+	data.SchedulerAu = types.Int64Null()
+	data.SchedulerReplicas = types.Int64Null()
+	data.TaskPodNodePoolId = types.StringNull()
+	data.SchedulerSize = types.StringNull()
+	data.IsDevelopmentMode = types.BoolNull()
+	data.IsHighAvailability = types.BoolNull()
+	data.ResourceQuotaCpu = types.StringNull()
+	data.ResourceQuotaMemory = types.StringNull()
+	data.DefaultTaskPodCpu = types.StringNull()
+	data.DefaultTaskPodMemory = types.StringNull()
+	data.ScalingSpec = types.ObjectNull(schemas.ScalingSpecResourceAttributeTypes())
+	switch lo.FromPtr(deployment.Type) {
+	case platform.DeploymentTypeSTANDARD:
+		data.CloudProvider = types.StringPointerValue((*string)(deployment.CloudProvider))
+		data.Region = types.StringPointerValue(deployment.Region)
+	case platform.DeploymentTypeDEDICATED, platform.DeploymentTypeHYBRID:
+		data.ClusterId = types.StringPointerValue(deployment.ClusterId)
+	}
+	if isHybrid {
+		if deployment.SchedulerAu != nil {
+			data.SchedulerAu = types.Int64Value(int64(*deployment.SchedulerAu))
+		}
+		data.SchedulerReplicas = types.Int64Value(int64(deployment.SchedulerReplicas))
+		data.TaskPodNodePoolId = types.StringPointerValue(deployment.TaskPodNodePoolId)
+		return nil
+	}
+	data.SchedulerSize = types.StringPointerValue((*string)(deployment.SchedulerSize))
+	data.IsDevelopmentMode = types.BoolPointerValue(deployment.IsDevelopmentMode)
+	data.IsHighAvailability = types.BoolPointerValue(deployment.IsHighAvailability)
+	data.ResourceQuotaCpu = types.StringPointerValue(deployment.ResourceQuotaCpu)
+	data.ResourceQuotaMemory = types.StringPointerValue(deployment.ResourceQuotaMemory)
+	data.DefaultTaskPodCpu = types.StringPointerValue(deployment.DefaultTaskPodCpu)
+	data.DefaultTaskPodMemory = types.StringPointerValue(deployment.DefaultTaskPodMemory)
+	data.ScalingSpec, diags = ScalingSpecTemplateTypesObject(ctx, deployment.ScalingSpec)
+	return diags
+}
+
+// WorkerQueueTemplateTypesObject returns a worker queue of the resource without the pod_cpu and pod_memory computed by
+// the API, hybrid worker queues only have a node_pool_id and hosted worker queues only have an astro_machine
+func WorkerQueueTemplateTypesObject(
+	ctx context.Context,
+	workerQueue platform.WorkerQueue,
+	isHybrid bool,
+) (types.Object, diag.Diagnostics) {
+	obj := WorkerQueueResource{
+		Name:              types.StringValue(workerQueue.Name),
+		AstroMachine:      types.StringNull(),
+		IsDefault:         types.BoolValue(workerQueue.IsDefault),
+		MaxWorkerCount:    types.Int64Value(int64(workerQueue.MaxWorkerCount)),
+		MinWorkerCount:    types.Int64Value(int64(workerQueue.MinWorkerCount)),
+		NodePoolId:        types.StringNull(),
+		PodCpu:            types.StringNull(),
+		PodMemory:         types.StringNull(),
+		WorkerConcurrency: types.Int64Value(int64(workerQueue.WorkerConcurrency)),
+	}
+	if isHybrid {
+		obj.NodePoolId = types.StringPointerValue(workerQueue.NodePoolId)
+	} else {
+		obj.AstroMachine = types.StringPointerValue(workerQueue.AstroMachine)
+	}
+
+	return types.ObjectValueFrom(ctx, schemas.WorkerQueueResourceAttributeTypes(), obj)
+}
+
+// ScalingSpecTemplateTypesObject returns the scaling_spec of the resource with the hibernation schedules of a
+// deployment, it is null when the deployment has no schedules. The hibernation override is not copied since it is
+// about the current state of the deployment.
+func ScalingSpecTemplateTypesObject(
+	ctx context.Context,
+	scalingSpec *platform.DeploymentScalingSpec,
+) (types.Object, diag.Diagnostics) {
+	if scalingSpec == nil || scalingSpec.HibernationSpec == nil || len(lo.FromPtr(scalingSpec.HibernationSpec.Schedules)) == 0 {
+		return types.ObjectNull(schemas.ScalingSpecResourceAttributeTypes()), nil
+	}
+	return ScalingSpecResourceTypesObject(ctx, &platform.DeploymentScalingSpec{
+		HibernationSpec: &platform.DeploymentHibernationSpec{
+			Schedules: scalingSpec.HibernationSpec.Schedules,
+		},
+	}, types.ObjectNull(schemas.ScalingSpecResourceAttributeTypes()))
+}
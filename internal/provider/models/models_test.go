@@ -0,0 +1,438 @@
+package models_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/cassette"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/fakeapi"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+// The responses read by these tests are replayed from a cassette, run them with ASTRO_CASSETTE_MODE=record to
+// record the cassette again against a seeded fake Astro API
+var cassettePath = filepath.Join("testdata", "cassettes", "read_from_response.json")
+
+func newCassetteClients(t *testing.T) (*platform.ClientWithResponses, *iam.ClientWithResponses, map[string]string) {
+	mode := cassette.ModeReplay
+	host := "https://api.astronomer.io"
+	if os.Getenv("ASTRO_CASSETTE_MODE") == string(cassette.ModeRecord) {
+		mode = cassette.ModeRecord
+	}
+	recorder, err := cassette.NewRecorder(cassettePath, mode)
+	assert.NoError(t, err)
+	if mode == cassette.ModeRecord {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		t.Cleanup(server.Close)
+		fixtures := server.Seed()
+		host = server.URL()
+		recorder.SetVariable("HOSTED_ORGANIZATION_ID", fixtures.HostedOrganizationId)
+		recorder.SetVariable("HOSTED_WORKSPACE_ID", fixtures.HostedWorkspaceId)
+		recorder.SetVariable("HOSTED_DEPLOYMENT_ID", fixtures.HostedDeploymentId)
+		recorder.SetVariable("HOSTED_TEAM_ID", fixtures.HostedTeamId)
+		t.Cleanup(func() {
+			assert.NoError(t, recorder.Save())
+		})
+	}
+
+	platformClient, err := platform.NewPlatformClient(host, clients.StaticToken(fakeapi.HostedOrganizationToken), "test", platform.WithHTTPClient(recorder))
+	assert.NoError(t, err)
+	iamClient, err := iam.NewIamClient(host, clients.StaticToken(fakeapi.HostedOrganizationToken), "test", iam.WithHTTPClient(recorder))
+	assert.NoError(t, err)
+	return platformClient, iamClient, recorder.Variables()
+}
+
+func deploymentResourceSchema() tfsdk.State {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	resources.NewDeploymentResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	return tfsdk.State{Schema: schemaResp.Schema}
+}
+
+// nullDeploymentResource returns a deployment resource whose attributes are null values of their types
+func nullDeploymentResource(t *testing.T) models.DeploymentResource {
+	t.Helper()
+	state := deploymentResourceSchema()
+	objectType := state.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	state.Raw = tftypes.NewValue(objectType, attributes)
+	var data models.DeploymentResource
+	diags := state.Get(context.Background(), &data)
+	assert.False(t, diags.HasError(), diags)
+	return data
+}
+
+// assertStateWithoutValue checks that no string of the state of a deployment is value
+func assertStateWithoutValue(t *testing.T, data *models.DeploymentResource, value string) {
+	t.Helper()
+	state := deploymentResourceSchema()
+	diags := state.Set(context.Background(), data)
+	assert.False(t, diags.HasError(), diags)
+	err := tftypes.Walk(state.Raw, func(path *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+		if v.Type().Is(tftypes.String) && v.IsKnown() && !v.IsNull() {
+			var s string
+			if err := v.As(&s); err != nil {
+				return false, err
+			}
+			assert.NotEqual(t, value, s, "the state has the value at %v", path)
+		}
+		return true, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestUnit_ReadFromResponse(t *testing.T) {
+	ctx := context.Background()
+	platformClient, iamClient, variables := newCassetteClients(t)
+	organizationId := variables["HOSTED_ORGANIZATION_ID"]
+
+	t.Run("workspace", func(t *testing.T) {
+		workspace, err := platformClient.GetWorkspaceWithResponse(ctx, organizationId, variables["HOSTED_WORKSPACE_ID"])
+		assert.NoError(t, err)
+
+		var data models.Workspace
+		diags := data.ReadFromResponse(ctx, workspace.JSON200)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, variables["HOSTED_WORKSPACE_ID"], data.Id.ValueString())
+		assert.Equal(t, workspace.JSON200.Name, data.Name.ValueString())
+		assert.False(t, data.CreatedBy.IsNull())
+	})
+
+	t.Run("deployment with secret environment variables", func(t *testing.T) {
+		envVars := []platform.DeploymentEnvironmentVariableRequest{
+			{Key: "KEY", Value: lo.ToPtr("value")},
+			{Key: "SECRET", Value: lo.ToPtr("secret"), IsSecret: true},
+		}
+		var req platform.CreateDeploymentRequest
+		assert.NoError(t, req.FromCreateStandardDeploymentRequest(platform.CreateStandardDeploymentRequest{
+			AstroRuntimeVersion:  "11.5.0",
+			CloudProvider:        lo.ToPtr(platform.CreateStandardDeploymentRequestCloudProviderAWS),
+			DefaultTaskPodCpu:    "0.25",
+			DefaultTaskPodMemory: "0.5Gi",
+			EnvironmentVariables: &envVars,
+			Executor:             platform.CreateStandardDeploymentRequestExecutorCELERY,
+			Name:                 "deployment",
+			Region:               lo.ToPtr("us-east-1"),
+			ResourceQuotaCpu:     "10",
+			ResourceQuotaMemory:  "20Gi",
+			SchedulerSize:        platform.CreateStandardDeploymentRequestSchedulerSizeSMALL,
+			Type:                 platform.CreateStandardDeploymentRequestTypeSTANDARD,
+			WorkerQueues: &[]platform.WorkerQueueRequest{{
+				AstroMachine:      platform.WorkerQueueRequestAstroMachineA5,
+				IsDefault:         true,
+				MaxWorkerCount:    10,
+				Name:              "default",
+				WorkerConcurrency: 5,
+			}},
+			WorkspaceId: variables["HOSTED_WORKSPACE_ID"],
+		}))
+		deployment, err := platformClient.CreateDeploymentWithResponse(ctx, organizationId, req)
+		assert.NoError(t, err)
+
+		var data models.DeploymentResource
+		diags := data.ReadFromResponse(ctx, deployment.JSON200, lo.ToPtr("11.5.0"), &envVars)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "STANDARD", data.Type.ValueString())
+		assert.Equal(t, "11.5.0", data.OriginalAstroRuntimeVersion.ValueString())
+		assert.Len(t, data.EnvironmentVariables.Elements(), 2)
+		assert.Len(t, data.WorkerQueues.Elements(), 1)
+
+		// environment variables configured by key, only the hashes of the secrets are kept
+		data = nullDeploymentResource(t)
+		data.SecretEnvironmentVariables = types.MapValueMust(types.StringType, map[string]attr.Value{
+			"SECRET": types.StringValue("secret"),
+		})
+		diags = data.ReadFromResponse(ctx, deployment.JSON200, lo.ToPtr("11.5.0"), &envVars)
+		assert.False(t, diags.HasError())
+		assert.True(t, data.EnvironmentVariables.IsNull())
+		assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{"KEY": types.StringValue("value")}), data.EnvironmentVariablesMap)
+		assert.True(t, data.SecretEnvironmentVariables.IsNull())
+		assert.Len(t, data.SecretEnvironmentVariablesHashes.Elements(), 1)
+		secretHash := data.SecretEnvironmentVariablesHashes.Elements()["SECRET"].(types.String).ValueString()
+		assert.True(t, utils.SecretMatchesHash("secret", secretHash))
+		assertStateWithoutValue(t, &data, "secret")
+		hashes := data.SecretEnvironmentVariablesHashes
+
+		// the hash of a secret is kept while it matches its value, or when its value is not known
+		data = nullDeploymentResource(t)
+		data.SecretEnvironmentVariablesHashes = hashes
+		diags = data.ReadFromResponse(ctx, deployment.JSON200, lo.ToPtr("11.5.0"), &envVars)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, hashes, data.SecretEnvironmentVariablesHashes)
+		data = nullDeploymentResource(t)
+		data.SecretEnvironmentVariablesHashes = hashes
+		diags = data.ReadFromResponse(ctx, deployment.JSON200, lo.ToPtr("11.5.0"), &[]platform.DeploymentEnvironmentVariableRequest{envVars[0]})
+		assert.False(t, diags.HasError())
+		assert.Equal(t, hashes, data.SecretEnvironmentVariablesHashes)
+
+		// a changed secret is hashed again
+		data = nullDeploymentResource(t)
+		data.SecretEnvironmentVariablesHashes = hashes
+		diags = data.ReadFromResponse(ctx, deployment.JSON200, lo.ToPtr("11.5.0"), &[]platform.DeploymentEnvironmentVariableRequest{
+			envVars[0],
+			{Key: "SECRET", Value: lo.ToPtr("changed"), IsSecret: true},
+		})
+		assert.False(t, diags.HasError())
+		changedHash := data.SecretEnvironmentVariablesHashes.Elements()["SECRET"].(types.String).ValueString()
+		assert.NotEqual(t, secretHash, changedHash)
+		assert.True(t, utils.SecretMatchesHash("changed", changedHash))
+		assertStateWithoutValue(t, &data, "changed")
+
+		// secrets without a hash whose values are not known are left out
+		data = nullDeploymentResource(t)
+		data.SecretEnvironmentVariables = types.MapValueMust(types.StringType, map[string]attr.Value{})
+		diags = data.ReadFromResponse(ctx, deployment.JSON200, lo.ToPtr("11.5.0"), &[]platform.DeploymentEnvironmentVariableRequest{envVars[0]})
+		assert.False(t, diags.HasError())
+		assert.Empty(t, data.SecretEnvironmentVariablesHashes.Elements())
+	})
+
+	t.Run("dedicated deployment and its cluster", func(t *testing.T) {
+		deployment, err := platformClient.GetDeploymentWithResponse(ctx, organizationId, variables["HOSTED_DEPLOYMENT_ID"])
+		assert.NoError(t, err)
+
+		var deploymentData models.DeploymentDataSource
+		diags := deploymentData.ReadFromResponse(ctx, deployment.JSON200)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "DEDICATED", deploymentData.Type.ValueString())
+		assert.Equal(t, "HEALTHY", deploymentData.Status.ValueString())
+
+		cluster, err := platformClient.GetClusterWithResponse(ctx, organizationId, deploymentData.ClusterId.ValueString())
+		assert.NoError(t, err)
+
+		var clusterData models.ClusterResource
+		diags = clusterData.ReadFromResponse(ctx, cluster.JSON200)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "CREATED", clusterData.Status.ValueString())
+		assert.Len(t, clusterData.NodePools.Elements(), 1)
+	})
+
+	t.Run("team", func(t *testing.T) {
+		team, err := iamClient.GetTeamWithResponse(ctx, organizationId, variables["HOSTED_TEAM_ID"])
+		assert.NoError(t, err)
+		members, err := iamClient.ListTeamMembersWithResponse(ctx, organizationId, variables["HOSTED_TEAM_ID"], nil)
+		assert.NoError(t, err)
+		memberIds := lo.Map(members.JSON200.TeamMembers, func(member iam.TeamMember, _ int) string {
+			return member.UserId
+		})
+
+		var data models.TeamResource
+		diags := data.ReadFromResponse(ctx, team.JSON200, &memberIds)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, team.JSON200.Name, data.Name.ValueString())
+		assert.Len(t, data.MemberIds.Elements(), 1)
+		assert.False(t, data.IsIdpManaged.ValueBool())
+	})
+
+	t.Run("api token", func(t *testing.T) {
+		apiToken, err := iamClient.CreateApiTokenWithResponse(ctx, organizationId, iam.CreateApiTokenRequest{
+			EntityId: lo.ToPtr(variables["HOSTED_WORKSPACE_ID"]),
+			Name:     "token",
+			Role:     string(iam.WORKSPACEMEMBER),
+			Type:     iam.WORKSPACE,
+		})
+		assert.NoError(t, err)
+
+		var data models.ApiTokenResource
+		diags := data.ReadFromResponse(ctx, apiToken.JSON200, *apiToken.JSON200.Token)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "WORKSPACE", data.Type.ValueString())
+		assert.NotEmpty(t, data.Token.ValueString())
+		assert.Len(t, data.Roles.Elements(), 1)
+	})
+}
+
+func TestUnit_ScalingSpec(t *testing.T) {
+	ctx := context.Background()
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	hibernateAtCron, err := utils.CronToUTC("0 19 * * 1-5", newYork, time.Now())
+	assert.NoError(t, err)
+	wakeAtCron, err := utils.CronToUTC("0 7 * * 1-5", newYork, time.Now())
+	assert.NoError(t, err)
+	scalingSpec := &platform.DeploymentScalingSpec{
+		HibernationSpec: &platform.DeploymentHibernationSpec{
+			Schedules: &[]platform.DeploymentHibernationSchedule{
+				{HibernateAtCron: hibernateAtCron, WakeAtCron: wakeAtCron, IsEnabled: true},
+				{HibernateAtCron: "30 0 * * 6", WakeAtCron: "30 0 * * 0", IsEnabled: false},
+			},
+		},
+	}
+
+	t.Run("keeps the cron expressions of the prior schedules in their timezone", func(t *testing.T) {
+		schedule := types.ObjectValueMust(schemas.HibernationScheduleAttributeTypes(), map[string]attr.Value{
+			"description":       types.StringNull(),
+			"hibernate_at_cron": types.StringValue("0 19 * * 1-5"),
+			"is_enabled":        types.BoolValue(true),
+			"wake_at_cron":      types.StringValue("0 7 * * 1-5"),
+		})
+		prior := types.ObjectValueMust(schemas.ScalingSpecResourceAttributeTypes(), map[string]attr.Value{
+			"hibernation_spec": types.ObjectValueMust(schemas.HibernationSpecResourceAttributeTypes(), map[string]attr.Value{
+				"override":  types.ObjectNull(schemas.HibernationOverrideAttributeTypes()),
+				"schedules": types.SetValueMust(types.ObjectType{AttrTypes: schemas.HibernationScheduleAttributeTypes()}, []attr.Value{schedule}),
+				"timezone":  types.StringValue("America/New_York"),
+			}),
+		})
+
+		obj, diags := models.ScalingSpecResourceTypesObject(ctx, scalingSpec, prior)
+		assert.False(t, diags.HasError())
+		hibernationSpec := obj.Attributes()["hibernation_spec"].(types.Object).Attributes()
+		assert.Equal(t, types.StringValue("America/New_York"), hibernationSpec["timezone"])
+		crons := lo.Map(hibernationSpec["schedules"].(types.Set).Elements(), func(element attr.Value, _ int) string {
+			attributes := element.(types.Object).Attributes()
+			return attributes["hibernate_at_cron"].(types.String).ValueString() + " / " + attributes["wake_at_cron"].(types.String).ValueString()
+		})
+		assert.ElementsMatch(t, []string{"0 19 * * 1-5 / 0 7 * * 1-5", "30 0 * * 6 / 30 0 * * 0"}, crons)
+
+		// imported deployments have no prior scaling_spec
+		obj, diags = models.ScalingSpecResourceTypesObject(ctx, scalingSpec, types.ObjectNull(schemas.ScalingSpecResourceAttributeTypes()))
+		assert.False(t, diags.HasError())
+		hibernationSpec = obj.Attributes()["hibernation_spec"].(types.Object).Attributes()
+		assert.True(t, hibernationSpec["timezone"].IsNull())
+	})
+
+	t.Run("sets the next events of the enabled schedules", func(t *testing.T) {
+		obj, diags := models.ScalingStatusTypesObject(ctx, &platform.DeploymentScalingStatus{
+			HibernationStatus: &platform.DeploymentHibernationStatus{IsHibernating: false},
+		}, scalingSpec)
+		assert.False(t, diags.HasError())
+		hibernationStatus := obj.Attributes()["hibernation_status"].(types.Object).Attributes()
+		for _, name := range []string{"next_hibernate_at", "next_wake_at"} {
+			nextEventAt, err := time.Parse(time.RFC3339, hibernationStatus[name].(types.String).ValueString())
+			assert.NoError(t, err)
+			assert.True(t, nextEventAt.After(time.Now()))
+			assert.True(t, nextEventAt.Before(time.Now().Add(4*24*time.Hour)))
+			// the disabled schedule is ignored
+			assert.Equal(t, 0, nextEventAt.Minute())
+		}
+
+		obj, diags = models.ScalingStatusTypesObject(ctx, &platform.DeploymentScalingStatus{
+			HibernationStatus: &platform.DeploymentHibernationStatus{IsHibernating: false},
+		}, nil)
+		assert.False(t, diags.HasError())
+		hibernationStatus = obj.Attributes()["hibernation_status"].(types.Object).Attributes()
+		assert.True(t, hibernationStatus["next_hibernate_at"].IsNull())
+		assert.True(t, hibernationStatus["next_wake_at"].IsNull())
+	})
+}
+
+func TestUnit_DeploymentTemplate(t *testing.T) {
+	ctx := context.Background()
+	source := &platform.Deployment{
+		Id:                  "clozc036j01to01jrlgvueo8t",
+		AstroRuntimeVersion: "11.5.0",
+		CloudProvider:       lo.ToPtr(platform.DeploymentCloudProviderAWS),
+		ContactEmails:       &[]string{"owner@astronomer.test"},
+		DefaultTaskPodCpu:   lo.ToPtr("0.25"),
+		EnvironmentVariables: &[]platform.DeploymentEnvironmentVariable{
+			{Key: "KEY", Value: lo.ToPtr("value")},
+			{Key: "SECRET", IsSecret: true},
+		},
+		Executor:          lo.ToPtr(platform.DeploymentExecutorCELERY),
+		IsDevelopmentMode: lo.ToPtr(true),
+		Region:            lo.ToPtr("us-east-1"),
+		ScalingSpec: &platform.DeploymentScalingSpec{
+			HibernationSpec: &platform.DeploymentHibernationSpec{
+				Override:  &platform.DeploymentHibernationOverride{IsHibernating: lo.ToPtr(true), IsActive: lo.ToPtr(true)},
+				Schedules: &[]platform.DeploymentHibernationSchedule{{HibernateAtCron: "0 19 * * *", WakeAtCron: "0 7 * * *", IsEnabled: true}},
+			},
+		},
+		SchedulerReplicas: 1,
+		SchedulerSize:     lo.ToPtr(platform.DeploymentSchedulerSizeSMALL),
+		Type:              lo.ToPtr(platform.DeploymentTypeSTANDARD),
+		WorkerQueues: &[]platform.WorkerQueue{{
+			Id:                "queue",
+			AstroMachine:      lo.ToPtr("A5"),
+			IsDefault:         true,
+			MaxWorkerCount:    10,
+			Name:              "default",
+			NodePoolId:        lo.ToPtr("node-pool"),
+			PodCpu:            "1",
+			PodMemory:         "2Gi",
+			WorkerConcurrency: 5,
+		}},
+		WorkspaceId: "workspace",
+	}
+
+	t.Run("copies the configuration of hosted deployments", func(t *testing.T) {
+		var data models.DeploymentTemplateDataSource
+		diags := data.ReadFromResponse(ctx, source)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "us-east-1", data.Region.ValueString())
+		assert.True(t, data.ClusterId.IsNull())
+		assert.True(t, data.SchedulerReplicas.IsNull())
+		assert.Equal(t, "SMALL", data.SchedulerSize.ValueString())
+
+		// the template is assigned to the attributes of the resource, whose request builders read it as configured
+		workerQueues, diags := resources.RequestHostedWorkerQueues(ctx, data.WorkerQueues)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, &[]platform.WorkerQueueRequest{{
+			AstroMachine:      platform.WorkerQueueRequestAstroMachineA5,
+			IsDefault:         true,
+			MaxWorkerCount:    10,
+			Name:              "default",
+			WorkerConcurrency: 5,
+		}}, workerQueues)
+		workerQueue := data.WorkerQueues.Elements()[0].(types.Object).Attributes()
+		assert.True(t, workerQueue["node_pool_id"].IsNull())
+		assert.True(t, workerQueue["pod_cpu"].IsNull())
+
+		envVars, diags := resources.RequestDeploymentEnvironmentVariables(ctx, &models.DeploymentResource{
+			EnvironmentVariables:       types.SetNull(types.ObjectType{AttrTypes: schemas.DeploymentEnvironmentVariableAttributeTypes()}),
+			EnvironmentVariablesMap:    data.EnvironmentVariablesMap,
+			SecretEnvironmentVariables: types.MapNull(types.StringType),
+		})
+		assert.False(t, diags.HasError())
+		assert.Equal(t, []platform.DeploymentEnvironmentVariableRequest{{Key: "KEY", Value: lo.ToPtr("value")}}, envVars)
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("SECRET")}), data.SecretEnvironmentVariableKeys)
+
+		// the hibernation override is not copied
+		scalingSpec, diags := resources.RequestScalingSpec(ctx, data.ScalingSpec)
+		assert.False(t, diags.HasError())
+		assert.Nil(t, scalingSpec.HibernationSpec.Override)
+		assert.Equal(t, source.ScalingSpec.HibernationSpec.Schedules, scalingSpec.HibernationSpec.Schedules)
+	})
+
+	t.Run("copies the configuration of hybrid deployments", func(t *testing.T) {
+		hybrid := *source
+		hybrid.Type = lo.ToPtr(platform.DeploymentTypeHYBRID)
+		hybrid.ClusterId = lo.ToPtr("cluster")
+		hybrid.SchedulerAu = lo.ToPtr(5)
+		hybrid.SchedulerSize = nil
+		hybrid.ScalingSpec = nil
+
+		var data models.DeploymentTemplateDataSource
+		diags := data.ReadFromResponse(ctx, &hybrid)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "cluster", data.ClusterId.ValueString())
+		assert.True(t, data.Region.IsNull())
+		assert.Equal(t, int64(5), data.SchedulerAu.ValueInt64())
+		assert.Equal(t, int64(1), data.SchedulerReplicas.ValueInt64())
+		assert.True(t, data.IsDevelopmentMode.IsNull())
+		assert.True(t, data.ScalingSpec.IsNull())
+
+		workerQueues, diags := resources.RequestHybridWorkerQueues(ctx, data.WorkerQueues)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "node-pool", (*workerQueues)[0].NodePoolId)
+		workerQueue := data.WorkerQueues.Elements()[0].(types.Object).Attributes()
+		assert.True(t, workerQueue["astro_machine"].IsNull())
+	})
+}
@@ -4,7 +4,27 @@ import "github.com/hashicorp/terraform-plugin-framework/types"
 
 // AstroProviderModel describes the provider data model.
 type AstroProviderModel struct {
-	Token          types.String `tfsdk:"token"`
-	OrganizationId types.String `tfsdk:"organization_id"`
-	Host           types.String `tfsdk:"host"`
+	Token                 types.String `tfsdk:"token"`
+	TokenFile             types.String `tfsdk:"token_file"`
+	Exec                  types.Object `tfsdk:"exec"`
+	AstroCliConfig        types.Object `tfsdk:"astro_cli_config"`
+	OrganizationId        types.String `tfsdk:"organization_id"`
+	Host                  types.String `tfsdk:"host"`
+	TracingEndpoint       types.String `tfsdk:"tracing_endpoint"`
+	ReadOnly              types.Bool   `tfsdk:"read_only"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Int64  `tfsdk:"requests_per_second"`
+}
+
+// ProviderExec describes the exec credential helper of the provider
+type ProviderExec struct {
+	Command types.String `tfsdk:"command"`
+	Args    types.List   `tfsdk:"args"`
+	Env     types.Map    `tfsdk:"env"`
+}
+
+// ProviderAstroCliConfig describes the Astro CLI config file the provider reads its credentials from
+type ProviderAstroCliConfig struct {
+	Path    types.String `tfsdk:"path"`
+	Context types.String `tfsdk:"context"`
 }
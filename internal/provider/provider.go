@@ -2,14 +2,18 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
 
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
 	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/datasources"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -30,6 +34,9 @@ type AstroProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+	// httpClient replaces the default HTTP client of the API clients when set, for example with a cassette recorder
+	// in tests.
+	httpClient clients.HttpRequestDoer
 }
 
 func (p *AstroProvider) Metadata(
@@ -69,16 +76,18 @@ func (p *AstroProvider) Configure(
 		return
 	}
 
-	// Will use Token provided in the configuration, or fallback to the ASTRO_API_TOKEN env var
-	if data.Token.IsNull() {
-		data.Token = types.StringValue(os.Getenv("ASTRO_API_TOKEN"))
+	// Will use the token sources of the configuration, or fallback to the ASTRO_API_TOKEN env var
+	token, diags := tokenSource(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if len(data.Token.ValueString()) == 0 {
+	if len(data.OrganizationId.ValueString()) == 0 {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("token"),
-			"Missing Astro API Token",
-			"Astro API Token must be set in the configuration or the 'ASTRO_API_TOKEN' environment variable",
+			path.Root("organization_id"),
+			"Missing Astro Organization ID",
+			"Astro Organization ID must be set in the configuration or read from the context of 'astro_cli_config'",
 		)
 		return
 	}
@@ -87,10 +96,44 @@ func (p *AstroProvider) Configure(
 		data.Host = types.StringValue("https://api.astronomer.io")
 	}
 
+	if err := tracing.Configure(ctx, data.TracingEndpoint.ValueString(), p.version); err != nil {
+		tflog.Error(ctx, "failed to configure tracing", map[string]any{"error": err})
+		resp.Diagnostics.AddError("Failed to configure tracing", err.Error())
+		return
+	}
+
+	var platformOptions []platform.ClientOption
+	var iamOptions []iam.ClientOption
+	httpClient := p.httpClient
+	if !data.MaxConcurrentRequests.IsNull() || !data.RequestsPerSecond.IsNull() {
+		// both clients share the limiter so the limits apply to all the requests of the provider
+		limiter := clients.NewRequestLimiter(data.MaxConcurrentRequests.ValueInt64(), float64(data.RequestsPerSecond.ValueInt64()))
+		httpClient = clients.NewLimitedHttpClient(limiter, httpClient)
+	}
+	// Will use read_only provided in the configuration, or fallback to the ASTRO_READ_ONLY env var
+	if data.ReadOnly.IsNull() {
+		readOnly, err := parseBoolEnvVar("ASTRO_READ_ONLY")
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("read_only"), "Invalid ASTRO_READ_ONLY env var", err.Error())
+			return
+		}
+		data.ReadOnly = types.BoolValue(readOnly)
+	}
+	if data.ReadOnly.ValueBool() {
+		// rejected requests are not queued by the limiter
+		tflog.Info(ctx, "provider is in read-only mode")
+		httpClient = clients.NewReadOnlyHttpClient(httpClient)
+	}
+	if httpClient != nil {
+		platformOptions = append(platformOptions, platform.WithHTTPClient(httpClient))
+		iamOptions = append(iamOptions, iam.WithHTTPClient(httpClient))
+	}
+
 	platformClient, err := platform.NewPlatformClient(
 		data.Host.ValueString(),
-		data.Token.ValueString(),
+		token,
 		p.version,
+		platformOptions...,
 	)
 	if err != nil {
 		tflog.Error(ctx, "failed to create platform client", map[string]any{"error": err})
@@ -100,17 +143,31 @@ func (p *AstroProvider) Configure(
 		)
 		return
 	}
-	iamClient, err := iam.NewIamClient(data.Host.ValueString(), data.Token.ValueString(), p.version)
+	iamClient, err := iam.NewIamClient(data.Host.ValueString(), token, p.version, iamOptions...)
 	if err != nil {
 		tflog.Error(ctx, "failed to create iam client", map[string]any{"error": err})
 		resp.Diagnostics.AddError("Failed to create iam client", "failed to create IAM API client")
 		return
 	}
 
+	apiToken, err := token.Token(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get Astro API Token", err.Error())
+		return
+	}
+	identity, diags := callerIdentity(ctx, iamClient, data.OrganizationId.ValueString(), apiToken)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	apiClientsModel := models.ApiClientsModel{
 		OrganizationId: data.OrganizationId.ValueString(),
 		PlatformClient: platformClient,
 		IamClient:      iamClient,
+		Cache:          clients.NewResponseCache(),
+		CallerIdentity: identity,
+		ReadOnly:       data.ReadOnly.ValueBool(),
 	}
 
 	// Example client configuration for data sources and resources
@@ -138,6 +195,7 @@ func (p *AstroProvider) DataSources(ctx context.Context) []func() datasource.Dat
 		datasources.NewWorkspacesDataSource,
 		datasources.NewDeploymentDataSource,
 		datasources.NewDeploymentsDataSource,
+		datasources.NewDeploymentTemplateDataSource,
 		datasources.NewOrganizationDataSource,
 		datasources.NewClusterDataSource,
 		datasources.NewClustersDataSource,
@@ -149,6 +207,7 @@ func (p *AstroProvider) DataSources(ctx context.Context) []func() datasource.Dat
 		datasources.NewUsersDataSource,
 		datasources.NewApiTokenDataSource,
 		datasources.NewApiTokensDataSource,
+		datasources.NewCallerIdentityDataSource,
 	}
 }
 
@@ -163,3 +222,26 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// NewWithHttpClient creates a provider whose API clients send their requests with httpClient
+func NewWithHttpClient(version string, httpClient clients.HttpRequestDoer) func() provider.Provider {
+	return func() provider.Provider {
+		return &AstroProvider{
+			version:    version,
+			httpClient: httpClient,
+		}
+	}
+}
+
+// parseBoolEnvVar returns the value of a boolean env var, false if it is not set
+func parseBoolEnvVar(name string) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%v must be true or false, got %q", name, value)
+	}
+	return parsed, nil
+}
@@ -3,44 +3,254 @@ package provider_test
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/fakeapi"
 	astronomerprovider "github.com/astronomer/terraform-provider-astro/internal/provider"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/lucsky/cuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestUnit_Provider(t *testing.T) {
-	t.Run("errors if missing token", func(t *testing.T) {
-		ctx := context.Background()
+	ctx := context.Background()
+	organizationId := cuid.New()
+	configure := func(values map[string]tftypes.Value) provider.ConfigureResponse {
 		p := astronomerprovider.New("test")()
 		resp := provider.ConfigureResponse{}
-		req := provider.ConfigureRequest{
-			Config: tfsdk.Config{
-				Raw: tftypes.NewValue(tftypes.Object{
-					AttributeTypes: map[string]tftypes.Type{
-						"token":           tftypes.String,
-						"organization_id": tftypes.String,
-						"host":            tftypes.String,
-					},
-				}, map[string]tftypes.Value{
-					"organization_id": tftypes.NewValue(tftypes.String, cuid.New()),
-					"host":            tftypes.NewValue(tftypes.String, "https://api.astronomer.io"),
-					"token":           tftypes.NewValue(tftypes.String, ""),
+		p.Configure(ctx, provider.ConfigureRequest{Config: providerConfig(values)}, &resp)
+		return resp
+	}
+
+	t.Run("errors if missing token", func(t *testing.T) {
+		resp := configure(map[string]tftypes.Value{
+			"organization_id": tftypes.NewValue(tftypes.String, organizationId),
+			"host":            tftypes.NewValue(tftypes.String, "https://api.astronomer.io"),
+			"token":           tftypes.NewValue(tftypes.String, ""),
+		})
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Missing Astro API Token")
+	})
+
+	t.Run("reads the token of token_file", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		assert.NoError(t, os.WriteFile(tokenFile, []byte("file-token\n"), 0o600))
+		resp := configure(map[string]tftypes.Value{
+			"organization_id": tftypes.NewValue(tftypes.String, organizationId),
+			"token_file":      tftypes.NewValue(tftypes.String, tokenFile),
+		})
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		resp = configure(map[string]tftypes.Value{
+			"organization_id": tftypes.NewValue(tftypes.String, organizationId),
+			"token_file":      tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing")),
+		})
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Failed to get Astro API Token")
+	})
+
+	t.Run("runs the exec credential helper", func(t *testing.T) {
+		execConfig := func(script string) tftypes.Value {
+			return tftypes.NewValue(execType, map[string]tftypes.Value{
+				"command": tftypes.NewValue(tftypes.String, "sh"),
+				"args": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "-c"),
+					tftypes.NewValue(tftypes.String, script),
 				}),
-				Schema: astronomerprovider.ProviderSchema(),
-			},
+				"env": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"VAULT_TOKEN": tftypes.NewValue(tftypes.String, "exec-token"),
+				}),
+			})
 		}
-		p.Configure(ctx, req, &resp)
+		resp := configure(map[string]tftypes.Value{
+			"organization_id": tftypes.NewValue(tftypes.String, organizationId),
+			"exec":            execConfig(`echo "{\"token\":\"$VAULT_TOKEN\"}"`),
+		})
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		resp = configure(map[string]tftypes.Value{
+			"organization_id": tftypes.NewValue(tftypes.String, organizationId),
+			"exec":            execConfig(`echo "vault is sealed" >&2; exit 1`),
+		})
 		assert.True(t, resp.Diagnostics.HasError())
-		assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Missing Astro API Token")
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "vault is sealed")
 	})
+
+	t.Run("reads the organization and token of astro_cli_config", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		assert.NoError(t, os.WriteFile(configPath, []byte(fmt.Sprintf(`
+context: astronomer_io
+contexts:
+  astronomer_io:
+    domain: astronomer.io
+    organization: %v
+    token: Bearer cli-token
+`, organizationId)), 0o600))
+		cliConfig := func(context string) tftypes.Value {
+			return tftypes.NewValue(astroCliConfigType, map[string]tftypes.Value{
+				"path":    tftypes.NewValue(tftypes.String, configPath),
+				"context": tftypes.NewValue(tftypes.String, context),
+			})
+		}
+		t.Setenv("ASTRO_API_TOKEN", "")
+		resp := configure(map[string]tftypes.Value{
+			"astro_cli_config": cliConfig("astronomer.io"),
+		})
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		resp = configure(map[string]tftypes.Value{
+			"astro_cli_config": cliConfig("astronomer-dev.io"),
+		})
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Failed to read Astro CLI config")
+	})
+
+	t.Run("validates the API token of the organization", func(t *testing.T) {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		defer server.Close()
+		fixtures := server.Seed()
+		_, iamClient := newFakeApiClients(t, server)
+		workspaceToken, err := iamClient.CreateApiTokenWithResponse(ctx, fixtures.HostedOrganizationId, iam.CreateApiTokenRequest{
+			EntityId: lo.ToPtr(fixtures.HostedWorkspaceId),
+			Name:     "workspace token",
+			Role:     string(iam.WORKSPACEOWNER),
+			Type:     iam.WORKSPACE,
+		})
+		assert.NoError(t, err)
+		configureWithToken := func(token, organizationId string) provider.ConfigureResponse {
+			return configure(map[string]tftypes.Value{
+				"organization_id": tftypes.NewValue(tftypes.String, organizationId),
+				"host":            tftypes.NewValue(tftypes.String, server.URL()),
+				"token":           tftypes.NewValue(tftypes.String, token),
+			})
+		}
+
+		resp := configureWithToken(fakeapi.HostedOrganizationToken, fixtures.HostedOrganizationId)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		identity := resp.ResourceData.(models.ApiClientsModel).CallerIdentity
+		assert.Equal(t, fakeapi.HostedOrganizationApiTokenId, identity.ApiToken.Id)
+		assert.Empty(t, identity.CheckScope("astro_team", iam.ApiTokenTypeORGANIZATION))
+
+		resp = configureWithToken(*workspaceToken.JSON200.Token, fixtures.HostedOrganizationId)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		identity = resp.ResourceData.(models.ApiClientsModel).CallerIdentity
+		assert.Equal(t, iam.ApiTokenTypeWORKSPACE, identity.ApiToken.Type)
+		assert.Empty(t, identity.CheckScope("astro_deployment", iam.ApiTokenTypeWORKSPACE))
+		diags := identity.CheckScope("astro_team", iam.ApiTokenTypeORGANIZATION)
+		assert.Len(t, diags.Warnings(), 1)
+		assert.Contains(t, diags.Warnings()[0].Summary(), "astro_team")
+		assert.Empty(t, identity.CheckScope("astro_team", iam.ApiTokenTypeORGANIZATION), "warns once per resource type")
+
+		resp = configureWithToken(*workspaceToken.JSON200.Token, fixtures.HybridOrganizationId)
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Astro API Token does not belong to the organization")
+		// without permissions claims the organization of the token is only known if the token can read itself
+		resp = configureWithToken(fakeapi.HostedOrganizationToken, fixtures.HybridOrganizationId)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.Contains(t, resp.Diagnostics.Warnings()[0].Summary(), "Unable to validate Astro API Token")
+	})
+
+	t.Run("only sends GET requests in read-only mode", func(t *testing.T) {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		defer server.Close()
+		fixtures := server.Seed()
+		for _, readOnly := range []tftypes.Value{tftypes.NewValue(tftypes.Bool, true), tftypes.NewValue(tftypes.Bool, nil)} {
+			t.Setenv("ASTRO_READ_ONLY", "true")
+			resp := configure(map[string]tftypes.Value{
+				"organization_id": tftypes.NewValue(tftypes.String, fixtures.HostedOrganizationId),
+				"host":            tftypes.NewValue(tftypes.String, server.URL()),
+				"token":           tftypes.NewValue(tftypes.String, fakeapi.HostedOrganizationToken),
+				"read_only":       readOnly,
+			})
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.True(t, resp.ResourceData.(models.ApiClientsModel).ReadOnly)
+			platformClient := resp.ResourceData.(models.ApiClientsModel).PlatformClient
+
+			workspaces, err := platformClient.ListWorkspacesWithResponse(ctx, fixtures.HostedOrganizationId, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, workspaces.StatusCode())
+			_, err = platformClient.CreateWorkspaceWithResponse(ctx, fixtures.HostedOrganizationId, platform.CreateWorkspaceRequest{Name: "workspace"})
+			assert.ErrorIs(t, err, clients.ErrReadOnly)
+		}
+
+		t.Setenv("ASTRO_READ_ONLY", "yes")
+		resp := configure(map[string]tftypes.Value{
+			"organization_id": tftypes.NewValue(tftypes.String, fixtures.HostedOrganizationId),
+			"token":           tftypes.NewValue(tftypes.String, fakeapi.HostedOrganizationToken),
+		})
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Invalid ASTRO_READ_ONLY env var")
+	})
+
+	t.Run("errors if missing organization", func(t *testing.T) {
+		resp := configure(map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "token"),
+		})
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Missing Astro Organization ID")
+	})
+}
+
+var execType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"command": tftypes.String,
+		"args":    tftypes.List{ElementType: tftypes.String},
+		"env":     tftypes.Map{ElementType: tftypes.String},
+	},
+}
+
+var astroCliConfigType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"path":    tftypes.String,
+		"context": tftypes.String,
+	},
+}
+
+func newFakeApiClients(t *testing.T, server *fakeapi.Server) (*platform.ClientWithResponses, *iam.ClientWithResponses) {
+	platformClient, err := platform.NewPlatformClient(server.URL(), clients.StaticToken(fakeapi.HostedOrganizationToken), "test")
+	assert.NoError(t, err)
+	iamClient, err := iam.NewIamClient(server.URL(), clients.StaticToken(fakeapi.HostedOrganizationToken), "test")
+	assert.NoError(t, err)
+	return platformClient, iamClient
+}
+
+// providerConfig creates a provider config with values, the other attributes are null
+func providerConfig(values map[string]tftypes.Value) tfsdk.Config {
+	attributeTypes := map[string]tftypes.Type{
+		"token":                   tftypes.String,
+		"token_file":              tftypes.String,
+		"exec":                    execType,
+		"astro_cli_config":        astroCliConfigType,
+		"organization_id":         tftypes.String,
+		"host":                    tftypes.String,
+		"tracing_endpoint":        tftypes.String,
+		"read_only":               tftypes.Bool,
+		"max_concurrent_requests": tftypes.Number,
+		"requests_per_second":     tftypes.Number,
+	}
+	attributeValues := map[string]tftypes.Value{}
+	for name, attributeType := range attributeTypes {
+		attributeValues[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributeValues[name] = value
+		}
+	}
+	return tfsdk.Config{
+		Raw:    tftypes.NewValue(tftypes.Object{AttributeTypes: attributeTypes}, attributeValues),
+		Schema: astronomerprovider.ProviderSchema(),
+	}
 }
 
 func TestAcc_Provider_config(t *testing.T) {
@@ -56,7 +266,7 @@ func TestAcc_Provider_config(t *testing.T) {
 			},
 			{
 				Config:      missingOrganizationIdConfig(),
-				ExpectError: regexp.MustCompile(`.*The argument "organization_id" is required*`),
+				ExpectError: regexp.MustCompile(`.*Missing Astro Organization ID.*`),
 			},
 			{
 				Config:      organizationIdIsNotCuidConfig(),
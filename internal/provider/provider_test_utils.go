@@ -2,10 +2,16 @@ package provider
 
 import (
 	"fmt"
+	"math/rand"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/cassette"
+	"github.com/astronomer/terraform-provider-astro/internal/fakeapi"
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -15,7 +21,133 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var TestAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"astro": providerserver.NewProtocol6WithError(New("test")()),
+	"astro": func() (tfprotov6.ProviderServer, error) {
+		return providerserver.NewProtocol6WithError(NewWithHttpClient("test", testHttpClient)())()
+	},
+}
+
+// testHttpClient is the HTTP client of the providers created by TestAccProtoV6ProviderFactories, it is set to a
+// cassette recorder when ASTRO_CASSETTE_MODE is set
+var testHttpClient clients.HttpRequestDoer
+
+// FakeApiEnvVar is the environment variable that runs the acceptance tests against an in-memory fake of the Astro API
+// instead of a live organization, so they can run without credentials
+const FakeApiEnvVar = "ASTRO_FAKE_API"
+
+// CassetteModeEnvVar is the environment variable that records the requests of the acceptance tests of a package to
+// CassettePath when set to "record", and replays them without network access when set to "replay"
+const CassetteModeEnvVar = "ASTRO_CASSETTE_MODE"
+
+// CassettePath is the cassette file of a package, relative to the package directory
+var CassettePath = filepath.Join("testdata", "cassettes", "acceptance.json")
+
+// cassetteSeed seeds the random names of the test resources so a replay generates the names that were recorded
+const cassetteSeed = 1
+
+// cassetteVariables are the environment variables saved in cassettes, the API tokens are not saved since
+// replays do not need them
+var cassetteVariables = []string{
+	"ASTRO_API_HOST",
+	"HOSTED_ORGANIZATION_ID",
+	"HYBRID_ORGANIZATION_ID",
+	"HOSTED_SCIM_ORGANIZATION_ID",
+	"HYBRID_CLUSTER_ID",
+	"HYBRID_NODE_POOL_ID",
+	"HYBRID_DRY_RUN_CLUSTER_ID",
+	"HOSTED_TEAM_ID",
+	"HOSTED_USER_ID",
+	"HOSTED_DUMMY_USER_ID",
+	"HOSTED_WORKSPACE_ID",
+	"HOSTED_DEPLOYMENT_ID",
+	"HOSTED_STANDARD_DEPLOYMENT_ID",
+	"HOSTED_API_TOKEN_ID",
+}
+
+var cassetteTokenVariables = []string{
+	"HOSTED_ORGANIZATION_API_TOKEN",
+	"HYBRID_ORGANIZATION_API_TOKEN",
+	"HOSTED_SCIM_ORGANIZATION_API_TOKEN",
+}
+
+// RunAcceptanceTests runs the tests of a package from its TestMain.
+// If ASTRO_FAKE_API is set, a fake Astro API is started and seeded, and the environment variables
+// checked by TestAccPreCheck are set to point the tests at it.
+// If ASTRO_CASSETTE_MODE is set, the requests of the tests are recorded to or replayed from CassettePath.
+func RunAcceptanceTests(m *testing.M) int {
+	if os.Getenv(FakeApiEnvVar) != "" {
+		server := fakeapi.NewServer(fakeapi.Options{})
+		defer server.Close()
+		if err := setEnvVars(fakeApiEnvVars(server.URL(), server.Seed())); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	mode := os.Getenv(CassetteModeEnvVar)
+	if mode == "" {
+		return m.Run()
+	}
+	recorder, err := cassette.NewRecorder(CassettePath, cassette.Mode(mode))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if mode == string(cassette.ModeReplay) {
+		envVars := recorder.Variables()
+		for _, envVar := range cassetteTokenVariables {
+			envVars[envVar] = clients.RedactedValue
+		}
+		if err = setEnvVars(envVars); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	} else {
+		for _, envVar := range cassetteVariables {
+			recorder.SetVariable(envVar, os.Getenv(envVar))
+		}
+	}
+	// the acceptance tests generate their resource names with the global source of math/rand
+	rand.Seed(cassetteSeed)
+	testHttpClient = recorder
+	utils.SetTestHttpClient(recorder)
+
+	code := m.Run()
+	if err = recorder.Save(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return code
+}
+
+func fakeApiEnvVars(host string, fixtures fakeapi.Fixtures) map[string]string {
+	return map[string]string{
+		"ASTRO_API_HOST":                     host,
+		"HOSTED_ORGANIZATION_ID":             fixtures.HostedOrganizationId,
+		"HOSTED_ORGANIZATION_API_TOKEN":      fakeapi.HostedOrganizationToken,
+		"HYBRID_ORGANIZATION_ID":             fixtures.HybridOrganizationId,
+		"HYBRID_ORGANIZATION_API_TOKEN":      fakeapi.HybridOrganizationToken,
+		"HOSTED_SCIM_ORGANIZATION_ID":        fixtures.HostedScimOrganizationId,
+		"HOSTED_SCIM_ORGANIZATION_API_TOKEN": fakeapi.HostedScimOrganizationToken,
+		"HYBRID_CLUSTER_ID":                  fixtures.HybridClusterId,
+		"HYBRID_NODE_POOL_ID":                fixtures.HybridNodePoolId,
+		"HYBRID_DRY_RUN_CLUSTER_ID":          fixtures.HybridDryRunClusterId,
+		"HOSTED_TEAM_ID":                     fixtures.HostedTeamId,
+		"HOSTED_USER_ID":                     fixtures.HostedUserId,
+		"HOSTED_DUMMY_USER_ID":               fixtures.HostedDummyUserId,
+		"HOSTED_WORKSPACE_ID":                fixtures.HostedWorkspaceId,
+		"HOSTED_DEPLOYMENT_ID":               fixtures.HostedDeploymentId,
+		"HOSTED_STANDARD_DEPLOYMENT_ID":      fixtures.HostedStandardDeploymentId,
+		"HOSTED_API_TOKEN_ID":                fixtures.HostedApiTokenId,
+	}
+}
+
+func setEnvVars(envVars map[string]string) error {
+	for envVar, value := range envVars {
+		if err := os.Setenv(envVar, value); err != nil {
+			return fmt.Errorf("failed to set %v: %w", envVar, err)
+		}
+	}
+	return nil
 }
 
 func TestAccPreCheck(t *testing.T) {
@@ -3,12 +3,20 @@ package resources
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/astronomer/terraform-provider-astro/internal/clients"
 	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/samber/lo"
 )
 
 // ClusterResourceRefreshFunc returns a retry.StateRefreshFunc that polls the platform API for the cluster status
@@ -17,7 +25,7 @@ import (
 // If there is an error, it returns the error
 // WaitForStateContext will keep polling until the target status is reached, the timeout is reached or an err is returned
 func ClusterResourceRefreshFunc(ctx context.Context, platformClient *platform.ClientWithResponses, organizationId string, clusterId string) retry.StateRefreshFunc {
-	return func() (any, string, error) {
+	return tracing.TraceRefresh(ctx, "astro_cluster.Poll", clusterId, func(ctx context.Context) (any, string, error) {
 		cluster, err := platformClient.GetClusterWithResponse(ctx, organizationId, clusterId)
 		if err != nil {
 			tflog.Error(ctx, "failed to get cluster while polling for cluster 'CREATED' status", map[string]interface{}{"error": err})
@@ -45,5 +53,108 @@ func ClusterResourceRefreshFunc(ctx context.Context, platformClient *platform.Cl
 			}
 		}
 		return nil, "", fmt.Errorf("error getting cluster %s", clusterId)
+	})
+}
+
+// privateNetworks are the private IPv4 address ranges of RFC 1918, the subnet ranges of clusters must be private
+var privateNetworks = lo.Map([]string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}, func(cidr string, _ int) *net.IPNet {
+	_, network, _ := net.ParseCIDR(cidr)
+	return network
+})
+
+// ValidateClusterSubnetRanges checks that the subnet ranges of a cluster are private IPv4 CIDR ranges that do not
+// overlap, unknown and null ranges are not checked
+func ValidateClusterSubnetRanges(data *models.ClusterResource) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	type subnetRange struct {
+		attribute string
+		network   *net.IPNet
+	}
+	var subnetRanges []subnetRange
+	for attribute, value := range map[string]types.String{
+		"vpc_subnet_range":      data.VpcSubnetRange,
+		"pod_subnet_range":      data.PodSubnetRange,
+		"service_subnet_range":  data.ServiceSubnetRange,
+		"service_peering_range": data.ServicePeeringRange,
+	} {
+		if !isKnown(value) {
+			continue
+		}
+		ip, network, err := net.ParseCIDR(value.ValueString())
+		if err != nil || ip.To4() == nil {
+			diags.AddAttributeError(
+				path.Root(attribute),
+				fmt.Sprintf("%v is not a valid IPv4 CIDR range", attribute),
+				fmt.Sprintf("Please provide a range such as 172.20.0.0/19, got %v", value.ValueString()),
+			)
+			continue
+		}
+		if !ip.Equal(network.IP) {
+			diags.AddAttributeError(
+				path.Root(attribute),
+				fmt.Sprintf("%v has host bits set", attribute),
+				fmt.Sprintf("Please provide the network address of the range, %v instead of %v", network, value.ValueString()),
+			)
+			continue
+		}
+		if !lo.SomeBy(privateNetworks, func(privateNetwork *net.IPNet) bool { return networkContains(privateNetwork, network) }) {
+			diags.AddAttributeError(
+				path.Root(attribute),
+				fmt.Sprintf("%v is not a private range", attribute),
+				fmt.Sprintf("Please provide a range within the private ranges of RFC 1918 (10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16), got %v", value.ValueString()),
+			)
+			continue
+		}
+		subnetRanges = append(subnetRanges, subnetRange{attribute: attribute, network: network})
 	}
+
+	// report each overlap once, on the attributes in a stable order
+	for i, first := range subnetRanges {
+		for _, second := range subnetRanges[i+1:] {
+			if !first.network.Contains(second.network.IP) && !second.network.Contains(first.network.IP) {
+				continue
+			}
+			attributes := []string{first.attribute, second.attribute}
+			if attributes[0] > attributes[1] {
+				attributes[0], attributes[1] = attributes[1], attributes[0]
+			}
+			diags.AddAttributeError(
+				path.Root(attributes[1]),
+				fmt.Sprintf("%v overlaps %v", attributes[1], attributes[0]),
+				fmt.Sprintf("Please provide subnet ranges that do not overlap, %v and %v overlap", first.network, second.network),
+			)
+		}
+	}
+	return diags
+}
+
+// ValidateClusterOptions checks the region of a cluster against the cluster options of its cloud provider, unknown
+// regions are not checked
+func ValidateClusterOptions(data *models.ClusterResource, clusterOptions []platform.ClusterOptions) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	cloudProvider := data.CloudProvider.ValueString()
+	options, ok := lo.Find(clusterOptions, func(options platform.ClusterOptions) bool {
+		return string(options.Provider) == cloudProvider
+	})
+	if !ok || !isKnown(data.Region) || len(options.Regions) == 0 {
+		return diags
+	}
+	regions := lo.Map(options.Regions, func(region platform.ProviderRegion, _ int) string {
+		return region.Name
+	})
+	if !lo.Contains(regions, data.Region.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("region"),
+			fmt.Sprintf("region is not available for '%v' cluster", cloudProvider),
+			fmt.Sprintf("Please provide one of %v, got %v", strings.Join(regions, ", "), data.Region.ValueString()),
+		)
+	}
+	return diags
+}
+
+// networkContains returns whether the network inner is within the network outer
+func networkContains(outer, inner *net.IPNet) bool {
+	outerSize, _ := outer.Mask.Size()
+	innerSize, _ := inner.Mask.Size()
+	return outerSize <= innerSize && outer.Contains(inner.IP)
 }
@@ -0,0 +1,85 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/samber/lo"
+)
+
+// PartitionDeploymentsByName splits deployments into the ones whose name matches namePattern and the others, every
+// deployment matches a nil pattern
+func PartitionDeploymentsByName(deployments []platform.Deployment, namePattern *regexp.Regexp) ([]platform.Deployment, []platform.Deployment) {
+	var matching, others []platform.Deployment
+	for _, deployment := range deployments {
+		if namePattern == nil || namePattern.MatchString(deployment.Name) {
+			matching = append(matching, deployment)
+		} else {
+			others = append(others, deployment)
+		}
+	}
+	return matching, others
+}
+
+// ClusterDeploymentsError returns the error of deleting a cluster that still has deployments, with the inventory of the
+// deployments that block its deletion. namePattern is the force_destroy_deployment_name_pattern the deployments do not
+// match, it is empty when force_destroy is false.
+func ClusterDeploymentsError(clusterId string, deployments []platform.Deployment, namePattern string) diag.Diagnostic {
+	inventory := lo.Map(deployments, func(deployment platform.Deployment, _ int) string {
+		return fmt.Sprintf("  - deployment %v (%v) in workspace %v", deployment.Name, deployment.Id, deployment.WorkspaceId)
+	})
+	if namePattern != "" {
+		return diag.NewAttributeErrorDiagnostic(
+			path.Root("force_destroy_deployment_name_pattern"),
+			"Cluster has deployments that are not force destroyed",
+			fmt.Sprintf(
+				"Cluster %v cannot be deleted while it has deployments, and these deployments do not match the force_destroy_deployment_name_pattern %v:\n%v\n\nPlease delete them first, or change the force_destroy_deployment_name_pattern and apply the change before destroying the cluster.",
+				clusterId, namePattern, strings.Join(inventory, "\n"),
+			),
+		)
+	}
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root("force_destroy"),
+		"Cluster is not empty",
+		fmt.Sprintf(
+			"Cluster %v cannot be deleted while it has deployments, it has:\n%v\n\nPlease delete them first, or set force_destroy to true and apply the change before destroying the cluster to delete them with it.",
+			clusterId, strings.Join(inventory, "\n"),
+		),
+	)
+}
+
+// listClusterDeployments returns the deployments of a cluster, the API does not filter deployments by cluster
+func (r *ClusterResource) listClusterDeployments(ctx context.Context, clusterId string) ([]platform.Deployment, error) {
+	params := &platform.ListDeploymentsParams{
+		Limit: lo.ToPtr(1000),
+	}
+	var deployments []platform.Deployment
+	offset := 0
+	for {
+		params.Offset = &offset
+		deploymentsResp, err := r.platformClient.ListDeploymentsWithResponse(ctx, r.organizationId, params)
+		if err != nil {
+			return nil, err
+		}
+		_, diagnostic := clients.NormalizeAPIError(ctx, deploymentsResp.HTTPResponse, deploymentsResp.Body)
+		if diagnostic != nil {
+			return nil, fmt.Errorf("%s", diagnostic.Detail())
+		}
+		if deploymentsResp.JSON200 == nil {
+			return nil, fmt.Errorf("nil response")
+		}
+		deployments = append(deployments, lo.Filter(deploymentsResp.JSON200.Deployments, func(deployment platform.Deployment, _ int) bool {
+			return lo.FromPtr(deployment.ClusterId) == clusterId
+		})...)
+		offset += 1000
+		if deploymentsResp.JSON200.TotalCount <= offset {
+			return deployments, nil
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package resources_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_ClusterDeployments(t *testing.T) {
+	deployments := []platform.Deployment{
+		{Id: "deployment1", Name: "ci-123-ephemeral", WorkspaceId: "workspace"},
+		{Id: "deployment2", Name: "production", WorkspaceId: "workspace"},
+	}
+	names := func(deployments []platform.Deployment) []string {
+		return lo.Map(deployments, func(deployment platform.Deployment, _ int) string { return deployment.Name })
+	}
+
+	t.Run("force destroys every deployment without a name pattern", func(t *testing.T) {
+		matching, others := resources.PartitionDeploymentsByName(deployments, nil)
+		assert.Equal(t, []string{"ci-123-ephemeral", "production"}, names(matching))
+		assert.Empty(t, others)
+	})
+
+	t.Run("force destroys the deployments matching the name pattern", func(t *testing.T) {
+		matching, others := resources.PartitionDeploymentsByName(deployments, regexp.MustCompile("-ephemeral$"))
+		assert.Equal(t, []string{"ci-123-ephemeral"}, names(matching))
+		assert.Equal(t, []string{"production"}, names(others))
+	})
+
+	t.Run("lists the deployments that block the deletion of the cluster", func(t *testing.T) {
+		diagnostic := resources.ClusterDeploymentsError("cluster", deployments, "")
+		assert.Equal(t, "Cluster is not empty", diagnostic.Summary())
+		assert.Contains(t, diagnostic.Detail(), "  - deployment ci-123-ephemeral (deployment1) in workspace workspace\n  - deployment production (deployment2) in workspace workspace\n")
+		assert.Contains(t, diagnostic.Detail(), "set force_destroy to true")
+
+		diagnostic = resources.ClusterDeploymentsError("cluster", deployments[1:], "-ephemeral$")
+		assert.Equal(t, "Cluster has deployments that are not force destroyed", diagnostic.Summary())
+		assert.Contains(t, diagnostic.Detail(), "do not match the force_destroy_deployment_name_pattern -ephemeral$")
+		assert.Contains(t, diagnostic.Detail(), "  - deployment production (deployment2) in workspace workspace\n")
+	})
+}
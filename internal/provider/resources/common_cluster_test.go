@@ -0,0 +1,83 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_ValidateCluster(t *testing.T) {
+	summaries := func(diags diag.Diagnostics) []string {
+		return lo.Map(diags.Errors(), func(diagnostic diag.Diagnostic, _ int) string {
+			return diagnostic.Summary()
+		})
+	}
+	gcpCluster := func() *models.ClusterResource {
+		return &models.ClusterResource{
+			CloudProvider:       types.StringValue(string(platform.ClusterCloudProviderGCP)),
+			Region:              types.StringValue("us-central1"),
+			VpcSubnetRange:      types.StringValue("172.20.0.0/19"),
+			PodSubnetRange:      types.StringValue("172.21.0.0/19"),
+			ServiceSubnetRange:  types.StringValue("172.22.0.0/22"),
+			ServicePeeringRange: types.StringValue("172.23.0.0/20"),
+		}
+	}
+
+	t.Run("accepts private subnet ranges that do not overlap", func(t *testing.T) {
+		assert.Empty(t, summaries(resources.ValidateClusterSubnetRanges(gcpCluster())))
+
+		data := gcpCluster()
+		data.VpcSubnetRange = types.StringValue("10.0.0.0/16")
+		data.PodSubnetRange = types.StringUnknown()
+		data.ServiceSubnetRange = types.StringNull()
+		data.ServicePeeringRange = types.StringValue("192.168.0.0/24")
+		assert.Empty(t, summaries(resources.ValidateClusterSubnetRanges(data)))
+	})
+
+	t.Run("rejects invalid subnet ranges", func(t *testing.T) {
+		data := gcpCluster()
+		data.VpcSubnetRange = types.StringValue("172.20.0.0")
+		data.PodSubnetRange = types.StringValue("172.21.0.1/19")
+		data.ServiceSubnetRange = types.StringValue("8.8.8.0/24")
+		data.ServicePeeringRange = types.StringValue("fd00::/8")
+		assert.ElementsMatch(t, []string{
+			"vpc_subnet_range is not a valid IPv4 CIDR range",
+			"pod_subnet_range has host bits set",
+			"service_subnet_range is not a private range",
+			"service_peering_range is not a valid IPv4 CIDR range",
+		}, summaries(resources.ValidateClusterSubnetRanges(data)))
+
+		data = gcpCluster()
+		data.VpcSubnetRange = types.StringValue("172.16.0.0/12")
+		data.ServicePeeringRange = types.StringValue("172.21.16.0/20")
+		assert.ElementsMatch(t, []string{
+			"vpc_subnet_range overlaps pod_subnet_range",
+			"vpc_subnet_range overlaps service_subnet_range",
+			"vpc_subnet_range overlaps service_peering_range",
+			"service_peering_range overlaps pod_subnet_range",
+		}, summaries(resources.ValidateClusterSubnetRanges(data)))
+	})
+
+	t.Run("checks the region against the cluster options", func(t *testing.T) {
+		clusterOptions := []platform.ClusterOptions{
+			{Provider: platform.ClusterOptionsProviderAWS, Regions: []platform.ProviderRegion{{Name: "us-east-1"}}},
+			{Provider: platform.ClusterOptionsProviderGCP, Regions: []platform.ProviderRegion{{Name: "us-central1"}, {Name: "us-east4"}}},
+		}
+		assert.Empty(t, summaries(resources.ValidateClusterOptions(gcpCluster(), clusterOptions)))
+
+		data := gcpCluster()
+		data.Region = types.StringValue("us-east-1")
+		diags := resources.ValidateClusterOptions(data, clusterOptions)
+		assert.Equal(t, []string{"region is not available for 'GCP' cluster"}, summaries(diags))
+		assert.Contains(t, diags.Errors()[0].Detail(), "us-central1, us-east4")
+
+		data.Region = types.StringUnknown()
+		assert.Empty(t, summaries(resources.ValidateClusterOptions(data, clusterOptions)))
+	})
+}
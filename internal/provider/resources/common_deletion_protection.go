@@ -0,0 +1,339 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+)
+
+// DeletionProtectionError returns the error of deleting a resource whose deletion_protection is true, reason explains
+// why the resource would be deleted
+func DeletionProtectionError(resourceType, id, reason string) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root("deletion_protection"),
+		"Deletion protection is enabled",
+		fmt.Sprintf(
+			"%v %v would be deleted since %v, but its deletion_protection is true. Set deletion_protection to false and apply the change before deleting or replacing it.",
+			resourceType, id, reason,
+		),
+	)
+}
+
+// ModifyPlanDeletionProtection fails the plans destroying or replacing a resource whose deletion_protection is true.
+// The value of the state is checked, so that protection must be removed by an apply before the resource can be deleted.
+// It must be called once the resource has planned its own replacements in resp.RequiresReplace.
+func ModifyPlanDeletionProtection(
+	ctx context.Context,
+	resourceType string,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to protect when the resource is created
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var deletionProtection types.Bool
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() || !deletionProtection.ValueBool() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(DeletionProtectionError(resourceType, id.ValueString(), "it is destroyed"))
+		return
+	}
+	replacedAttributes, diags := plannedReplacements(ctx, req)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	replacedAttributes = append(replacedAttributes, resp.RequiresReplace...)
+	if len(replacedAttributes) > 0 {
+		reason := fmt.Sprintf("changing %v requires replacing it", joinPaths(replacedAttributes))
+		resp.Diagnostics.Append(DeletionProtectionError(resourceType, id.ValueString(), reason))
+	}
+}
+
+// plannedReplacements returns the attributes whose RequiresReplace plan modifiers replace the resource. Resource
+// ModifyPlan methods are called without the replacements of the attribute plan modifiers, so the plan modifiers of the
+// schema are run again against the plan.
+func plannedReplacements(ctx context.Context, req resource.ModifyPlanRequest) (path.Paths, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var replacedAttributes path.Paths
+	for name, attribute := range req.Plan.Schema.GetAttributes() {
+		resourceAttribute, ok := attribute.(schema.Attribute)
+		if !ok {
+			continue
+		}
+		attributeReplacements, attributeDiags := attributeReplacements(ctx, req, path.Root(name), resourceAttribute)
+		diags.Append(attributeDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		replacedAttributes = append(replacedAttributes, attributeReplacements...)
+	}
+	return replacedAttributes, diags
+}
+
+// attributeReplacements returns the attribute if one of its plan modifiers requires replacing the resource, and the
+// attributes of single nested attributes that do. The attributes nested in lists, sets and maps are not checked.
+func attributeReplacements(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	attributePath path.Path,
+	attribute schema.Attribute,
+) (path.Paths, diag.Diagnostics) {
+	var replace bool
+	var diags diag.Diagnostics
+	switch attribute := attribute.(type) {
+	case schema.StringAttribute:
+		replace, diags = requiresReplace(ctx, req, attributePath, attribute.PlanModifiers, func(modifier planmodifier.String, configValue, planValue, stateValue types.String) bool {
+			modifierResp := &planmodifier.StringResponse{PlanValue: planValue}
+			modifier.PlanModifyString(ctx, planmodifier.StringRequest{
+				Path:           attributePath,
+				PathExpression: attributePath.Expression(),
+				Config:         req.Config,
+				ConfigValue:    configValue,
+				Plan:           req.Plan,
+				PlanValue:      planValue,
+				State:          req.State,
+				StateValue:     stateValue,
+				Private:        req.Private,
+			}, modifierResp)
+			return modifierResp.RequiresReplace
+		})
+	case schema.BoolAttribute:
+		replace, diags = requiresReplace(ctx, req, attributePath, attribute.PlanModifiers, func(modifier planmodifier.Bool, configValue, planValue, stateValue types.Bool) bool {
+			modifierResp := &planmodifier.BoolResponse{PlanValue: planValue}
+			modifier.PlanModifyBool(ctx, planmodifier.BoolRequest{
+				Path:           attributePath,
+				PathExpression: attributePath.Expression(),
+				Config:         req.Config,
+				ConfigValue:    configValue,
+				Plan:           req.Plan,
+				PlanValue:      planValue,
+				State:          req.State,
+				StateValue:     stateValue,
+				Private:        req.Private,
+			}, modifierResp)
+			return modifierResp.RequiresReplace
+		})
+	case schema.Int64Attribute:
+		replace, diags = requiresReplace(ctx, req, attributePath, attribute.PlanModifiers, func(modifier planmodifier.Int64, configValue, planValue, stateValue types.Int64) bool {
+			modifierResp := &planmodifier.Int64Response{PlanValue: planValue}
+			modifier.PlanModifyInt64(ctx, planmodifier.Int64Request{
+				Path:           attributePath,
+				PathExpression: attributePath.Expression(),
+				Config:         req.Config,
+				ConfigValue:    configValue,
+				Plan:           req.Plan,
+				PlanValue:      planValue,
+				State:          req.State,
+				StateValue:     stateValue,
+				Private:        req.Private,
+			}, modifierResp)
+			return modifierResp.RequiresReplace
+		})
+	case schema.Float64Attribute:
+		replace, diags = requiresReplace(ctx, req, attributePath, attribute.PlanModifiers, func(modifier planmodifier.Float64, configValue, planValue, stateValue types.Float64) bool {
+			modifierResp := &planmodifier.Float64Response{PlanValue: planValue}
+			modifier.PlanModifyFloat64(ctx, planmodifier.Float64Request{
+				Path:           attributePath,
+				PathExpression: attributePath.Expression(),
+				Config:         req.Config,
+				ConfigValue:    configValue,
+				Plan:           req.Plan,
+				PlanValue:      planValue,
+				State:          req.State,
+				StateValue:     stateValue,
+				Private:        req.Private,
+			}, modifierResp)
+			return modifierResp.RequiresReplace
+		})
+	case schema.NumberAttribute:
+		replace, diags = requiresReplace(ctx, req, attributePath, attribute.PlanModifiers, func(modifier planmodifier.Number, configValue, planValue, stateValue types.Number) bool {
+			modifierResp := &planmodifier.NumberResponse{PlanValue: planValue}
+			modifier.PlanModifyNumber(ctx, planmodifier.NumberRequest{
+				Path:           attributePath,
+				PathExpression: attributePath.Expression(),
+				Config:         req.Config,
+				ConfigValue:    configValue,
+				Plan:           req.Plan,
+				PlanValue:      planValue,
+				State:          req.State,
+				StateValue:     stateValue,
+				Private:        req.Private,
+			}, modifierResp)
+			return modifierResp.RequiresReplace
+		})
+	case schema.ListAttribute:
+		replace, diags = requiresReplaceList(ctx, req, attributePath, attribute.PlanModifiers)
+	case schema.ListNestedAttribute:
+		replace, diags = requiresReplaceList(ctx, req, attributePath, attribute.PlanModifiers)
+	case schema.SetAttribute:
+		replace, diags = requiresReplaceSet(ctx, req, attributePath, attribute.PlanModifiers)
+	case schema.SetNestedAttribute:
+		replace, diags = requiresReplaceSet(ctx, req, attributePath, attribute.PlanModifiers)
+	case schema.MapAttribute:
+		replace, diags = requiresReplaceMap(ctx, req, attributePath, attribute.PlanModifiers)
+	case schema.MapNestedAttribute:
+		replace, diags = requiresReplaceMap(ctx, req, attributePath, attribute.PlanModifiers)
+	case schema.ObjectAttribute:
+		replace, diags = requiresReplaceObject(ctx, req, attributePath, attribute.PlanModifiers)
+	case schema.SingleNestedAttribute:
+		replace, diags = requiresReplaceObject(ctx, req, attributePath, attribute.PlanModifiers)
+		if diags.HasError() || replace {
+			break
+		}
+		var replacedAttributes path.Paths
+		for name, nestedAttribute := range attribute.Attributes {
+			nestedReplacements, nestedDiags := attributeReplacements(ctx, req, attributePath.AtName(name), nestedAttribute)
+			diags.Append(nestedDiags...)
+			if diags.HasError() {
+				return nil, diags
+			}
+			replacedAttributes = append(replacedAttributes, nestedReplacements...)
+		}
+		return replacedAttributes, diags
+	case schema.DynamicAttribute:
+		replace, diags = requiresReplace(ctx, req, attributePath, attribute.PlanModifiers, func(modifier planmodifier.Dynamic, configValue, planValue, stateValue types.Dynamic) bool {
+			modifierResp := &planmodifier.DynamicResponse{PlanValue: planValue}
+			modifier.PlanModifyDynamic(ctx, planmodifier.DynamicRequest{
+				Path:           attributePath,
+				PathExpression: attributePath.Expression(),
+				Config:         req.Config,
+				ConfigValue:    configValue,
+				Plan:           req.Plan,
+				PlanValue:      planValue,
+				State:          req.State,
+				StateValue:     stateValue,
+				Private:        req.Private,
+			}, modifierResp)
+			return modifierResp.RequiresReplace
+		})
+	}
+	if diags.HasError() || !replace {
+		return nil, diags
+	}
+	return path.Paths{attributePath}, diags
+}
+
+func requiresReplaceList(ctx context.Context, req resource.ModifyPlanRequest, attributePath path.Path, modifiers []planmodifier.List) (bool, diag.Diagnostics) {
+	return requiresReplace(ctx, req, attributePath, modifiers, func(modifier planmodifier.List, configValue, planValue, stateValue types.List) bool {
+		modifierResp := &planmodifier.ListResponse{PlanValue: planValue}
+		modifier.PlanModifyList(ctx, planmodifier.ListRequest{
+			Path:           attributePath,
+			PathExpression: attributePath.Expression(),
+			Config:         req.Config,
+			ConfigValue:    configValue,
+			Plan:           req.Plan,
+			PlanValue:      planValue,
+			State:          req.State,
+			StateValue:     stateValue,
+			Private:        req.Private,
+		}, modifierResp)
+		return modifierResp.RequiresReplace
+	})
+}
+
+func requiresReplaceSet(ctx context.Context, req resource.ModifyPlanRequest, attributePath path.Path, modifiers []planmodifier.Set) (bool, diag.Diagnostics) {
+	return requiresReplace(ctx, req, attributePath, modifiers, func(modifier planmodifier.Set, configValue, planValue, stateValue types.Set) bool {
+		modifierResp := &planmodifier.SetResponse{PlanValue: planValue}
+		modifier.PlanModifySet(ctx, planmodifier.SetRequest{
+			Path:           attributePath,
+			PathExpression: attributePath.Expression(),
+			Config:         req.Config,
+			ConfigValue:    configValue,
+			Plan:           req.Plan,
+			PlanValue:      planValue,
+			State:          req.State,
+			StateValue:     stateValue,
+			Private:        req.Private,
+		}, modifierResp)
+		return modifierResp.RequiresReplace
+	})
+}
+
+func requiresReplaceMap(ctx context.Context, req resource.ModifyPlanRequest, attributePath path.Path, modifiers []planmodifier.Map) (bool, diag.Diagnostics) {
+	return requiresReplace(ctx, req, attributePath, modifiers, func(modifier planmodifier.Map, configValue, planValue, stateValue types.Map) bool {
+		modifierResp := &planmodifier.MapResponse{PlanValue: planValue}
+		modifier.PlanModifyMap(ctx, planmodifier.MapRequest{
+			Path:           attributePath,
+			PathExpression: attributePath.Expression(),
+			Config:         req.Config,
+			ConfigValue:    configValue,
+			Plan:           req.Plan,
+			PlanValue:      planValue,
+			State:          req.State,
+			StateValue:     stateValue,
+			Private:        req.Private,
+		}, modifierResp)
+		return modifierResp.RequiresReplace
+	})
+}
+
+func requiresReplaceObject(ctx context.Context, req resource.ModifyPlanRequest, attributePath path.Path, modifiers []planmodifier.Object) (bool, diag.Diagnostics) {
+	return requiresReplace(ctx, req, attributePath, modifiers, func(modifier planmodifier.Object, configValue, planValue, stateValue types.Object) bool {
+		modifierResp := &planmodifier.ObjectResponse{PlanValue: planValue}
+		modifier.PlanModifyObject(ctx, planmodifier.ObjectRequest{
+			Path:           attributePath,
+			PathExpression: attributePath.Expression(),
+			Config:         req.Config,
+			ConfigValue:    configValue,
+			Plan:           req.Plan,
+			PlanValue:      planValue,
+			State:          req.State,
+			StateValue:     stateValue,
+			Private:        req.Private,
+		}, modifierResp)
+		return modifierResp.RequiresReplace
+	})
+}
+
+// requiresReplace reads the config, plan and state values of an attribute and runs its plan modifiers until one
+// requires replacing the resource
+func requiresReplace[T attr.Value, M any](
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	attributePath path.Path,
+	modifiers []M,
+	modify func(modifier M, configValue, planValue, stateValue T) bool,
+) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(modifiers) == 0 {
+		return false, diags
+	}
+	var configValue, planValue, stateValue T
+	diags.Append(req.Config.GetAttribute(ctx, attributePath, &configValue)...)
+	diags.Append(req.Plan.GetAttribute(ctx, attributePath, &planValue)...)
+	diags.Append(req.State.GetAttribute(ctx, attributePath, &stateValue)...)
+	if diags.HasError() {
+		return false, diags
+	}
+	for _, modifier := range modifiers {
+		if modify(modifier, configValue, planValue, stateValue) {
+			return true, diags
+		}
+	}
+	return false, diags
+}
+
+func joinPaths(paths path.Paths) string {
+	names := lo.Uniq(lo.Map(paths, func(attributePath path.Path, _ int) string {
+		return attributePath.String()
+	}))
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,129 @@
+package resources_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_ModifyPlanDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIfConfigured()},
+			},
+			"size": schema.Int64Attribute{
+				Optional:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"zones": schema.SetAttribute{
+				ElementType:   types.StringType,
+				Optional:      true,
+				PlanModifiers: []planmodifier.Set{setplanmodifier.RequiresReplace()},
+			},
+			"spec": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"region": schema.StringAttribute{
+						Optional:      true,
+						PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+					},
+				},
+			},
+			"deletion_protection": schemas.DeletionProtectionResourceSchemaAttribute("deployment"),
+		},
+	}
+	objectType := testSchema.Type().TerraformType(ctx)
+	specType := objectType.(tftypes.Object).AttributeTypes["spec"]
+	valueWith := func(name, workspaceId string, deletionProtection bool, attributes map[string]tftypes.Value) tftypes.Value {
+		values := map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, "deployment-id"),
+			"name":                tftypes.NewValue(tftypes.String, name),
+			"workspace_id":        tftypes.NewValue(tftypes.String, workspaceId),
+			"size":                tftypes.NewValue(tftypes.Number, 1),
+			"zones":               tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "a")}),
+			"spec":                tftypes.NewValue(specType, map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "us-east-1")}),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, deletionProtection),
+		}
+		for attribute, value := range attributes {
+			values[attribute] = value
+		}
+		return tftypes.NewValue(objectType, values)
+	}
+	value := func(name, workspaceId string, deletionProtection bool) tftypes.Value {
+		return valueWith(name, workspaceId, deletionProtection, nil)
+	}
+	modifyPlanReplacing := func(state, plan tftypes.Value, requiresReplace path.Paths) *resource.ModifyPlanResponse {
+		req := resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: testSchema, Raw: plan},
+			Plan:   tfsdk.Plan{Schema: testSchema, Raw: plan},
+			State:  tfsdk.State{Schema: testSchema, Raw: state},
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan, RequiresReplace: requiresReplace}
+		resources.ModifyPlanDeletionProtection(ctx, "astro_deployment", req, resp)
+		return resp
+	}
+	modifyPlan := func(state, plan tftypes.Value) *resource.ModifyPlanResponse {
+		return modifyPlanReplacing(state, plan, nil)
+	}
+	null := tftypes.NewValue(objectType, nil)
+
+	t.Run("fails destroy and replacement plans of protected resources", func(t *testing.T) {
+		resp := modifyPlan(value("name", "workspace-1", true), null)
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "astro_deployment deployment-id would be deleted since it is destroyed")
+
+		resp = modifyPlan(value("name", "workspace-1", true), value("name", "workspace-2", true))
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "changing workspace_id requires replacing it")
+
+		// replacements of attributes of any kind, and of the resource ModifyPlan, are protected
+		resp = modifyPlan(value("name", "workspace-1", true), valueWith("name", "workspace-1", true, map[string]tftypes.Value{
+			"size": tftypes.NewValue(tftypes.Number, 2),
+		}))
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "changing size requires replacing it")
+
+		resp = modifyPlan(value("name", "workspace-1", true), valueWith("name", "workspace-1", true, map[string]tftypes.Value{
+			"zones": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "b")}),
+			"spec":  tftypes.NewValue(specType, map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "us-west-2")}),
+		}))
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "changing spec.region, zones requires replacing it")
+
+		resp = modifyPlanReplacing(value("name", "workspace-1", true), value("new-name", "workspace-1", true), path.Paths{path.Root("name")})
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "changing name requires replacing it")
+
+		// protection is only removed once false is applied
+		resp = modifyPlan(value("name", "workspace-1", true), value("name", "workspace-2", false))
+		assert.True(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("allows other plans", func(t *testing.T) {
+		assert.False(t, modifyPlan(null, value("name", "workspace-1", true)).Diagnostics.HasError())
+		assert.False(t, modifyPlan(value("name", "workspace-1", true), value("new-name", "workspace-1", true)).Diagnostics.HasError())
+		assert.False(t, modifyPlan(value("name", "workspace-1", true), value("name", "workspace-1", false)).Diagnostics.HasError())
+		assert.False(t, modifyPlan(value("name", "workspace-1", false), value("name", "workspace-2", false)).Diagnostics.HasError())
+		assert.False(t, modifyPlan(value("name", "workspace-1", false), null).Diagnostics.HasError())
+	})
+}
@@ -0,0 +1,85 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// DeleteDeployments deletes deployments and waits for them to disappear, it is used to delete the dependents of the
+// workspaces and clusters destroyed with force_destroy. Progress is reported through tflog.
+func DeleteDeployments(
+	ctx context.Context,
+	platformClient *platform.ClientWithResponses,
+	cache *clients.ResponseCache,
+	organizationId string,
+	deployments []platform.Deployment,
+) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	for i, deployment := range deployments {
+		tflog.Info(ctx, fmt.Sprintf("deleting deployment %v (%v), %v of %v", deployment.Name, deployment.Id, i+1, len(deployments)))
+		deleted, err := platformClient.DeleteDeploymentWithResponse(ctx, organizationId, deployment.Id)
+		cache.Invalidate(ctx, clients.DeploymentsCacheKeyPrefix)
+		if err != nil {
+			tflog.Error(ctx, "failed to delete deployment", map[string]interface{}{"error": err, "deploymentId": deployment.Id})
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to delete deployment %v, got error: %s", deployment.Id, err),
+			)
+			return diags
+		}
+		statusCode, diagnostic := clients.NormalizeAPIError(ctx, deleted.HTTPResponse, deleted.Body)
+		if statusCode != http.StatusNotFound && diagnostic != nil {
+			diags.Append(diagnostic)
+			return diags
+		}
+	}
+
+	// Wait for the deployments to be deleted, the objects they depend on cannot be deleted before
+	for i, deployment := range deployments {
+		tflog.Info(ctx, fmt.Sprintf("waiting for deployment %v (%v) to be deleted, %v of %v", deployment.Name, deployment.Id, i+1, len(deployments)))
+		stateConf := &retry.StateChangeConf{
+			Pending:    []string{"DELETING"},
+			Target:     []string{"DELETED"},
+			Refresh:    DeploymentDeletionRefreshFunc(ctx, platformClient, organizationId, deployment.Id),
+			Timeout:    1 * time.Hour,
+			MinTimeout: 10 * time.Second,
+		}
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			diags.AddError("Deployment deletion failed", err.Error())
+			return diags
+		}
+	}
+	return diags
+}
+
+// DeploymentDeletionRefreshFunc returns a retry.StateRefreshFunc that polls the platform API until a deployment is not
+// found anymore, it returns "DELETED" once the deployment is not found and "DELETING" while it still exists
+func DeploymentDeletionRefreshFunc(ctx context.Context, platformClient *platform.ClientWithResponses, organizationId string, deploymentId string) retry.StateRefreshFunc {
+	return tracing.TraceRefresh(ctx, "astro_deployment.Poll", deploymentId, func(ctx context.Context) (any, string, error) {
+		deployment, err := platformClient.GetDeploymentWithResponse(ctx, organizationId, deploymentId)
+		if err != nil {
+			tflog.Error(ctx, "failed to get deployment while polling for its deletion", map[string]interface{}{"error": err})
+			return nil, "", err
+		}
+		statusCode, diagnostic := clients.NormalizeAPIError(ctx, deployment.HTTPResponse, deployment.Body)
+		if statusCode == http.StatusNotFound {
+			return &platform.Deployment{}, "DELETED", nil
+		}
+		if diagnostic != nil {
+			return nil, "", fmt.Errorf("error getting deployment %s", diagnostic.Detail())
+		}
+		if deployment.JSON200 == nil {
+			return nil, "", fmt.Errorf("error getting deployment %s", deploymentId)
+		}
+		return deployment.JSON200, "DELETING", nil
+	})
+}
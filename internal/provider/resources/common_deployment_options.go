@@ -0,0 +1,162 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+)
+
+// quantitySuffixes are the multipliers of the suffixes of the CPU and memory quantities of the API
+var quantitySuffixes = map[string]float64{
+	"m":  0.001,
+	"Ki": 1 << 10,
+	"Mi": 1 << 20,
+	"Gi": 1 << 30,
+	"Ti": 1 << 40,
+}
+
+// ParseQuantity parses a CPU or memory quantity of the API such as "0.25", "500m" or "0.5Gi"
+func ParseQuantity(quantity string) (float64, error) {
+	number, multiplier := strings.TrimSpace(quantity), 1.0
+	for suffix, suffixMultiplier := range quantitySuffixes {
+		if trimmed, ok := strings.CutSuffix(number, suffix); ok {
+			number, multiplier = trimmed, suffixMultiplier
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid quantity %q", quantity)
+	}
+	return value * multiplier, nil
+}
+
+// ValidateDeploymentOptions checks the sizing of a 'STANDARD' or 'DEDICATED' deployment against the deployment options
+// of the API, unknown and null values are not checked
+func ValidateDeploymentOptions(ctx context.Context, data *models.DeploymentResource, options *platform.DeploymentOptions) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	deploymentType := data.Type.ValueString()
+
+	if isKnown(data.SchedulerSize) {
+		schedulerSizes := lo.Map(options.SchedulerMachines, func(machine platform.SchedulerMachine, _ int) string {
+			return string(machine.Name)
+		})
+		if len(schedulerSizes) > 0 && !lo.Contains(schedulerSizes, data.SchedulerSize.ValueString()) {
+			diags.AddAttributeError(
+				path.Root("scheduler_size"),
+				fmt.Sprintf("scheduler_size is not available for '%v' deployment", deploymentType),
+				fmt.Sprintf("Please provide one of %v, got %v", strings.Join(schedulerSizes, ", "), data.SchedulerSize.ValueString()),
+			)
+		}
+	}
+
+	diags.Append(validateQuantityRange(path.Root("resource_quota_cpu"), data.ResourceQuotaCpu, options.ResourceQuotas.ResourceQuota.Cpu)...)
+	diags.Append(validateQuantityRange(path.Root("resource_quota_memory"), data.ResourceQuotaMemory, options.ResourceQuotas.ResourceQuota.Memory)...)
+	diags.Append(validateQuantityRange(path.Root("default_task_pod_cpu"), data.DefaultTaskPodCpu, options.ResourceQuotas.DefaultPodSize.Cpu)...)
+	diags.Append(validateQuantityRange(path.Root("default_task_pod_memory"), data.DefaultTaskPodMemory, options.ResourceQuotas.DefaultPodSize.Memory)...)
+
+	if !isKnown(data.WorkerQueues) {
+		return diags
+	}
+	var workerQueues []models.WorkerQueueResource
+	diags.Append(data.WorkerQueues.ElementsAs(ctx, &workerQueues, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	workerMachines := lo.KeyBy(options.WorkerMachines, func(machine platform.WorkerMachine) string {
+		return string(machine.Name)
+	})
+	workerMachineNames := lo.Map(options.WorkerMachines, func(machine platform.WorkerMachine, _ int) string {
+		return string(machine.Name)
+	})
+	for i, element := range data.WorkerQueues.Elements() {
+		workerQueue := workerQueues[i]
+		workerQueuePath := path.Root("worker_queues").AtSetValue(element)
+
+		// worker concurrency depends on the Astro machine of the worker queue
+		workerConcurrency := options.WorkerQueues.WorkerConcurrency
+		if isKnown(workerQueue.AstroMachine) && len(workerMachines) > 0 {
+			machine, ok := workerMachines[workerQueue.AstroMachine.ValueString()]
+			if !ok {
+				diags.AddAttributeError(
+					workerQueuePath.AtName("astro_machine"),
+					fmt.Sprintf("astro_machine is not available for '%v' deployment", deploymentType),
+					fmt.Sprintf("Please provide one of %v, got %v", strings.Join(workerMachineNames, ", "), workerQueue.AstroMachine.ValueString()),
+				)
+			} else {
+				workerConcurrency = machine.Concurrency
+			}
+		}
+
+		diags.Append(validateRange(workerQueuePath.AtName("min_worker_count"), workerQueue.MinWorkerCount, options.WorkerQueues.MinWorkers)...)
+		diags.Append(validateRange(workerQueuePath.AtName("max_worker_count"), workerQueue.MaxWorkerCount, options.WorkerQueues.MaxWorkers)...)
+		diags.Append(validateRange(workerQueuePath.AtName("worker_concurrency"), workerQueue.WorkerConcurrency, workerConcurrency)...)
+		if isKnown(workerQueue.MinWorkerCount) && isKnown(workerQueue.MaxWorkerCount) && workerQueue.MinWorkerCount.ValueInt64() > workerQueue.MaxWorkerCount.ValueInt64() {
+			diags.AddAttributeError(
+				workerQueuePath.AtName("min_worker_count"),
+				"min_worker_count must not be greater than max_worker_count",
+				fmt.Sprintf("Please provide a min_worker_count of at most %v", workerQueue.MaxWorkerCount.ValueInt64()),
+			)
+		}
+	}
+	return diags
+}
+
+func validateQuantityRange(attributePath path.Path, value types.String, quantityRange platform.ResourceRange) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	if !isKnown(value) {
+		return diags
+	}
+	quantity, err := ParseQuantity(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(attributePath, fmt.Sprintf("%v is invalid", lastStep(attributePath)), err.Error())
+		return diags
+	}
+	floor, floorErr := ParseQuantity(quantityRange.Floor)
+	ceiling, ceilingErr := ParseQuantity(quantityRange.Ceiling)
+	// ranges the provider cannot read are left to the API
+	if floorErr != nil || ceilingErr != nil {
+		return diags
+	}
+	if quantity < floor || quantity > ceiling {
+		diags.AddAttributeError(
+			attributePath,
+			fmt.Sprintf("%v is out of range", lastStep(attributePath)),
+			fmt.Sprintf("Please provide a value between %v and %v, got %v", quantityRange.Floor, quantityRange.Ceiling, value.ValueString()),
+		)
+	}
+	return diags
+}
+
+func validateRange(attributePath path.Path, value types.Int64, valueRange platform.Range) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	if !isKnown(value) || valueRange.Ceiling == 0 {
+		return diags
+	}
+	if float32(value.ValueInt64()) < valueRange.Floor || float32(value.ValueInt64()) > valueRange.Ceiling {
+		diags.AddAttributeError(
+			attributePath,
+			fmt.Sprintf("%v is out of range", lastStep(attributePath)),
+			fmt.Sprintf("Please provide a value between %v and %v, got %v", valueRange.Floor, valueRange.Ceiling, value.ValueInt64()),
+		)
+	}
+	return diags
+}
+
+func isKnown(value attr.Value) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+func lastStep(attributePath path.Path) string {
+	step, _ := attributePath.Steps().LastStep()
+	return step.String()
+}
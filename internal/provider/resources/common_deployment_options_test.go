@@ -0,0 +1,134 @@
+package resources_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_ValidateDeploymentOptions(t *testing.T) {
+	ctx := context.Background()
+	options := &platform.DeploymentOptions{
+		ResourceQuotas: platform.ResourceQuotaOptions{
+			DefaultPodSize: platform.ResourceOption{
+				Cpu:    platform.ResourceRange{Floor: "0.25", Default: "1", Ceiling: "6"},
+				Memory: platform.ResourceRange{Floor: "0.5Gi", Default: "2Gi", Ceiling: "12Gi"},
+			},
+			ResourceQuota: platform.ResourceOption{
+				Cpu:    platform.ResourceRange{Floor: "1", Default: "10", Ceiling: "1000"},
+				Memory: platform.ResourceRange{Floor: "2Gi", Default: "20Gi", Ceiling: "2000Gi"},
+			},
+		},
+		SchedulerMachines: []platform.SchedulerMachine{
+			{Name: platform.SchedulerMachineNameSMALL},
+			{Name: platform.SchedulerMachineNameMEDIUM},
+		},
+		WorkerMachines: []platform.WorkerMachine{
+			{Name: platform.WorkerMachineNameA5, Concurrency: platform.Range{Floor: 1, Default: 5, Ceiling: 10}},
+			{Name: platform.WorkerMachineNameA10, Concurrency: platform.Range{Floor: 1, Default: 10, Ceiling: 20}},
+		},
+		WorkerQueues: platform.WorkerQueueOptions{
+			MaxWorkers:        platform.Range{Floor: 1, Default: 10, Ceiling: 30},
+			MinWorkers:        platform.Range{Floor: 0, Default: 1, Ceiling: 30},
+			WorkerConcurrency: platform.Range{Floor: 1, Default: 5, Ceiling: 64},
+		},
+	}
+	workerQueues := func(workerQueues ...map[string]attr.Value) types.Set {
+		elements := lo.Map(workerQueues, func(workerQueue map[string]attr.Value, _ int) attr.Value {
+			attributes := map[string]attr.Value{
+				"name":               types.StringValue("default"),
+				"astro_machine":      types.StringValue("A5"),
+				"is_default":         types.BoolValue(true),
+				"max_worker_count":   types.Int64Value(10),
+				"min_worker_count":   types.Int64Value(0),
+				"node_pool_id":       types.StringNull(),
+				"pod_cpu":            types.StringUnknown(),
+				"pod_memory":         types.StringUnknown(),
+				"worker_concurrency": types.Int64Value(5),
+			}
+			for name, value := range workerQueue {
+				attributes[name] = value
+			}
+			return types.ObjectValueMust(schemas.WorkerQueueResourceAttributeTypes(), attributes)
+		})
+		return types.SetValueMust(types.ObjectType{AttrTypes: schemas.WorkerQueueResourceAttributeTypes()}, elements)
+	}
+	deployment := func() *models.DeploymentResource {
+		return &models.DeploymentResource{
+			Type:                 types.StringValue(string(platform.DeploymentTypeSTANDARD)),
+			SchedulerSize:        types.StringValue("SMALL"),
+			ResourceQuotaCpu:     types.StringValue("10"),
+			ResourceQuotaMemory:  types.StringValue("20Gi"),
+			DefaultTaskPodCpu:    types.StringValue("0.25"),
+			DefaultTaskPodMemory: types.StringValue("512Mi"),
+			WorkerQueues:         workerQueues(map[string]attr.Value{}),
+		}
+	}
+	summaries := func(diags diag.Diagnostics) []string {
+		return lo.Map(diags.Errors(), func(diagnostic diag.Diagnostic, _ int) string {
+			return diagnostic.Summary()
+		})
+	}
+
+	t.Run("accepts values of the deployment options", func(t *testing.T) {
+		assert.Empty(t, summaries(resources.ValidateDeploymentOptions(ctx, deployment(), options)))
+
+		data := deployment()
+		data.SchedulerSize = types.StringUnknown()
+		data.ResourceQuotaCpu = types.StringNull()
+		data.WorkerQueues = types.SetUnknown(types.ObjectType{AttrTypes: schemas.WorkerQueueResourceAttributeTypes()})
+		assert.Empty(t, summaries(resources.ValidateDeploymentOptions(ctx, data, options)))
+	})
+
+	t.Run("rejects values out of the deployment options", func(t *testing.T) {
+		data := deployment()
+		data.SchedulerSize = types.StringValue("EXTRA_LARGE")
+		data.ResourceQuotaCpu = types.StringValue("2000")
+		data.ResourceQuotaMemory = types.StringValue("lots")
+		data.DefaultTaskPodMemory = types.StringValue("256Mi")
+		assert.ElementsMatch(t, []string{
+			"scheduler_size is not available for 'STANDARD' deployment",
+			"resource_quota_cpu is out of range",
+			"resource_quota_memory is invalid",
+			"default_task_pod_memory is out of range",
+		}, summaries(resources.ValidateDeploymentOptions(ctx, data, options)))
+	})
+
+	t.Run("rejects worker queues out of the deployment options", func(t *testing.T) {
+		data := deployment()
+		data.WorkerQueues = workerQueues(
+			map[string]attr.Value{"astro_machine": types.StringValue("A160")},
+			map[string]attr.Value{"name": types.StringValue("queue"), "worker_concurrency": types.Int64Value(15)},
+			map[string]attr.Value{"name": types.StringValue("large"), "astro_machine": types.StringValue("A10"), "worker_concurrency": types.Int64Value(15), "max_worker_count": types.Int64Value(40), "min_worker_count": types.Int64Value(50)},
+		)
+		diags := resources.ValidateDeploymentOptions(ctx, data, options)
+		assert.ElementsMatch(t, []string{
+			"astro_machine is not available for 'STANDARD' deployment",
+			"worker_concurrency is out of range",
+			"max_worker_count is out of range",
+			"min_worker_count is out of range",
+			"min_worker_count must not be greater than max_worker_count",
+		}, summaries(diags))
+	})
+
+	t.Run("parses quantities", func(t *testing.T) {
+		for quantity, expected := range map[string]float64{"0.25": 0.25, "500m": 0.5, "0.5Gi": 1 << 29, "512Mi": 1 << 29, "2": 2} {
+			value, err := resources.ParseQuantity(quantity)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, value, quantity)
+		}
+		_, err := resources.ParseQuantity("-1")
+		assert.Error(t, err)
+		_, err = resources.ParseQuantity("1Xi")
+		assert.Error(t, err)
+	})
+}
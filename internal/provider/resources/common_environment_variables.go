@@ -0,0 +1,146 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+)
+
+// secretEnvironmentVariablesPrivateKey is the private state key of the update times of the secret environment
+// variables, by key, when the provider last read them
+const secretEnvironmentVariablesPrivateKey = "secret_environment_variables_updated_at"
+
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// SecretEnvironmentVariablesUpdatedAt returns the update times of the secret environment variables of a deployment by key
+func SecretEnvironmentVariablesUpdatedAt(deployment *platform.Deployment) map[string]string {
+	secrets := lo.Filter(lo.FromPtr(deployment.EnvironmentVariables), func(envVar platform.DeploymentEnvironmentVariable, _ int) bool {
+		return envVar.IsSecret
+	})
+	return lo.SliceToMap(secrets, func(envVar platform.DeploymentEnvironmentVariable) (string, string) {
+		return envVar.Key, envVar.UpdatedAt
+	})
+}
+
+// ChangedSecrets returns the keys of the secret environment variables whose update time differs from the one the
+// provider last read, they were changed outside of Terraform. Secrets without a recorded update time, such as the
+// secrets of states written by older versions of the provider, are not changed.
+func ChangedSecrets(deployment *platform.Deployment, recordedUpdatedAt map[string]string) map[string]bool {
+	changed := map[string]bool{}
+	for key, updatedAt := range SecretEnvironmentVariablesUpdatedAt(deployment) {
+		if recorded, ok := recordedUpdatedAt[key]; ok && recorded != updatedAt {
+			changed[key] = true
+		}
+	}
+	return changed
+}
+
+// WithoutChangedSecrets removes the secret environment variables that were changed outside of Terraform, since the API
+// does not return secret values and the values of the prior state may be out of date. The secrets are then left out
+// of the new state so that the plan applies them again.
+func WithoutChangedSecrets(
+	envVars []platform.DeploymentEnvironmentVariableRequest,
+	deployment *platform.Deployment,
+	recordedUpdatedAt map[string]string,
+) []platform.DeploymentEnvironmentVariableRequest {
+	changed := ChangedSecrets(deployment, recordedUpdatedAt)
+	return lo.Filter(envVars, func(envVar platform.DeploymentEnvironmentVariableRequest, _ int) bool {
+		return !envVar.IsSecret || !changed[envVar.Key]
+	})
+}
+
+// WithoutChangedSecretHashes removes the hashes of the secret environment variables that were changed outside of
+// Terraform, so that their hashes are unknown in the plan and the secrets are applied again
+func WithoutChangedSecretHashes(
+	hashes types.Map,
+	deployment *platform.Deployment,
+	recordedUpdatedAt map[string]string,
+) types.Map {
+	if !isKnown(hashes) {
+		return hashes
+	}
+	changed := ChangedSecrets(deployment, recordedUpdatedAt)
+	return types.MapValueMust(types.StringType, lo.OmitBy(hashes.Elements(), func(key string, _ attr.Value) bool {
+		return changed[key]
+	}))
+}
+
+// PlanSecretEnvironmentVariablesHashes plans the hashes of the write-only secret_environment_variables of the
+// configuration. The prior hash of a secret is kept while it matches the configured value, it is unknown otherwise
+// so that the secret is applied.
+func PlanSecretEnvironmentVariablesHashes(secrets types.Map, priorHashes types.Map) (types.Map, diag.Diagnostics) {
+	switch {
+	case secrets.IsNull():
+		return types.MapNull(types.StringType), nil
+	case secrets.IsUnknown():
+		return types.MapUnknown(types.StringType), nil
+	}
+	values := make(map[string]attr.Value)
+	for key, value := range secrets.Elements() {
+		secret, _ := value.(types.String)
+		priorHash, _ := priorHashes.Elements()[key].(types.String)
+		if isKnown(secret) && isKnown(priorHash) && utils.SecretMatchesHash(secret.ValueString(), priorHash.ValueString()) {
+			values[key] = priorHash
+		} else {
+			values[key] = types.StringUnknown()
+		}
+	}
+	return types.MapValue(types.StringType, values)
+}
+
+func readSecretEnvironmentVariablesUpdatedAt(ctx context.Context, private privateStateGetter) (map[string]string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, secretEnvironmentVariablesPrivateKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, diags
+	}
+	var updatedAt map[string]string
+	if err := json.Unmarshal(value, &updatedAt); err != nil {
+		diags.AddError("Invalid private state", fmt.Sprintf("Unable to read the update times of the secret environment variables, got error: %s", err))
+	}
+	return updatedAt, diags
+}
+
+func writeSecretEnvironmentVariablesUpdatedAt(ctx context.Context, private privateStateSetter, deployment *platform.Deployment) diag.Diagnostics {
+	value, err := json.Marshal(SecretEnvironmentVariablesUpdatedAt(deployment))
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Invalid private state", fmt.Sprintf("Unable to write the update times of the secret environment variables, got error: %s", err))}
+	}
+	return private.SetKey(ctx, secretEnvironmentVariablesPrivateKey, value)
+}
+
+// ValidateEnvironmentVariablesMaps checks that a key is not both in environment_variables_map and
+// secret_environment_variables
+func ValidateEnvironmentVariablesMaps(data *models.DeploymentResource) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	if !isKnown(data.EnvironmentVariablesMap) || !isKnown(data.SecretEnvironmentVariables) {
+		return diags
+	}
+	keys := lo.Keys(data.SecretEnvironmentVariables.Elements())
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, ok := data.EnvironmentVariablesMap.Elements()[key]; ok {
+			diags.AddAttributeError(
+				path.Root("secret_environment_variables").AtMapKey(key),
+				"environment variable keys must be unique",
+				fmt.Sprintf("Please remove %v from either environment_variables_map or secret_environment_variables", key),
+			)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,129 @@
+package resources_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_EnvironmentVariables(t *testing.T) {
+	ctx := context.Background()
+	stringMap := func(values map[string]string) types.Map {
+		return types.MapValueMust(types.StringType, lo.MapValues(values, func(value string, _ string) attr.Value {
+			return types.StringValue(value)
+		}))
+	}
+
+	t.Run("requests the environment variables of the maps", func(t *testing.T) {
+		envVars, diags := resources.RequestDeploymentEnvironmentVariables(ctx, &models.DeploymentResource{
+			EnvironmentVariables:       types.SetNull(types.ObjectType{}),
+			EnvironmentVariablesMap:    stringMap(map[string]string{"B": "b", "A": "a"}),
+			SecretEnvironmentVariables: stringMap(map[string]string{"C": "c"}),
+		})
+		assert.False(t, diags.HasError())
+		assert.Equal(t, []platform.DeploymentEnvironmentVariableRequest{
+			{Key: "A", Value: lo.ToPtr("a")},
+			{Key: "B", Value: lo.ToPtr("b")},
+			{Key: "C", Value: lo.ToPtr("c"), IsSecret: true},
+		}, envVars)
+
+		envVars, diags = resources.RequestDeploymentEnvironmentVariables(ctx, &models.DeploymentResource{
+			EnvironmentVariables:       types.SetNull(types.ObjectType{}),
+			EnvironmentVariablesMap:    types.MapNull(types.StringType),
+			SecretEnvironmentVariables: types.MapNull(types.StringType),
+		})
+		assert.False(t, diags.HasError())
+		assert.Empty(t, envVars)
+	})
+
+	t.Run("fails keys in both maps", func(t *testing.T) {
+		diags := resources.ValidateEnvironmentVariablesMaps(&models.DeploymentResource{
+			EnvironmentVariablesMap:    stringMap(map[string]string{"A": "a", "B": "b"}),
+			SecretEnvironmentVariables: stringMap(map[string]string{"B": "b"}),
+		})
+		assert.Len(t, diags.Errors(), 1)
+		assert.Contains(t, diags.Errors()[0].Detail(), "Please remove B")
+
+		diags = resources.ValidateEnvironmentVariablesMaps(&models.DeploymentResource{
+			EnvironmentVariablesMap:    stringMap(map[string]string{"A": "a"}),
+			SecretEnvironmentVariables: types.MapUnknown(types.StringType),
+		})
+		assert.False(t, diags.HasError())
+	})
+
+	t.Run("leaves out secrets changed outside of terraform", func(t *testing.T) {
+		deployment := &platform.Deployment{
+			EnvironmentVariables: &[]platform.DeploymentEnvironmentVariable{
+				{Key: "KEY", Value: lo.ToPtr("value"), UpdatedAt: "2024-05-02T00:00:00Z"},
+				{Key: "SECRET", IsSecret: true, UpdatedAt: "2024-05-01T00:00:00Z"},
+				{Key: "CHANGED", IsSecret: true, UpdatedAt: "2024-05-02T00:00:00Z"},
+				{Key: "UNRECORDED", IsSecret: true, UpdatedAt: "2024-05-02T00:00:00Z"},
+			},
+		}
+		assert.Equal(t, map[string]string{
+			"SECRET":     "2024-05-01T00:00:00Z",
+			"CHANGED":    "2024-05-02T00:00:00Z",
+			"UNRECORDED": "2024-05-02T00:00:00Z",
+		}, resources.SecretEnvironmentVariablesUpdatedAt(deployment))
+
+		envVars := []platform.DeploymentEnvironmentVariableRequest{
+			{Key: "KEY", Value: lo.ToPtr("value")},
+			{Key: "SECRET", Value: lo.ToPtr("secret"), IsSecret: true},
+			{Key: "CHANGED", Value: lo.ToPtr("secret"), IsSecret: true},
+			{Key: "UNRECORDED", Value: lo.ToPtr("secret"), IsSecret: true},
+		}
+		keys := lo.Map(resources.WithoutChangedSecrets(envVars, deployment, map[string]string{
+			"KEY":     "2024-05-01T00:00:00Z",
+			"SECRET":  "2024-05-01T00:00:00Z",
+			"CHANGED": "2024-05-01T00:00:00Z",
+		}), func(envVar platform.DeploymentEnvironmentVariableRequest, _ int) string {
+			return envVar.Key
+		})
+		assert.Equal(t, []string{"KEY", "SECRET", "UNRECORDED"}, keys)
+
+		hashes := stringMap(map[string]string{"SECRET": "sha256:00:00", "CHANGED": "sha256:00:01", "UNRECORDED": "sha256:00:02"})
+		assert.Equal(t, stringMap(map[string]string{"SECRET": "sha256:00:00", "UNRECORDED": "sha256:00:02"}), resources.WithoutChangedSecretHashes(hashes, deployment, map[string]string{
+			"SECRET":  "2024-05-01T00:00:00Z",
+			"CHANGED": "2024-05-01T00:00:00Z",
+		}))
+		assert.True(t, resources.WithoutChangedSecretHashes(types.MapNull(types.StringType), deployment, nil).IsNull())
+	})
+
+	t.Run("plans the hashes of secrets", func(t *testing.T) {
+		secretHash, err := utils.HashSecret("secret")
+		assert.NoError(t, err)
+		priorHashes := stringMap(map[string]string{"SECRET": secretHash, "CHANGED": secretHash, "REMOVED": secretHash})
+
+		hashes, diags := resources.PlanSecretEnvironmentVariablesHashes(types.MapValueMust(types.StringType, map[string]attr.Value{
+			"SECRET":  types.StringValue("secret"),
+			"CHANGED": types.StringValue("changed"),
+			"ADDED":   types.StringValue("added"),
+			"UNKNOWN": types.StringUnknown(),
+		}), priorHashes)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{
+			"SECRET":  types.StringValue(secretHash),
+			"CHANGED": types.StringUnknown(),
+			"ADDED":   types.StringUnknown(),
+			"UNKNOWN": types.StringUnknown(),
+		}), hashes)
+
+		hashes, diags = resources.PlanSecretEnvironmentVariablesHashes(types.MapNull(types.StringType), priorHashes)
+		assert.False(t, diags.HasError())
+		assert.True(t, hashes.IsNull())
+		hashes, diags = resources.PlanSecretEnvironmentVariablesHashes(types.MapUnknown(types.StringType), priorHashes)
+		assert.False(t, diags.HasError())
+		assert.True(t, hashes.IsUnknown())
+		hashes, diags = resources.PlanSecretEnvironmentVariablesHashes(stringMap(map[string]string{"SECRET": "secret"}), types.MapNull(types.StringType))
+		assert.False(t, diags.HasError())
+		assert.True(t, hashes.Elements()["SECRET"].IsUnknown())
+	})
+}
@@ -0,0 +1,157 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// hibernationScheduleHorizon is how far ahead the hibernation schedules are checked for conflicts, long enough for
+// weekly schedules
+const hibernationScheduleHorizon = 5 * 7 * 24 * time.Hour
+
+type hibernationWindow struct {
+	start, end time.Time
+}
+
+type hibernationSchedule struct {
+	models.HibernationSchedule
+	path                path.Path
+	hibernateAt, wakeAt *utils.CronSchedule
+}
+
+// ValidateHibernationSchedules checks that the schedules of a hibernation_spec can be translated to UTC with its
+// timezone, that each schedule hibernates and wakes at different times, and that no enabled schedule wakes the
+// deployment while another keeps it hibernating. Unknown values are not checked.
+func ValidateHibernationSchedules(ctx context.Context, hibernationSpec models.HibernationSpecResource, now time.Time) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	if !isKnown(hibernationSpec.Schedules) || hibernationSpec.Timezone.IsUnknown() {
+		return diags
+	}
+	var schedules []models.HibernationSchedule
+	diags.Append(hibernationSpec.Schedules.ElementsAs(ctx, &schedules, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	location := time.UTC
+	if isKnown(hibernationSpec.Timezone) {
+		var err error
+		// invalid time zones are reported by the validator of timezone
+		if location, err = time.LoadLocation(hibernationSpec.Timezone.ValueString()); err != nil {
+			return diags
+		}
+	}
+
+	schedulesPath := path.Root("scaling_spec").AtName("hibernation_spec").AtName("schedules")
+	var enabledSchedules []hibernationSchedule
+	for i, element := range hibernationSpec.Schedules.Elements() {
+		schedule := hibernationSchedule{
+			HibernationSchedule: schedules[i],
+			path:                schedulesPath.AtSetValue(element),
+		}
+		if !isKnown(schedule.HibernateAtCron) || !isKnown(schedule.WakeAtCron) {
+			continue
+		}
+		if strings.Join(strings.Fields(schedule.HibernateAtCron.ValueString()), " ") == strings.Join(strings.Fields(schedule.WakeAtCron.ValueString()), " ") {
+			diags.AddAttributeError(
+				schedule.path,
+				"hibernation schedule hibernates and wakes at the same time",
+				fmt.Sprintf("Please provide a wake_at_cron different from the hibernate_at_cron %q", schedule.HibernateAtCron.ValueString()),
+			)
+			continue
+		}
+		hibernateAtCron, hibernateErr := hibernationScheduleCron(schedule.HibernateAtCron.ValueString(), location, now)
+		if hibernateErr != nil {
+			diags.AddAttributeError(schedule.path.AtName("hibernate_at_cron"), "hibernate_at_cron cannot be used with timezone", hibernateErr.Error())
+		}
+		wakeAtCron, wakeErr := hibernationScheduleCron(schedule.WakeAtCron.ValueString(), location, now)
+		if wakeErr != nil {
+			diags.AddAttributeError(schedule.path.AtName("wake_at_cron"), "wake_at_cron cannot be used with timezone", wakeErr.Error())
+		}
+		if hibernateErr != nil || wakeErr != nil || !schedule.IsEnabled.ValueBool() {
+			continue
+		}
+		// invalid cron expressions are reported by the validators of the cron attributes
+		schedule.hibernateAt, hibernateErr = utils.ParseCron(hibernateAtCron)
+		schedule.wakeAt, wakeErr = utils.ParseCron(wakeAtCron)
+		if hibernateErr == nil && wakeErr == nil {
+			enabledSchedules = append(enabledSchedules, schedule)
+		}
+	}
+
+	// windows that started in the last week may still be in progress
+	from, to := now.Add(-7*24*time.Hour), now.Add(hibernationScheduleHorizon)
+	for _, hibernating := range enabledSchedules {
+		windows := hibernationWindows(hibernating.hibernateAt, hibernating.wakeAt, from, to)
+		for _, waking := range enabledSchedules {
+			if waking.path.Equal(hibernating.path) {
+				continue
+			}
+			window, wakeAt, ok := conflictingWake(windows, waking.wakeAt, from, to)
+			if !ok {
+				continue
+			}
+			diags.AddAttributeError(
+				schedulesPath,
+				"hibernation schedules conflict",
+				fmt.Sprintf(
+					"The schedule with wake_at_cron %q wakes the deployment at %v, while the schedule with hibernate_at_cron %q keeps it hibernating from %v until %v. Please change the schedules so that they do not overlap.",
+					waking.WakeAtCron.ValueString(), wakeAt.Format(time.RFC3339), hibernating.HibernateAtCron.ValueString(),
+					window.start.Format(time.RFC3339), window.end.Format(time.RFC3339),
+				),
+			)
+			return diags
+		}
+	}
+	return diags
+}
+
+// hibernationScheduleCron returns the cron expression in UTC, the time zone the API evaluates schedules in
+func hibernationScheduleCron(expression string, location *time.Location, now time.Time) (string, error) {
+	if location == time.UTC {
+		return expression, nil
+	}
+	return utils.CronToUTC(expression, location, now)
+}
+
+// hibernationWindows returns the successive periods between a hibernation and the next wake of a schedule
+func hibernationWindows(hibernateAt, wakeAt *utils.CronSchedule, from, to time.Time) []hibernationWindow {
+	var windows []hibernationWindow
+	for t := from; ; {
+		start := hibernateAt.Next(t)
+		if start.IsZero() || start.After(to) {
+			return windows
+		}
+		end := wakeAt.Next(start)
+		if end.IsZero() || end.After(to) {
+			return append(windows, hibernationWindow{start: start, end: to})
+		}
+		windows = append(windows, hibernationWindow{start: start, end: end})
+		t = end
+	}
+}
+
+// conflictingWake returns the first wake of a schedule strictly inside one of the windows of another schedule
+func conflictingWake(windows []hibernationWindow, wakeAt *utils.CronSchedule, from, to time.Time) (hibernationWindow, time.Time, bool) {
+	i := 0
+	for t := from; i < len(windows); {
+		wake := wakeAt.Next(t)
+		if wake.IsZero() || wake.After(to) {
+			break
+		}
+		for i < len(windows) && !windows[i].end.After(wake) {
+			i++
+		}
+		if i < len(windows) && windows[i].start.Before(wake) {
+			return windows[i], wake, true
+		}
+		t = wake
+	}
+	return hibernationWindow{}, time.Time{}, false
+}
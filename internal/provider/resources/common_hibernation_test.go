@@ -0,0 +1,95 @@
+package resources_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_ValidateHibernationSchedules(t *testing.T) {
+	ctx := context.Background()
+	// a Wednesday in winter, when New York is 5 hours behind UTC
+	now := time.Date(2024, 1, 17, 12, 0, 0, 0, time.UTC)
+	schedule := func(hibernateAtCron, wakeAtCron string, isEnabled bool) attr.Value {
+		return types.ObjectValueMust(schemas.HibernationScheduleAttributeTypes(), map[string]attr.Value{
+			"description":       types.StringNull(),
+			"hibernate_at_cron": types.StringValue(hibernateAtCron),
+			"is_enabled":        types.BoolValue(isEnabled),
+			"wake_at_cron":      types.StringValue(wakeAtCron),
+		})
+	}
+	validate := func(timezone types.String, schedules ...attr.Value) diag.Diagnostics {
+		hibernationSpec := models.HibernationSpecResource{
+			Override:  types.ObjectNull(schemas.HibernationOverrideAttributeTypes()),
+			Schedules: types.SetValueMust(types.ObjectType{AttrTypes: schemas.HibernationScheduleAttributeTypes()}, schedules),
+			Timezone:  timezone,
+		}
+		return resources.ValidateHibernationSchedules(ctx, hibernationSpec, now)
+	}
+	summaries := func(diags diag.Diagnostics) []string {
+		return lo.Map(diags.Errors(), func(diagnostic diag.Diagnostic, _ int) string {
+			return diagnostic.Summary()
+		})
+	}
+
+	t.Run("allows consistent schedules", func(t *testing.T) {
+		diags := validate(types.StringNull(),
+			schedule("0 19 * * 1-5", "0 7 * * 1-5", true),
+			// nested in the weeknights schedule and waking at the same time
+			schedule("0 0 * * 6", "0 7 * * 1", true),
+		)
+		assert.Empty(t, diags.Errors())
+
+		diags = validate(types.StringValue("America/New_York"), schedule("0 19 * * 1-5", "0 7 * * 1-5", true))
+		assert.Empty(t, diags.Errors())
+	})
+
+	t.Run("ignores unknown values and disabled schedules", func(t *testing.T) {
+		unknownSchedule := types.ObjectValueMust(schemas.HibernationScheduleAttributeTypes(), map[string]attr.Value{
+			"description":       types.StringNull(),
+			"hibernate_at_cron": types.StringUnknown(),
+			"is_enabled":        types.BoolValue(true),
+			"wake_at_cron":      types.StringValue("0 7 * * *"),
+		})
+		diags := validate(types.StringNull(), unknownSchedule, schedule("0 19 * * *", "0 7 * * *", true))
+		assert.Empty(t, diags.Errors())
+
+		diags = validate(types.StringNull(), schedule("0 19 * * *", "0 7 * * *", true), schedule("0 20 * * *", "0 22 * * *", false))
+		assert.Empty(t, diags.Errors())
+	})
+
+	t.Run("fails schedules hibernating and waking at the same time", func(t *testing.T) {
+		diags := validate(types.StringNull(), schedule("0 19 * * *", "0  19 * * *", true))
+		assert.Equal(t, []string{"hibernation schedule hibernates and wakes at the same time"}, summaries(diags))
+	})
+
+	t.Run("fails schedules that cannot be used with a timezone", func(t *testing.T) {
+		diags := validate(types.StringValue("America/New_York"), schedule("*/30 19 * * *", "0 7 1 * *", true))
+		assert.Equal(t, []string{"hibernate_at_cron cannot be used with timezone", "wake_at_cron cannot be used with timezone"}, summaries(diags))
+	})
+
+	t.Run("fails schedules waking the deployment while another keeps it hibernating", func(t *testing.T) {
+		diags := validate(types.StringNull(),
+			schedule("0 19 * * 1-5", "0 7 * * 1-5", true),
+			schedule("0 20 * * *", "0 22 * * *", true),
+		)
+		assert.Equal(t, []string{"hibernation schedules conflict"}, summaries(diags))
+		assert.Contains(t, diags.Errors()[0].Detail(), `The schedule with wake_at_cron "0 22 * * *" wakes the deployment at 2024-01-`)
+
+		// partially overlapping windows
+		diags = validate(types.StringNull(),
+			schedule("0 19 * * *", "0 7 * * *", true),
+			schedule("0 5 * * *", "0 9 * * *", true),
+		)
+		assert.Equal(t, []string{"hibernation schedules conflict"}, summaries(diags))
+	})
+}
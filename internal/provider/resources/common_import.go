@@ -0,0 +1,293 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/samber/lo"
+)
+
+// ParseImportId returns the name of an import ID such as `team:<name>` with the prefix team, the boolean is false when
+// the import ID does not have the prefix and is an object ID
+func ParseImportId(importId, prefix string) (string, bool) {
+	return strings.CutPrefix(importId, prefix+":")
+}
+
+// ImportMatch returns the ID of the only object matching an import ID, ids are the IDs of the objects of objectType
+// whose name matches it. The import fails when no object or several objects match.
+func ImportMatch(importId, objectType string, ids []string) (string, diag.Diagnostics) {
+	diags := make(diag.Diagnostics, 0)
+	switch len(ids) {
+	case 1:
+		return ids[0], diags
+	case 0:
+		diags.AddError(
+			fmt.Sprintf("Cannot import %v", objectType),
+			fmt.Sprintf("No %v matches the import ID %v. Please check the name, or import the %v with its ID.", objectType, importId, objectType),
+		)
+	default:
+		ids = slices.Clone(ids)
+		slices.Sort(ids)
+		diags.AddError(
+			"Ambiguous import ID",
+			fmt.Sprintf("%v %vs match the import ID %v: %v. Please import the %v with one of these IDs.", len(ids), objectType, importId, strings.Join(ids, ", "), objectType),
+		)
+	}
+	return "", diags
+}
+
+// ImportWorkspaceId returns the ID of the workspace of an import ID, `workspace:<name>` import IDs are resolved by name
+func ImportWorkspaceId(
+	ctx context.Context,
+	platformClient *platform.ClientWithResponses,
+	organizationId string,
+	importId string,
+) (string, diag.Diagnostics) {
+	name, ok := ParseImportId(importId, "workspace")
+	if !ok {
+		return importId, nil
+	}
+	return workspaceIdByName(ctx, platformClient, organizationId, importId, name)
+}
+
+func workspaceIdByName(
+	ctx context.Context,
+	platformClient *platform.ClientWithResponses,
+	organizationId string,
+	importId string,
+	name string,
+) (string, diag.Diagnostics) {
+	diags := make(diag.Diagnostics, 0)
+	workspaces, err := platformClient.ListWorkspacesWithResponse(ctx, organizationId, &platform.ListWorkspacesParams{
+		Names: &[]string{name},
+		Limit: lo.ToPtr(1000),
+	})
+	if err != nil {
+		tflog.Error(ctx, "failed to list workspaces to import", map[string]interface{}{"error": err})
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list workspaces, got error: %s", err))
+		return "", diags
+	}
+	_, diagnostic := clients.NormalizeAPIError(ctx, workspaces.HTTPResponse, workspaces.Body)
+	if diagnostic != nil {
+		diags.Append(diagnostic)
+		return "", diags
+	}
+	if workspaces.JSON200 == nil {
+		diags.AddError("Client Error", "Unable to list workspaces, got nil response")
+		return "", diags
+	}
+	ids := lo.FilterMap(workspaces.JSON200.Workspaces, func(workspace platform.Workspace, _ int) (string, bool) {
+		return workspace.Id, workspace.Name == name
+	})
+	return ImportMatch(importId, "workspace", ids)
+}
+
+// ImportDeploymentId returns the ID of the deployment of an import ID, `<workspace name>/<deployment name>` import IDs
+// are resolved by name
+func ImportDeploymentId(
+	ctx context.Context,
+	platformClient *platform.ClientWithResponses,
+	organizationId string,
+	importId string,
+) (string, diag.Diagnostics) {
+	workspaceName, name, ok := strings.Cut(importId, "/")
+	if !ok {
+		return importId, nil
+	}
+	workspaceId, diags := workspaceIdByName(ctx, platformClient, organizationId, importId, workspaceName)
+	if diags.HasError() {
+		return "", diags
+	}
+	deployments, err := platformClient.ListDeploymentsWithResponse(ctx, organizationId, &platform.ListDeploymentsParams{
+		Names:        &[]string{name},
+		WorkspaceIds: &[]string{workspaceId},
+		Limit:        lo.ToPtr(1000),
+	})
+	if err != nil {
+		tflog.Error(ctx, "failed to list deployments to import", map[string]interface{}{"error": err})
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list deployments, got error: %s", err))
+		return "", diags
+	}
+	_, diagnostic := clients.NormalizeAPIError(ctx, deployments.HTTPResponse, deployments.Body)
+	if diagnostic != nil {
+		diags.Append(diagnostic)
+		return "", diags
+	}
+	if deployments.JSON200 == nil {
+		diags.AddError("Client Error", "Unable to list deployments, got nil response")
+		return "", diags
+	}
+	ids := lo.FilterMap(deployments.JSON200.Deployments, func(deployment platform.Deployment, _ int) (string, bool) {
+		return deployment.Id, deployment.Name == name && deployment.WorkspaceId == workspaceId
+	})
+	return ImportMatch(importId, "deployment", ids)
+}
+
+// ImportClusterId returns the ID of the cluster of an import ID, `cluster:<name>` import IDs are resolved by name
+func ImportClusterId(
+	ctx context.Context,
+	platformClient *platform.ClientWithResponses,
+	organizationId string,
+	importId string,
+) (string, diag.Diagnostics) {
+	name, ok := ParseImportId(importId, "cluster")
+	if !ok {
+		return importId, nil
+	}
+	diags := make(diag.Diagnostics, 0)
+	clusters, err := platformClient.ListClustersWithResponse(ctx, organizationId, &platform.ListClustersParams{
+		Names: &[]string{name},
+		Limit: lo.ToPtr(1000),
+	})
+	if err != nil {
+		tflog.Error(ctx, "failed to list clusters to import", map[string]interface{}{"error": err})
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list clusters, got error: %s", err))
+		return "", diags
+	}
+	_, diagnostic := clients.NormalizeAPIError(ctx, clusters.HTTPResponse, clusters.Body)
+	if diagnostic != nil {
+		diags.Append(diagnostic)
+		return "", diags
+	}
+	if clusters.JSON200 == nil {
+		diags.AddError("Client Error", "Unable to list clusters, got nil response")
+		return "", diags
+	}
+	ids := lo.FilterMap(clusters.JSON200.Clusters, func(cluster platform.Cluster, _ int) (string, bool) {
+		return cluster.Id, cluster.Name == name
+	})
+	return ImportMatch(importId, "cluster", ids)
+}
+
+// ImportTeamId returns the ID of the team of an import ID, `team:<name>` import IDs are resolved by name
+func ImportTeamId(
+	ctx context.Context,
+	iamClient *iam.ClientWithResponses,
+	organizationId string,
+	importId string,
+) (string, diag.Diagnostics) {
+	name, ok := ParseImportId(importId, "team")
+	if !ok {
+		return importId, nil
+	}
+	diags := make(diag.Diagnostics, 0)
+	teams, err := iamClient.ListTeamsWithResponse(ctx, organizationId, &iam.ListTeamsParams{
+		Names: &[]string{name},
+		Limit: lo.ToPtr(1000),
+	})
+	if err != nil {
+		tflog.Error(ctx, "failed to list teams to import", map[string]interface{}{"error": err})
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list teams, got error: %s", err))
+		return "", diags
+	}
+	_, diagnostic := clients.NormalizeAPIError(ctx, teams.HTTPResponse, teams.Body)
+	if diagnostic != nil {
+		diags.Append(diagnostic)
+		return "", diags
+	}
+	if teams.JSON200 == nil {
+		diags.AddError("Client Error", "Unable to list teams, got nil response")
+		return "", diags
+	}
+	ids := lo.FilterMap(teams.JSON200.Teams, func(team iam.Team, _ int) (string, bool) {
+		return team.Id, team.Name == name
+	})
+	return ImportMatch(importId, "team", ids)
+}
+
+// ImportUserId returns the ID of the user of an import ID, `user:<email>` import IDs are resolved by the email of the
+// user. The API does not filter users by email, so all the users of the organization are listed.
+func ImportUserId(
+	ctx context.Context,
+	iamClient *iam.ClientWithResponses,
+	organizationId string,
+	importId string,
+) (string, diag.Diagnostics) {
+	email, ok := ParseImportId(importId, "user")
+	if !ok {
+		return importId, nil
+	}
+	diags := make(diag.Diagnostics, 0)
+	params := &iam.ListUsersParams{
+		Limit: lo.ToPtr(1000),
+	}
+	var ids []string
+	offset := 0
+	for {
+		params.Offset = &offset
+		users, err := iamClient.ListUsersWithResponse(ctx, organizationId, params)
+		if err != nil {
+			tflog.Error(ctx, "failed to list users to import", map[string]interface{}{"error": err})
+			diags.AddError("Client Error", fmt.Sprintf("Unable to list users, got error: %s", err))
+			return "", diags
+		}
+		_, diagnostic := clients.NormalizeAPIError(ctx, users.HTTPResponse, users.Body)
+		if diagnostic != nil {
+			diags.Append(diagnostic)
+			return "", diags
+		}
+		if users.JSON200 == nil {
+			diags.AddError("Client Error", "Unable to list users, got nil response")
+			return "", diags
+		}
+		ids = append(ids, lo.FilterMap(users.JSON200.Users, func(user iam.User, _ int) (string, bool) {
+			return user.Id, strings.EqualFold(user.Username, email)
+		})...)
+		offset += 1000
+		if users.JSON200.TotalCount <= offset {
+			return ImportMatch(importId, "user", ids)
+		}
+	}
+}
+
+// ImportApiTokenId returns the ID of the API token of an import ID, `api_token:<name>` import IDs are resolved by name.
+// The API does not filter API tokens by name, so all the API tokens of the organization are listed.
+func ImportApiTokenId(
+	ctx context.Context,
+	iamClient *iam.ClientWithResponses,
+	organizationId string,
+	importId string,
+) (string, diag.Diagnostics) {
+	name, ok := ParseImportId(importId, "api_token")
+	if !ok {
+		return importId, nil
+	}
+	diags := make(diag.Diagnostics, 0)
+	params := &iam.ListApiTokensParams{
+		Limit: lo.ToPtr(1000),
+	}
+	var ids []string
+	offset := 0
+	for {
+		params.Offset = &offset
+		apiTokens, err := iamClient.ListApiTokensWithResponse(ctx, organizationId, params)
+		if err != nil {
+			tflog.Error(ctx, "failed to list API tokens to import", map[string]interface{}{"error": err})
+			diags.AddError("Client Error", fmt.Sprintf("Unable to list API tokens, got error: %s", err))
+			return "", diags
+		}
+		_, diagnostic := clients.NormalizeAPIError(ctx, apiTokens.HTTPResponse, apiTokens.Body)
+		if diagnostic != nil {
+			diags.Append(diagnostic)
+			return "", diags
+		}
+		if apiTokens.JSON200 == nil {
+			diags.AddError("Client Error", "Unable to list API tokens, got nil response")
+			return "", diags
+		}
+		ids = append(ids, lo.FilterMap(apiTokens.JSON200.Tokens, func(apiToken iam.ApiToken, _ int) (string, bool) {
+			return apiToken.Id, apiToken.Name == name
+		})...)
+		offset += 1000
+		if apiTokens.JSON200.TotalCount <= offset {
+			return ImportMatch(importId, "API token", ids)
+		}
+	}
+}
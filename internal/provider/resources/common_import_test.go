@@ -0,0 +1,45 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_Import(t *testing.T) {
+	t.Run("parses import IDs with a prefix", func(t *testing.T) {
+		name, ok := resources.ParseImportId("team:data engineering", "team")
+		assert.True(t, ok)
+		assert.Equal(t, "data engineering", name)
+
+		name, ok = resources.ParseImportId("user:user@astronomer.io", "user")
+		assert.True(t, ok)
+		assert.Equal(t, "user@astronomer.io", name)
+
+		_, ok = resources.ParseImportId("clozc036j01to01jrlgvuf98d", "team")
+		assert.False(t, ok)
+		_, ok = resources.ParseImportId("cluster:production", "team")
+		assert.False(t, ok)
+	})
+
+	t.Run("imports the only match", func(t *testing.T) {
+		id, diags := resources.ImportMatch("team:data", "team", []string{"team1"})
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "team1", id)
+	})
+
+	t.Run("fails without a match", func(t *testing.T) {
+		_, diags := resources.ImportMatch("team:data", "team", nil)
+		assert.Len(t, diags.Errors(), 1)
+		assert.Equal(t, "Cannot import team", diags.Errors()[0].Summary())
+		assert.Contains(t, diags.Errors()[0].Detail(), "No team matches the import ID team:data")
+	})
+
+	t.Run("fails with several matches", func(t *testing.T) {
+		_, diags := resources.ImportMatch("prod/etl", "deployment", []string{"deployment2", "deployment1"})
+		assert.Len(t, diags.Errors(), 1)
+		assert.Equal(t, "Ambiguous import ID", diags.Errors()[0].Summary())
+		assert.Contains(t, diags.Errors()[0].Detail(), "2 deployments match the import ID prod/etl: deployment1, deployment2")
+	})
+}
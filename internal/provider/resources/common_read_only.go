@@ -0,0 +1,22 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// CheckReadOnly fails the Create, Update or Delete operation of a resource when the provider is in read-only mode. The
+// client transport already rejects the requests changing objects, failing before the operation starts also prevents
+// the partial changes of operations that read objects before changing them.
+func CheckReadOnly(readOnly bool, resourceType, operation string) diag.Diagnostics {
+	if !readOnly {
+		return nil
+	}
+	return diag.Diagnostics{diag.NewErrorDiagnostic(
+		"Provider is in read-only mode",
+		fmt.Sprintf("Cannot %v %v: %v", strings.ToLower(operation), resourceType, clients.ErrReadOnly),
+	)}
+}
@@ -0,0 +1,84 @@
+package resources_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_ReadOnly(t *testing.T) {
+	t.Run("allows changes outside of read-only mode", func(t *testing.T) {
+		assert.Empty(t, resources.CheckReadOnly(false, "astro_workspace", "Create"))
+	})
+
+	t.Run("fails changes in read-only mode", func(t *testing.T) {
+		diags := resources.CheckReadOnly(true, "astro_workspace", "Delete")
+		assert.Len(t, diags.Errors(), 1)
+		assert.Equal(t, "Provider is in read-only mode", diags.Errors()[0].Summary())
+		assert.Contains(t, diags.Errors()[0].Detail(), "Cannot delete astro_workspace")
+	})
+
+	t.Run("fails every change of every resource before sending requests", func(t *testing.T) {
+		ctx := context.Background()
+		var requests atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		platformClient, err := platform.NewPlatformClient(server.URL, clients.StaticToken("token"), "test")
+		assert.NoError(t, err)
+		iamClient, err := iam.NewIamClient(server.URL, clients.StaticToken("token"), "test")
+		assert.NoError(t, err)
+		apiClients := models.ApiClientsModel{
+			OrganizationId: "organization-id",
+			PlatformClient: platformClient,
+			IamClient:      iamClient,
+			Cache:          clients.NewResponseCache(),
+			ReadOnly:       true,
+		}
+
+		for _, newResource := range []func() resource.Resource{
+			resources.NewWorkspaceResource,
+			resources.NewDeploymentResource,
+			resources.NewClusterResource,
+			resources.NewTeamRolesResource,
+			resources.NewHybridClusterWorkspaceAuthorizationResource,
+			resources.NewApiTokenResource,
+			resources.NewTeamResource,
+			resources.NewUserRolesResource,
+			resources.NewUserInviteResource,
+		} {
+			r := newResource()
+			var metadata resource.MetadataResponse
+			r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "astro"}, &metadata)
+			configureResp := &resource.ConfigureResponse{}
+			r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: apiClients}, configureResp)
+			assert.False(t, configureResp.Diagnostics.HasError(), metadata.TypeName)
+
+			createResp := &resource.CreateResponse{}
+			r.Create(ctx, resource.CreateRequest{}, createResp)
+			updateResp := &resource.UpdateResponse{}
+			r.Update(ctx, resource.UpdateRequest{}, updateResp)
+			deleteResp := &resource.DeleteResponse{}
+			r.Delete(ctx, resource.DeleteRequest{}, deleteResp)
+			for _, diags := range []diag.Diagnostics{createResp.Diagnostics, updateResp.Diagnostics, deleteResp.Diagnostics} {
+				if assert.Len(t, diags.Errors(), 1, metadata.TypeName) {
+					assert.Equal(t, "Provider is in read-only mode", diags.Errors()[0].Summary(), metadata.TypeName)
+				}
+			}
+		}
+		assert.Zero(t, requests.Load())
+	})
+}
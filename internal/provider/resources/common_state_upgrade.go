@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// StateUpgrade upgrades the JSON state of a resource from a schema version to the next one in place, by adding,
+// renaming or removing its attributes
+type StateUpgrade func(state map[string]any)
+
+// StateUpgraders returns the state upgraders of a resource whose schema version is len(upgrades), upgrades[v] upgrades
+// the state of version v to version v+1. Terraform upgrades the state of a prior version to the current version in one
+// step, so the upgrader of each version applies all the upgrades after it.
+func StateUpgraders(upgrades ...StateUpgrade) map[int64]resource.StateUpgrader {
+	stateUpgraders := make(map[int64]resource.StateUpgrader, len(upgrades))
+	for version := range upgrades {
+		stateUpgraders[int64(version)] = jsonStateUpgrader(int64(version), upgrades[version:])
+	}
+	return stateUpgraders
+}
+
+// jsonStateUpgrader upgrades the raw JSON state of a version without a prior schema, so that the schemas of prior
+// versions do not have to be kept
+func jsonStateUpgrader(version int64, upgrades []StateUpgrade) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil || req.RawState.JSON == nil {
+				resp.Diagnostics.AddError(
+					"Unable to Upgrade Resource State",
+					fmt.Sprintf("The state of schema version %v is not in the JSON format, please refresh it with a version of Terraform that supports this provider first.", version),
+				)
+				return
+			}
+			upgraded, err := UpgradeStateJSON(req.RawState.JSON, upgrades...)
+			if err != nil {
+				tflog.Error(ctx, "failed to upgrade state", map[string]interface{}{"error": err, "version": version})
+				resp.Diagnostics.AddError(
+					"Unable to Upgrade Resource State",
+					fmt.Sprintf("Unable to upgrade the state of schema version %v, got error: %s", version, err),
+				)
+				return
+			}
+			tflog.Debug(ctx, fmt.Sprintf("upgraded state from schema version %v with %v upgrades", version, len(upgrades)))
+			resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+		},
+	}
+}
+
+// UpgradeStateJSON applies upgrades in order to the JSON state of a resource. Numbers are kept as they are in the state
+// so that their precision is not lost.
+func UpgradeStateJSON(rawState []byte, upgrades ...StateUpgrade) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(rawState))
+	decoder.UseNumber()
+	var state map[string]any
+	if err := decoder.Decode(&state); err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("state is not a JSON object")
+	}
+	for _, upgrade := range upgrades {
+		upgrade(state)
+	}
+	return json.Marshal(state)
+}
+
+// SetStateDefault sets an attribute that is absent or null in the state to its default value, so that an attribute
+// added with a default does not show as changed in the first plan after the upgrade
+func SetStateDefault(state map[string]any, attribute string, value any) {
+	if state[attribute] == nil {
+		state[attribute] = value
+	}
+}
@@ -0,0 +1,149 @@
+package resources_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+)
+
+// upgradeState upgrades the state fixture of a prior schema version with the state upgrader of the resource, and
+// returns the upgraded state with the current schema
+func upgradeState(t *testing.T, r resource.Resource, version int64, fixture string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError())
+
+	rawState, err := os.ReadFile(filepath.Join("testdata", "state", fixture))
+	assert.NoError(t, err)
+
+	stateUpgrader, ok := r.(resource.ResourceWithUpgradeState).UpgradeState(ctx)[version]
+	assert.True(t, ok, "no state upgrader for version %v", version)
+	resp := &resource.UpgradeStateResponse{}
+	stateUpgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: rawState}}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.NotNil(t, resp.DynamicValue)
+
+	value, err := resp.DynamicValue.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	assert.NoError(t, err)
+	return tfsdk.State{Schema: schemaResp.Schema, Raw: value}
+}
+
+func schemaVersion(r resource.Resource) int64 {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+	return schemaResp.Schema.Version
+}
+
+func TestUnit_StateUpgrade(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("applies the upgrades of each version in order", func(t *testing.T) {
+		upgrades := []resources.StateUpgrade{
+			func(state map[string]any) { state["v1"] = state["name"] },
+			func(state map[string]any) { delete(state, "name") },
+		}
+		upgraded, err := resources.UpgradeStateJSON([]byte(`{"name":"a","count":12345678901234567890}`), upgrades...)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"v1":"a","count":12345678901234567890}`, string(upgraded))
+
+		stateUpgraders := resources.StateUpgraders(upgrades...)
+		assert.Len(t, stateUpgraders, 2)
+		resp := &resource.UpgradeStateResponse{}
+		stateUpgraders[1].StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(`{"name":"a"}`)}}, resp)
+		assert.False(t, resp.Diagnostics.HasError())
+		assert.JSONEq(t, `{}`, string(resp.DynamicValue.JSON))
+	})
+
+	t.Run("sets defaults of absent and null attributes only", func(t *testing.T) {
+		state := map[string]any{"null": nil, "set": true}
+		resources.SetStateDefault(state, "absent", false)
+		resources.SetStateDefault(state, "null", false)
+		resources.SetStateDefault(state, "set", false)
+		assert.Equal(t, map[string]any{"absent": false, "null": false, "set": true}, state)
+	})
+
+	t.Run("fails on invalid state", func(t *testing.T) {
+		_, err := resources.UpgradeStateJSON([]byte(`[]`))
+		assert.Error(t, err)
+		_, err = resources.UpgradeStateJSON([]byte(`null`))
+		assert.Error(t, err)
+
+		resp := &resource.UpgradeStateResponse{}
+		resources.StateUpgraders(func(map[string]any) {})[0].StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{}}, resp)
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Nil(t, resp.DynamicValue)
+	})
+
+	t.Run("upgrades deployment state from version 0", func(t *testing.T) {
+		assert.Equal(t, int64(1), schemaVersion(resources.NewDeploymentResource()))
+		state := upgradeState(t, resources.NewDeploymentResource(), 0, "deployment_v0.json")
+
+		var data models.DeploymentResource
+		diags := state.Get(ctx, &data)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, "clwgh7e3n003401mxnlhw4cff", data.Id.ValueString())
+		assert.Equal(t, "standard", data.Name.ValueString())
+		assert.False(t, data.DeletionProtection.IsNull())
+		assert.False(t, data.DeletionProtection.ValueBool())
+		assert.Len(t, data.EnvironmentVariables.Elements(), 2)
+		assert.True(t, data.EnvironmentVariablesMap.IsNull())
+		assert.True(t, data.SecretEnvironmentVariables.IsNull())
+		assert.True(t, data.SecretEnvironmentVariablesHashes.IsNull())
+		assert.Len(t, data.WorkerQueues.Elements(), 1)
+		assert.Equal(t, int64(1), data.SchedulerReplicas.ValueInt64())
+
+		var scalingSpec models.DeploymentScalingSpec
+		diags = data.ScalingSpec.As(ctx, &scalingSpec, basetypes.ObjectAsOptions{})
+		assert.False(t, diags.HasError(), diags)
+		var hibernationSpec models.HibernationSpecResource
+		diags = scalingSpec.HibernationSpec.As(ctx, &hibernationSpec, basetypes.ObjectAsOptions{})
+		assert.False(t, diags.HasError(), diags)
+		assert.Len(t, hibernationSpec.Schedules.Elements(), 1)
+		assert.True(t, hibernationSpec.Timezone.IsNull())
+	})
+
+	t.Run("upgrades cluster state from version 0", func(t *testing.T) {
+		assert.Equal(t, int64(1), schemaVersion(resources.NewClusterResource()))
+		state := upgradeState(t, resources.NewClusterResource(), 0, "cluster_v0.json")
+
+		var data models.ClusterResource
+		diags := state.Get(ctx, &data)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, "clwgh8rls004201mxu3ykm6dw", data.Id.ValueString())
+		assert.Equal(t, "dedicated", data.Name.ValueString())
+		assert.False(t, data.DeletionProtection.IsNull())
+		assert.False(t, data.DeletionProtection.ValueBool())
+		assert.False(t, data.ForceDestroy.IsNull())
+		assert.False(t, data.ForceDestroy.ValueBool())
+		assert.True(t, data.ForceDestroyDeploymentNamePattern.IsNull())
+		assert.Len(t, data.NodePools.Elements(), 1)
+		assert.Len(t, data.WorkspaceIds.Elements(), 1)
+	})
+	t.Run("upgrades workspace state from version 0", func(t *testing.T) {
+		assert.Equal(t, int64(1), schemaVersion(resources.NewWorkspaceResource()))
+		state := upgradeState(t, resources.NewWorkspaceResource(), 0, "workspace_v0.json")
+
+		var data models.WorkspaceResource
+		diags := state.Get(ctx, &data)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, "clwgh6ymt002801mxr9e4m2ak", data.Id.ValueString())
+		assert.Equal(t, "data engineering", data.Name.ValueString())
+		assert.True(t, data.CicdEnforcedDefault.ValueBool())
+		assert.False(t, data.DeletionProtection.IsNull())
+		assert.False(t, data.DeletionProtection.ValueBool())
+		assert.False(t, data.ForceDestroy.IsNull())
+		assert.False(t, data.ForceDestroy.ValueBool())
+	})
+}
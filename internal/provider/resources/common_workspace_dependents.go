@@ -0,0 +1,132 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/samber/lo"
+)
+
+// WorkspaceDependents are the objects of a workspace that block its deletion
+type WorkspaceDependents struct {
+	Deployments []platform.Deployment
+	ApiTokens   []iam.ApiToken
+}
+
+func (d WorkspaceDependents) IsEmpty() bool {
+	return len(d.Deployments) == 0 && len(d.ApiTokens) == 0
+}
+
+// WorkspaceDependentsError returns the error of deleting a workspace that has dependents, with the inventory of what
+// blocks its deletion
+func WorkspaceDependentsError(workspaceId string, dependents WorkspaceDependents) diag.Diagnostic {
+	var inventory []string
+	for _, deployment := range dependents.Deployments {
+		inventory = append(inventory, fmt.Sprintf("  - deployment %v (%v)", deployment.Name, deployment.Id))
+	}
+	for _, apiToken := range dependents.ApiTokens {
+		inventory = append(inventory, fmt.Sprintf("  - workspace API token %v (%v)", apiToken.Name, apiToken.Id))
+	}
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root("force_destroy"),
+		"Workspace is not empty",
+		fmt.Sprintf(
+			"Workspace %v cannot be deleted while it has deployments or workspace API tokens, it has:\n%v\n\nPlease delete them or move the deployments to another workspace first, or set force_destroy to true and apply the change before destroying the workspace to delete them with it.",
+			workspaceId, strings.Join(inventory, "\n"),
+		),
+	)
+}
+
+// listWorkspaceDependents returns the deployments and workspace API tokens of a workspace. API tokens of other types
+// that have a role in the workspace are not dependents, their role is removed with the workspace.
+func (r *workspaceResource) listWorkspaceDependents(ctx context.Context, workspaceId string) (WorkspaceDependents, error) {
+	var dependents WorkspaceDependents
+
+	deploymentsParams := &platform.ListDeploymentsParams{
+		WorkspaceIds: &[]string{workspaceId},
+		Limit:        lo.ToPtr(1000),
+	}
+	offset := 0
+	for {
+		deploymentsParams.Offset = &offset
+		deployments, err := r.platformClient.ListDeploymentsWithResponse(ctx, r.organizationId, deploymentsParams)
+		if err != nil {
+			return dependents, err
+		}
+		_, diagnostic := clients.NormalizeAPIError(ctx, deployments.HTTPResponse, deployments.Body)
+		if diagnostic != nil {
+			return dependents, fmt.Errorf("%s", diagnostic.Detail())
+		}
+		if deployments.JSON200 == nil {
+			return dependents, fmt.Errorf("nil response")
+		}
+		dependents.Deployments = append(dependents.Deployments, deployments.JSON200.Deployments...)
+		offset += 1000
+		if deployments.JSON200.TotalCount <= offset {
+			break
+		}
+	}
+
+	apiTokensParams := &iam.ListApiTokensParams{
+		WorkspaceId: &workspaceId,
+		Limit:       lo.ToPtr(1000),
+	}
+	offset = 0
+	for {
+		apiTokensParams.Offset = &offset
+		apiTokens, err := r.iamClient.ListApiTokensWithResponse(ctx, r.organizationId, apiTokensParams)
+		if err != nil {
+			return dependents, err
+		}
+		_, diagnostic := clients.NormalizeAPIError(ctx, apiTokens.HTTPResponse, apiTokens.Body)
+		if diagnostic != nil {
+			return dependents, fmt.Errorf("%s", diagnostic.Detail())
+		}
+		if apiTokens.JSON200 == nil {
+			return dependents, fmt.Errorf("nil response")
+		}
+		dependents.ApiTokens = append(dependents.ApiTokens, lo.Filter(apiTokens.JSON200.Tokens, func(apiToken iam.ApiToken, _ int) bool {
+			return apiToken.Type == iam.ApiTokenTypeWORKSPACE
+		})...)
+		offset += 1000
+		if apiTokens.JSON200.TotalCount <= offset {
+			return dependents, nil
+		}
+	}
+}
+
+// deleteWorkspaceDependents deletes the deployments of a workspace and waits for them to disappear, then deletes its
+// workspace API tokens
+func (r *workspaceResource) deleteWorkspaceDependents(ctx context.Context, dependents WorkspaceDependents) diag.Diagnostics {
+	diags := DeleteDeployments(ctx, r.platformClient, r.cache, r.organizationId, dependents.Deployments)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, apiToken := range dependents.ApiTokens {
+		tflog.Info(ctx, fmt.Sprintf("deleting workspace API token %v (%v)", apiToken.Name, apiToken.Id))
+		deleted, err := r.iamClient.DeleteApiTokenWithResponse(ctx, r.organizationId, apiToken.Id)
+		if err != nil {
+			tflog.Error(ctx, "failed to delete workspace API token", map[string]interface{}{"error": err, "apiTokenId": apiToken.Id})
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to delete API token %v of the workspace, got error: %s", apiToken.Id, err),
+			)
+			return diags
+		}
+		statusCode, diagnostic := clients.NormalizeAPIError(ctx, deleted.HTTPResponse, deleted.Body)
+		if statusCode != http.StatusNotFound && diagnostic != nil {
+			diags.Append(diagnostic)
+			return diags
+		}
+	}
+	return diags
+}
@@ -0,0 +1,29 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_WorkspaceDependents(t *testing.T) {
+	t.Run("is empty without deployments and API tokens", func(t *testing.T) {
+		assert.True(t, resources.WorkspaceDependents{}.IsEmpty())
+		assert.False(t, resources.WorkspaceDependents{ApiTokens: []iam.ApiToken{{Id: "token"}}}.IsEmpty())
+		assert.False(t, resources.WorkspaceDependents{Deployments: []platform.Deployment{{Id: "deployment"}}}.IsEmpty())
+	})
+
+	t.Run("lists what blocks the deletion of the workspace", func(t *testing.T) {
+		diagnostic := resources.WorkspaceDependentsError("workspace", resources.WorkspaceDependents{
+			Deployments: []platform.Deployment{{Id: "deployment1", Name: "etl"}, {Id: "deployment2", Name: "ml"}},
+			ApiTokens:   []iam.ApiToken{{Id: "token", Name: "ci"}},
+		})
+		assert.Equal(t, "Workspace is not empty", diagnostic.Summary())
+		assert.Contains(t, diagnostic.Detail(), "Workspace workspace cannot be deleted")
+		assert.Contains(t, diagnostic.Detail(), "  - deployment etl (deployment1)\n  - deployment ml (deployment2)\n  - workspace API token ci (token)\n")
+		assert.Contains(t, diagnostic.Detail(), "set force_destroy to true")
+	})
+}
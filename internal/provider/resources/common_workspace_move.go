@@ -0,0 +1,142 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/samber/lo"
+)
+
+// ValidateClusterWorkspaceAuthorization checks that a deployment can be moved to a workspace on its cluster. Clusters
+// without authorized workspaces are available to all the workspaces of the organization.
+func ValidateClusterWorkspaceAuthorization(cluster *platform.Cluster, workspaceId string) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	workspaceIds := lo.FromPtr(cluster.WorkspaceIds)
+	if len(workspaceIds) == 0 || lo.Contains(workspaceIds, workspaceId) {
+		return diags
+	}
+	diags.AddAttributeError(
+		path.Root("workspace_id"),
+		"workspace_id is not authorized on the deployment cluster",
+		fmt.Sprintf(
+			"Please authorize workspace %v on cluster %v with astro_hybrid_cluster_workspace_authorization before moving the deployment, or choose one of the authorized workspaces: %v",
+			workspaceId, cluster.Id, strings.Join(workspaceIds, ", "),
+		),
+	)
+	return diags
+}
+
+// ValidateWorkspaceMoveRoles checks that the users and teams with a role on a deployment have a role in the workspace
+// the deployment moves to, so that they keep access to it. They are not given a role by the provider, since their roles
+// are managed by the astro_user_roles and astro_team_roles resources.
+func ValidateWorkspaceMoveRoles(users []iam.User, teams []iam.Team, deploymentId, workspaceId string) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	var subjects []string
+	for _, user := range users {
+		if needsWorkspaceRole(lo.FromPtr(user.WorkspaceRoles), lo.FromPtr(user.DeploymentRoles), deploymentId, workspaceId) {
+			subjects = append(subjects, fmt.Sprintf("user %v", user.Username))
+		}
+	}
+	for _, team := range teams {
+		if needsWorkspaceRole(lo.FromPtr(team.WorkspaceRoles), lo.FromPtr(team.DeploymentRoles), deploymentId, workspaceId) {
+			subjects = append(subjects, fmt.Sprintf("team %v", team.Name))
+		}
+	}
+	if len(subjects) == 0 {
+		return diags
+	}
+	sort.Strings(subjects)
+	diags.AddAttributeError(
+		path.Root("workspace_id"),
+		"Users and teams of the deployment have no role in workspace_id",
+		fmt.Sprintf(
+			"The following users and teams have a role on deployment %v but no role in workspace %v, and would lose access to the deployment once it is moved: %v. Please give them a role in the workspace, for example WORKSPACE_ACCESSOR with astro_user_roles and astro_team_roles, or remove their role on the deployment before moving it.",
+			deploymentId, workspaceId, strings.Join(subjects, ", "),
+		),
+	)
+	return diags
+}
+
+// needsWorkspaceRole returns whether a subject with a role on a deployment has no role in a workspace
+func needsWorkspaceRole(
+	workspaceRoles []iam.WorkspaceRole,
+	deploymentRoles []iam.DeploymentRole,
+	deploymentId string,
+	workspaceId string,
+) bool {
+	hasDeploymentRole := lo.ContainsBy(deploymentRoles, func(role iam.DeploymentRole) bool {
+		return role.DeploymentId == deploymentId
+	})
+	hasWorkspaceRole := lo.ContainsBy(workspaceRoles, func(role iam.WorkspaceRole) bool {
+		return role.WorkspaceId == workspaceId
+	})
+	return hasDeploymentRole && !hasWorkspaceRole
+}
+
+// listDeploymentUsers returns the users with a role on a deployment
+func (r *DeploymentResource) listDeploymentUsers(ctx context.Context, deploymentId string) ([]iam.User, error) {
+	params := &iam.ListUsersParams{
+		DeploymentId: &deploymentId,
+		Limit:        lo.ToPtr(1000),
+	}
+	var users []iam.User
+	offset := 0
+	for {
+		params.Offset = &offset
+		usersResp, err := r.iamClient.ListUsersWithResponse(ctx, r.organizationId, params)
+		if err != nil {
+			return nil, err
+		}
+		_, diagnostic := clients.NormalizeAPIError(ctx, usersResp.HTTPResponse, usersResp.Body)
+		if diagnostic != nil {
+			return nil, fmt.Errorf("%s", diagnostic.Detail())
+		}
+		if usersResp.JSON200 == nil {
+			return nil, fmt.Errorf("nil response")
+		}
+		users = append(users, usersResp.JSON200.Users...)
+		offset += 1000
+		if usersResp.JSON200.TotalCount <= offset {
+			return users, nil
+		}
+	}
+}
+
+// listDeploymentTeams returns the teams with a role on a deployment, the API does not filter teams by deployment
+func (r *DeploymentResource) listDeploymentTeams(ctx context.Context, deploymentId string) ([]iam.Team, error) {
+	params := &iam.ListTeamsParams{
+		Limit: lo.ToPtr(1000),
+	}
+	var teams []iam.Team
+	offset := 0
+	for {
+		params.Offset = &offset
+		teamsResp, err := r.iamClient.ListTeamsWithResponse(ctx, r.organizationId, params)
+		if err != nil {
+			return nil, err
+		}
+		_, diagnostic := clients.NormalizeAPIError(ctx, teamsResp.HTTPResponse, teamsResp.Body)
+		if diagnostic != nil {
+			return nil, fmt.Errorf("%s", diagnostic.Detail())
+		}
+		if teamsResp.JSON200 == nil {
+			return nil, fmt.Errorf("nil response")
+		}
+		teams = append(teams, lo.Filter(teamsResp.JSON200.Teams, func(team iam.Team, _ int) bool {
+			return lo.ContainsBy(lo.FromPtr(team.DeploymentRoles), func(role iam.DeploymentRole) bool {
+				return role.DeploymentId == deploymentId
+			})
+		})...)
+		offset += 1000
+		if teamsResp.JSON200.TotalCount <= offset {
+			return teams, nil
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
+	"github.com/astronomer/terraform-provider-astro/internal/provider/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_WorkspaceMove(t *testing.T) {
+	t.Run("allows workspaces authorized on the cluster", func(t *testing.T) {
+		cluster := &platform.Cluster{Id: "cluster", WorkspaceIds: &[]string{"old", "new"}}
+		assert.False(t, resources.ValidateClusterWorkspaceAuthorization(cluster, "new").HasError())
+
+		cluster = &platform.Cluster{Id: "cluster"}
+		assert.False(t, resources.ValidateClusterWorkspaceAuthorization(cluster, "new").HasError())
+	})
+
+	t.Run("fails workspaces not authorized on the cluster", func(t *testing.T) {
+		cluster := &platform.Cluster{Id: "cluster", WorkspaceIds: &[]string{"old"}}
+		diags := resources.ValidateClusterWorkspaceAuthorization(cluster, "new")
+		assert.Len(t, diags.Errors(), 1)
+		assert.Equal(t, "workspace_id is not authorized on the deployment cluster", diags.Errors()[0].Summary())
+		assert.Contains(t, diags.Errors()[0].Detail(), "Please authorize workspace new on cluster cluster")
+	})
+
+	t.Run("fails moves of subjects of the deployment without a role in the new workspace", func(t *testing.T) {
+		deploymentRoles := &[]iam.DeploymentRole{{DeploymentId: "deployment", Role: "DEPLOYMENT_ADMIN"}}
+		users := []iam.User{
+			{Id: "user-1", Username: "user1@astronomer.io", DeploymentRoles: deploymentRoles, WorkspaceRoles: &[]iam.WorkspaceRole{{WorkspaceId: "old", Role: iam.WORKSPACEMEMBER}}},
+			{Id: "user-2", Username: "user2@astronomer.io", DeploymentRoles: deploymentRoles, WorkspaceRoles: &[]iam.WorkspaceRole{{WorkspaceId: "new", Role: iam.WORKSPACEOWNER}}},
+		}
+		teams := []iam.Team{{Id: "team", Name: "data", DeploymentRoles: deploymentRoles}}
+
+		diags := resources.ValidateWorkspaceMoveRoles(users, teams, "deployment", "new")
+		assert.Len(t, diags.Errors(), 1)
+		assert.Equal(t, "Users and teams of the deployment have no role in workspace_id", diags.Errors()[0].Summary())
+		assert.Contains(t, diags.Errors()[0].Detail(), "have a role on deployment deployment but no role in workspace new")
+		assert.Contains(t, diags.Errors()[0].Detail(), "team data, user user1@astronomer.io.")
+		assert.NotContains(t, diags.Errors()[0].Detail(), "user2")
+	})
+
+	t.Run("allows moves of subjects with a role in the new workspace or without a role on the deployment", func(t *testing.T) {
+		users := []iam.User{
+			{Id: "user-1", Username: "user1@astronomer.io", DeploymentRoles: &[]iam.DeploymentRole{{DeploymentId: "deployment", Role: "DEPLOYMENT_ADMIN"}}, WorkspaceRoles: &[]iam.WorkspaceRole{{WorkspaceId: "new", Role: iam.WORKSPACEACCESSOR}}},
+			{Id: "user-2", Username: "user2@astronomer.io", DeploymentRoles: &[]iam.DeploymentRole{{DeploymentId: "other", Role: "DEPLOYMENT_ADMIN"}}},
+			{Id: "user-3", Username: "user3@astronomer.io"},
+		}
+		assert.False(t, resources.ValidateWorkspaceMoveRoles(users, nil, "deployment", "new").HasError())
+		assert.False(t, resources.ValidateWorkspaceMoveRoles(nil, nil, "deployment", "new").HasError())
+	})
+}
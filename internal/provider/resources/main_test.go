@@ -0,0 +1,12 @@
+package resources_test
+
+import (
+	"os"
+	"testing"
+
+	astronomerprovider "github.com/astronomer/terraform-provider-astro/internal/provider"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(astronomerprovider.RunAcceptanceTests(m))
+}
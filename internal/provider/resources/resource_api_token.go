@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
 	"github.com/astronomer/terraform-provider-astro/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -38,6 +40,8 @@ type ApiTokenResource struct {
 	IamClient      *iam.ClientWithResponses
 	PlatformClient *platform.ClientWithResponses
 	OrganizationId string
+	ReadOnly       bool
+	Cache          *clients.ResponseCache
 }
 
 func (r *ApiTokenResource) Metadata(
@@ -59,6 +63,7 @@ func (r *ApiTokenResource) Schema(
 		Attributes:          schemas.ApiTokenResourceSchemaAttributes(),
 	}
 }
+
 func (r *ApiTokenResource) Configure(
 	ctx context.Context,
 	req resource.ConfigureRequest,
@@ -78,6 +83,9 @@ func (r *ApiTokenResource) Configure(
 	r.IamClient = apiClients.IamClient
 	r.PlatformClient = apiClients.PlatformClient
 	r.OrganizationId = apiClients.OrganizationId
+	r.ReadOnly = apiClients.ReadOnly
+	r.Cache = apiClients.Cache
+	resp.Diagnostics.Append(apiClients.CallerIdentity.CheckScope("astro_api_token", iam.ApiTokenTypeWORKSPACE)...)
 }
 
 func (r *ApiTokenResource) Create(
@@ -86,6 +94,13 @@ func (r *ApiTokenResource) Create(
 	resp *resource.CreateResponse,
 ) {
 	var data models.ApiTokenResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_api_token", tracing.OperationCreate, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.ReadOnly, "astro_api_token", tracing.OperationCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -237,6 +252,8 @@ func (r *ApiTokenResource) Read(
 	resp *resource.ReadResponse,
 ) {
 	var data models.ApiTokenResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_api_token", tracing.OperationRead, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -290,6 +307,13 @@ func (r *ApiTokenResource) Update(
 	resp *resource.UpdateResponse,
 ) {
 	var data, currentState models.ApiTokenResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_api_token", tracing.OperationUpdate, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.ReadOnly, "astro_api_token", tracing.OperationUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -438,6 +462,13 @@ func (r *ApiTokenResource) Delete(
 	resp *resource.DeleteResponse,
 ) {
 	var data models.ApiTokenResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_api_token", tracing.OperationDelete, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.ReadOnly, "astro_api_token", tracing.OperationDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -470,11 +501,18 @@ func (r *ApiTokenResource) Delete(
 	tflog.Trace(ctx, fmt.Sprintf("deleted an API token resource: %v", data.Id.ValueString()))
 }
 
+// ImportState imports an API token by ID, or by name with a `api_token:<name>` import ID
 func (r *ApiTokenResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
+	importId, diags := ImportApiTokenId(ctx, r.IamClient, r.OrganizationId, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.ID = importId
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -648,11 +686,14 @@ func (r *ApiTokenResource) HasValidWorkspaces(ctx context.Context, workspaceRole
 	}
 
 	// List organization workspaces
-	workspaces, err := r.PlatformClient.ListWorkspacesWithResponse(
-		ctx,
-		r.OrganizationId,
-		&listWorkspacesRequest,
-	)
+	cacheKey := clients.CacheKey(clients.WorkspacesCacheKeyPrefix, r.OrganizationId, strings.Join(workspaceIds, ","))
+	workspaces, err := clients.CachedResponse(ctx, r.Cache, cacheKey, clients.ListCacheTTL, func() (*platform.ListWorkspacesResponse, error) {
+		return r.PlatformClient.ListWorkspacesWithResponse(
+			ctx,
+			r.OrganizationId,
+			&listWorkspacesRequest,
+		)
+	})
 	if err != nil {
 		tflog.Error(ctx, "failed to list workspaces", map[string]interface{}{"error": err})
 		return diag.Diagnostics{
@@ -700,11 +741,14 @@ func (r *ApiTokenResource) HasValidDeployments(ctx context.Context, deploymentRo
 	}
 
 	// List organization deployments
-	deployments, err := r.PlatformClient.ListDeploymentsWithResponse(
-		ctx,
-		r.OrganizationId,
-		&listDeploymentsRequest,
-	)
+	cacheKey := clients.CacheKey(clients.DeploymentsCacheKeyPrefix, r.OrganizationId, strings.Join(deploymentIds, ","))
+	deployments, err := clients.CachedResponse(ctx, r.Cache, cacheKey, clients.ListCacheTTL, func() (*platform.ListDeploymentsResponse, error) {
+		return r.PlatformClient.ListDeploymentsWithResponse(
+			ctx,
+			r.OrganizationId,
+			&listDeploymentsRequest,
+		)
+	})
 	if err != nil {
 		tflog.Error(ctx, "failed to list deployments", map[string]interface{}{"error": err})
 		return diag.Diagnostics{
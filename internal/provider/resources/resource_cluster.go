@@ -4,28 +4,40 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"regexp"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 
 	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
 	"github.com/astronomer/terraform-provider-astro/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/samber/lo"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ClusterResource{}
 var _ resource.ResourceWithImportState = &ClusterResource{}
 var _ resource.ResourceWithConfigure = &ClusterResource{}
+var _ resource.ResourceWithUpgradeState = &ClusterResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterResource{}
 var _ resource.ResourceWithValidateConfig = &ClusterResource{}
 
+// clusterStateUpgrades upgrade the state of each prior schema version to the next one, the schema version is the
+// number of upgrades
+var clusterStateUpgrades = []StateUpgrade{
+	upgradeClusterStateV0,
+}
+
 func NewClusterResource() resource.Resource {
 	return &ClusterResource{}
 }
@@ -34,6 +46,8 @@ func NewClusterResource() resource.Resource {
 type ClusterResource struct {
 	platformClient *platform.ClientWithResponses
 	organizationId string
+	readOnly       bool
+	cache          *clients.ResponseCache
 }
 
 func (r *ClusterResource) Metadata(
@@ -52,10 +66,23 @@ func (r *ClusterResource) Schema(
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Cluster resource. If creating multiple clusters, add a delay between each cluster creation to avoid cluster creation limiting errors.",
+		Version:             int64(len(clusterStateUpgrades)),
 		Attributes:          schemas.ClusterResourceSchemaAttributes(ctx),
 	}
 }
 
+// UpgradeState upgrades the state of the prior schema versions of the cluster to the current version
+func (r *ClusterResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return StateUpgraders(clusterStateUpgrades...)
+}
+
+// upgradeClusterStateV0 upgrades the state of the clusters created before the schema was versioned, which did not
+// have deletion_protection and force_destroy
+func upgradeClusterStateV0(state map[string]any) {
+	SetStateDefault(state, "deletion_protection", false)
+	SetStateDefault(state, "force_destroy", false)
+}
+
 func (r *ClusterResource) Configure(
 	ctx context.Context,
 	req resource.ConfigureRequest,
@@ -74,6 +101,9 @@ func (r *ClusterResource) Configure(
 
 	r.platformClient = apiClients.PlatformClient
 	r.organizationId = apiClients.OrganizationId
+	r.readOnly = apiClients.ReadOnly
+	r.cache = apiClients.Cache
+	resp.Diagnostics.Append(apiClients.CallerIdentity.CheckScope("astro_cluster", iam.ApiTokenTypeORGANIZATION)...)
 }
 
 func (r *ClusterResource) Create(
@@ -82,6 +112,13 @@ func (r *ClusterResource) Create(
 	resp *resource.CreateResponse,
 ) {
 	var data models.ClusterResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_cluster", tracing.OperationCreate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_cluster", tracing.OperationCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -244,6 +281,8 @@ func (r *ClusterResource) Read(
 	resp *resource.ReadResponse,
 ) {
 	var data models.ClusterResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_cluster", tracing.OperationRead, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -296,6 +335,13 @@ func (r *ClusterResource) Update(
 	resp *resource.UpdateResponse,
 ) {
 	var data models.ClusterResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_cluster", tracing.OperationUpdate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_cluster", tracing.OperationUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -396,12 +442,23 @@ func (r *ClusterResource) Delete(
 	resp *resource.DeleteResponse,
 ) {
 	var data models.ClusterResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_cluster", tracing.OperationDelete, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_cluster", tracing.OperationDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.Append(DeletionProtectionError("astro_cluster", data.Id.ValueString(), "it is destroyed or replaced"))
+		return
+	}
 
 	// Create the timeout context for the cluster delete
 	deleteTimeout, diags := data.Timeouts.Delete(ctx, 1*time.Hour)
@@ -412,6 +469,37 @@ func (r *ClusterResource) Delete(
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
+	// The deployments of the cluster are deleted with it when force_destroy is true, otherwise they block its deletion
+	deployments, err := r.listClusterDeployments(ctx, data.Id.ValueString())
+	if err != nil {
+		tflog.Error(ctx, "failed to list cluster deployments", map[string]interface{}{"error": err})
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to list the deployments of the cluster, got error: %s", err),
+		)
+		return
+	}
+	if len(deployments) > 0 {
+		if !data.ForceDestroy.ValueBool() {
+			resp.Diagnostics.Append(ClusterDeploymentsError(data.Id.ValueString(), deployments, ""))
+			return
+		}
+		var namePattern *regexp.Regexp
+		if !data.ForceDestroyDeploymentNamePattern.IsNull() {
+			namePattern = regexp.MustCompile(data.ForceDestroyDeploymentNamePattern.ValueString())
+		}
+		forceDestroyed, others := PartitionDeploymentsByName(deployments, namePattern)
+		if len(others) > 0 {
+			resp.Diagnostics.Append(ClusterDeploymentsError(data.Id.ValueString(), others, data.ForceDestroyDeploymentNamePattern.ValueString()))
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("deleting the %v deployments of cluster %v before deleting it", len(forceDestroyed), data.Id.ValueString()))
+		resp.Diagnostics.Append(DeleteDeployments(ctx, r.platformClient, r.cache, r.organizationId, forceDestroyed)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// delete request
 	cluster, err := r.platformClient.DeleteClusterWithResponse(
 		ctx,
@@ -451,12 +539,86 @@ func (r *ClusterResource) Delete(
 	tflog.Trace(ctx, fmt.Sprintf("deleted a cluster resource: %v", data.Id.ValueString()))
 }
 
+// ImportState imports a cluster by ID, or by name with a `cluster:<name>` import ID
 func (r *ClusterResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
+	importId, diags := ImportClusterId(ctx, r.platformClient, r.organizationId, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.ID = importId
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// deletion_protection and force_destroy are not stored by the API, they have their default value until they are set
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+}
+
+// ModifyPlan fails the plans deleting or replacing the resource while its deletion_protection is true, and checks the
+// region of the cluster against the cluster options
+func (r *ClusterResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	ModifyPlanDeletionProtection(ctx, "astro_cluster", req, resp)
+	// Clusters are not validated again when they are destroyed or unchanged
+	if req.Plan.Raw.IsNull() || req.Plan.Raw.Equal(req.State.Raw) || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data models.ClusterResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Cluster creates take hours before failing, so the cluster options are checked when planning. The provider is not
+	// configured when validating the configuration.
+	if r.platformClient == nil || !isKnown(data.Type) || !isKnown(data.CloudProvider) {
+		return
+	}
+	clusterOptions, diagnostic := r.GetClusterOptions(ctx, &data)
+	if diagnostic != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to validate cluster options",
+			fmt.Sprintf("The configuration of the cluster will be validated when it is applied: %v", diagnostic.Detail()),
+		)
+		return
+	}
+	resp.Diagnostics.Append(ValidateClusterOptions(&data, clusterOptions)...)
+}
+
+// GetClusterOptions returns the cluster options of the type and cloud provider of a cluster
+func (r *ClusterResource) GetClusterOptions(ctx context.Context, data *models.ClusterResource) ([]platform.ClusterOptions, diag.Diagnostic) {
+	cacheKey := clients.CacheKey(clients.ClusterOptionsCacheKeyPrefix, r.organizationId, data.Type.ValueString(), data.CloudProvider.ValueString())
+	clusterOptions, err := clients.CachedResponse(ctx, r.cache, cacheKey, clients.ClusterOptionsCacheTTL, func() (*platform.GetClusterOptionsResponse, error) {
+		return r.platformClient.GetClusterOptionsWithResponse(ctx, r.organizationId, &platform.GetClusterOptionsParams{
+			Type:     platform.GetClusterOptionsParamsType(data.Type.ValueString()),
+			Provider: lo.ToPtr(platform.GetClusterOptionsParamsProvider(data.CloudProvider.ValueString())),
+		})
+	})
+	if err != nil {
+		tflog.Error(ctx, "failed to get cluster options", map[string]interface{}{"error": err})
+		return nil, diag.NewErrorDiagnostic(
+			"Client Error",
+			fmt.Sprintf("Unable to get cluster options, got error: %s", err),
+		)
+	}
+	_, diagnostic := clients.NormalizeAPIError(ctx, clusterOptions.HTTPResponse, clusterOptions.Body)
+	if diagnostic != nil {
+		return nil, diagnostic
+	}
+	if clusterOptions.JSON200 == nil {
+		return nil, diag.NewErrorDiagnostic(
+			"Client Error",
+			"Unable to get cluster options, got empty cluster options",
+		)
+	}
+	return *clusterOptions.JSON200, nil
 }
 
 // ValidateConfig validates the configuration of the resource as a whole before any operations are performed.
@@ -473,8 +635,10 @@ func (r *ClusterResource) ValidateConfig(
 		return
 	}
 
+	resp.Diagnostics.Append(ValidateClusterSubnetRanges(&data)...)
+
 	// Cloud provider specific validation
-	switch platform.ClusterCloudProvider(data.Type.ValueString()) {
+	switch platform.ClusterCloudProvider(data.CloudProvider.ValueString()) {
 	case platform.ClusterCloudProviderAWS:
 		resp.Diagnostics.Append(validateAwsConfig(ctx, &data)...)
 	case platform.ClusterCloudProviderAZURE:
@@ -566,7 +730,7 @@ func validateGcpConfig(ctx context.Context, data *models.ClusterResource) diag.D
 	// Unallowed values
 	if !data.TenantId.IsNull() {
 		diags.AddError(
-			"tenant_id is not allowed for 'AWS' cluster",
+			"tenant_id is not allowed for 'GCP' cluster",
 			"Please remove tenant_id",
 		)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -392,6 +393,63 @@ func TestAcc_ResourceClusterRemovedOutsideOfTerraform(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceClusterForceDestroy(t *testing.T) {
+	if os.Getenv(SKIP_CLUSTER_RESOURCE_TESTS) == "True" {
+		t.Skip(SKIP_CLUSTER_RESOURCE_TESTS_REASON)
+	}
+	namePrefix := utils.GenerateTestResourceName(10)
+	workspaceName := fmt.Sprintf("%v_workspace", namePrefix)
+	clusterName := fmt.Sprintf("%v_cluster", namePrefix)
+	deploymentName := fmt.Sprintf("%v-ephemeral", namePrefix)
+	clusterResource := fmt.Sprintf("astro_cluster.%v", clusterName)
+	input := clusterInput{
+		Name:                               clusterName,
+		Region:                             "us-east-1",
+		CloudProvider:                      "AWS",
+		RestrictedWorkspaceResourceVarName: fmt.Sprintf("astro_workspace.%v", workspaceName),
+	}
+	workspaceConfig := workspace(workspaceName, workspaceName, utils.TestResourceDescription, false)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: astronomerprovider.TestAccProtoV6ProviderFactories,
+		PreCheck:                 func() { astronomerprovider.TestAccPreCheck(t) },
+		CheckDestroy:             testAccCheckClusterExistence(t, clusterName, true, false),
+		Steps: []resource.TestStep{
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + workspaceConfig + clusterWithForceDestroy(input, false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(clusterResource, "force_destroy", "false"),
+					testAccCheckClusterExistence(t, clusterName, true, true),
+				),
+			},
+			// A cluster with a deployment is not deleted, the deployment is listed in the error
+			{
+				PreConfig:   func() { createDedicatedDeploymentOutsideOfTerraform(t, clusterName, workspaceName, deploymentName) },
+				Config:      astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + workspaceConfig,
+				ExpectError: regexp.MustCompile(fmt.Sprintf("(?s)Cluster is not empty.*deployment %v", deploymentName)),
+			},
+			// Deployments that do not match the name pattern are not deleted
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + workspaceConfig + clusterWithForceDestroy(input, true, "-keep$"),
+				Check:  resource.TestCheckResourceAttr(clusterResource, "force_destroy", "true"),
+			},
+			{
+				Config:      astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + workspaceConfig,
+				ExpectError: regexp.MustCompile("Cluster has deployments that are not force destroyed"),
+			},
+			// The cluster is deleted with the deployments matching the name pattern
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + workspaceConfig + clusterWithForceDestroy(input, true, "-ephemeral$"),
+				Check:  resource.TestCheckResourceAttr(clusterResource, "force_destroy_deployment_name_pattern", "-ephemeral$"),
+			},
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + workspaceConfig,
+				Check:  testAccCheckClusterExistence(t, clusterName, true, false),
+			},
+		},
+	})
+}
+
 type dedicatedDeploymentInput struct {
 	ClusterResourceVar   string
 	WorkspaceResourceVar string
@@ -455,6 +513,15 @@ func cluster(input clusterInput) string {
 `, input.Name, input.Name, input.Region, input.CloudProvider, gcpNetworkFields, workspaceId)
 }
 
+func clusterWithForceDestroy(input clusterInput, forceDestroy bool, deploymentNamePattern string) string {
+	forceDestroyFields := fmt.Sprintf("force_destroy = %t", forceDestroy)
+	if deploymentNamePattern != "" {
+		forceDestroyFields += fmt.Sprintf(`
+	force_destroy_deployment_name_pattern = "%v"`, deploymentNamePattern)
+	}
+	return strings.Replace(cluster(input), "workspace_ids =", forceDestroyFields+"\n\tworkspace_ids =", 1)
+}
+
 func clusterWithVariableName(input clusterInput) string {
 	tfConfig := fmt.Sprintf(`
 variable "name" {
@@ -485,6 +552,47 @@ func deleteClusterOutsideOfTerraform(t *testing.T, name string) {
 	assert.NoError(t, err)
 }
 
+// createDedicatedDeploymentOutsideOfTerraform creates a dedicated deployment in the cluster and workspace with these names
+func createDedicatedDeploymentOutsideOfTerraform(t *testing.T, clusterName, workspaceName, deploymentName string) {
+	t.Helper()
+
+	client, err := utils.GetTestHostedPlatformClient()
+	assert.NoError(t, err)
+
+	organizationId := os.Getenv("HOSTED_ORGANIZATION_ID")
+
+	ctx := context.Background()
+	clusters, err := client.ListClustersWithResponse(ctx, organizationId, &platform.ListClustersParams{
+		Names: &[]string{clusterName},
+	})
+	assert.NoError(t, err)
+	assert.True(t, len(clusters.JSON200.Clusters) >= 1, "cluster should exist but list clusters did not find it")
+	workspaces, err := client.ListWorkspacesWithResponse(ctx, organizationId, &platform.ListWorkspacesParams{
+		Names: &[]string{workspaceName},
+	})
+	assert.NoError(t, err)
+	assert.True(t, len(workspaces.JSON200.Workspaces) >= 1, "workspace should exist but list workspaces did not find it")
+
+	var req platform.CreateDeploymentRequest
+	assert.NoError(t, req.FromCreateDedicatedDeploymentRequest(platform.CreateDedicatedDeploymentRequest{
+		AstroRuntimeVersion:  "11.5.0",
+		ClusterId:            clusters.JSON200.Clusters[0].Id,
+		DefaultTaskPodCpu:    "0.25",
+		DefaultTaskPodMemory: "0.5Gi",
+		Executor:             platform.CreateDedicatedDeploymentRequestExecutorKUBERNETES,
+		IsCicdEnforced:       true,
+		Name:                 deploymentName,
+		ResourceQuotaCpu:     "10",
+		ResourceQuotaMemory:  "20Gi",
+		SchedulerSize:        platform.CreateDedicatedDeploymentRequestSchedulerSizeSMALL,
+		Type:                 platform.CreateDedicatedDeploymentRequestTypeDEDICATED,
+		WorkspaceId:          workspaces.JSON200.Workspaces[0].Id,
+	}))
+	deployment, err := client.CreateDeploymentWithResponse(ctx, organizationId, req)
+	assert.NoError(t, err)
+	assert.NotNil(t, deployment.JSON200, "deployment should be created")
+}
+
 func testAccCheckClusterExistence(t *testing.T, name string, isHosted, shouldExist bool) func(state *terraform.State) error {
 	t.Helper()
 	return func(state *terraform.State) error {
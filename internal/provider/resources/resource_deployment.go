@@ -2,8 +2,11 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -12,9 +15,11 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
 	"github.com/astronomer/terraform-provider-astro/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -26,8 +31,16 @@ import (
 var _ resource.Resource = &DeploymentResource{}
 var _ resource.ResourceWithImportState = &DeploymentResource{}
 var _ resource.ResourceWithConfigure = &DeploymentResource{}
+var _ resource.ResourceWithUpgradeState = &DeploymentResource{}
+var _ resource.ResourceWithModifyPlan = &DeploymentResource{}
 var _ resource.ResourceWithValidateConfig = &DeploymentResource{}
 
+// deploymentStateUpgrades upgrade the state of each prior schema version to the next one, the schema version is the
+// number of upgrades
+var deploymentStateUpgrades = []StateUpgrade{
+	upgradeDeploymentStateV0,
+}
+
 func NewDeploymentResource() resource.Resource {
 	return &DeploymentResource{}
 }
@@ -35,7 +48,10 @@ func NewDeploymentResource() resource.Resource {
 // DeploymentResource defines the resource implementation.
 type DeploymentResource struct {
 	platformClient *platform.ClientWithResponses
+	iamClient      *iam.ClientWithResponses
 	organizationId string
+	readOnly       bool
+	cache          *clients.ResponseCache
 }
 
 func (r *DeploymentResource) Metadata(
@@ -54,10 +70,22 @@ func (r *DeploymentResource) Schema(
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Deployment resource",
+		Version:             int64(len(deploymentStateUpgrades)),
 		Attributes:          schemas.DeploymentResourceSchemaAttributes(),
 	}
 }
 
+// UpgradeState upgrades the state of the prior schema versions of the deployment to the current version
+func (r *DeploymentResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return StateUpgraders(deploymentStateUpgrades...)
+}
+
+// upgradeDeploymentStateV0 upgrades the state of the deployments created before the schema was versioned, which did
+// not have deletion_protection
+func upgradeDeploymentStateV0(state map[string]any) {
+	SetStateDefault(state, "deletion_protection", false)
+}
+
 func (r *DeploymentResource) Configure(
 	ctx context.Context,
 	req resource.ConfigureRequest,
@@ -75,7 +103,11 @@ func (r *DeploymentResource) Configure(
 	}
 
 	r.platformClient = apiClients.PlatformClient
+	r.iamClient = apiClients.IamClient
 	r.organizationId = apiClients.OrganizationId
+	r.readOnly = apiClients.ReadOnly
+	r.cache = apiClients.Cache
+	resp.Diagnostics.Append(apiClients.CallerIdentity.CheckScope("astro_deployment", iam.ApiTokenTypeWORKSPACE)...)
 }
 
 func (r *DeploymentResource) Create(
@@ -84,9 +116,17 @@ func (r *DeploymentResource) Create(
 	resp *resource.CreateResponse,
 ) {
 	var data models.DeploymentResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_deployment", tracing.OperationCreate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_deployment", tracing.OperationCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan data into the model, the secret values are write-only and are only in the configuration
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_environment_variables"), &data.SecretEnvironmentVariables)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -137,7 +177,7 @@ func (r *DeploymentResource) Create(
 		}
 
 		// env vars
-		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, data.EnvironmentVariables)
+		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, &data)
 		if diags.HasError() {
 			resp.Diagnostics.Append(diags...)
 			return
@@ -197,7 +237,7 @@ func (r *DeploymentResource) Create(
 		}
 
 		// env vars
-		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, data.EnvironmentVariables)
+		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, &data)
 		if diags.HasError() {
 			resp.Diagnostics.Append(diags...)
 			return
@@ -255,7 +295,7 @@ func (r *DeploymentResource) Create(
 		}
 
 		// env vars
-		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, data.EnvironmentVariables)
+		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, &data)
 		if diags.HasError() {
 			resp.Diagnostics.Append(diags...)
 			return
@@ -285,6 +325,7 @@ func (r *DeploymentResource) Create(
 		r.organizationId,
 		createDeploymentRequest,
 	)
+	r.cache.Invalidate(ctx, clients.DeploymentsCacheKeyPrefix)
 	if err != nil {
 		tflog.Error(ctx, "failed to create deployment", map[string]interface{}{"error": err})
 		resp.Diagnostics.AddError(
@@ -304,6 +345,7 @@ func (r *DeploymentResource) Create(
 		resp.Diagnostics.Append(diags...)
 		return
 	}
+	resp.Diagnostics.Append(writeSecretEnvironmentVariablesUpdatedAt(ctx, resp.Private, deployment.JSON200)...)
 
 	tflog.Trace(ctx, fmt.Sprintf("created a deployment resource: %v", data.Id.ValueString()))
 
@@ -317,6 +359,8 @@ func (r *DeploymentResource) Read(
 	resp *resource.ReadResponse,
 ) {
 	var data models.DeploymentResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_deployment", tracing.OperationRead, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -324,7 +368,7 @@ func (r *DeploymentResource) Read(
 		return
 	}
 
-	envVars, diags := RequestDeploymentEnvironmentVariables(ctx, data.EnvironmentVariables)
+	envVars, diags := RequestDeploymentEnvironmentVariables(ctx, &data)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
@@ -356,11 +400,21 @@ func (r *DeploymentResource) Read(
 		return
 	}
 
+	// Secrets changed outside of Terraform are left out of the state so that the plan applies them again
+	secretsUpdatedAt, diags := readSecretEnvironmentVariablesUpdatedAt(ctx, req.Private)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	envVars = WithoutChangedSecrets(envVars, deployment.JSON200, secretsUpdatedAt)
+	data.SecretEnvironmentVariablesHashes = WithoutChangedSecretHashes(data.SecretEnvironmentVariablesHashes, deployment.JSON200, secretsUpdatedAt)
+
 	diags = data.ReadFromResponse(ctx, deployment.JSON200, data.OriginalAstroRuntimeVersion.ValueStringPointer(), &envVars)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
+	resp.Diagnostics.Append(writeSecretEnvironmentVariablesUpdatedAt(ctx, resp.Private, deployment.JSON200)...)
 
 	tflog.Trace(ctx, fmt.Sprintf("read a deployment resource: %v", data.Id.ValueString()))
 
@@ -374,9 +428,17 @@ func (r *DeploymentResource) Update(
 	resp *resource.UpdateResponse,
 ) {
 	var data models.DeploymentResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_deployment", tracing.OperationUpdate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_deployment", tracing.OperationUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan data into the model, the secret values are write-only and are only in the configuration
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_environment_variables"), &data.SecretEnvironmentVariables)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -414,7 +476,7 @@ func (r *DeploymentResource) Update(
 		}
 
 		// env vars
-		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, data.EnvironmentVariables)
+		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, &data)
 		if diags.HasError() {
 			resp.Diagnostics.Append(diags...)
 			return
@@ -472,7 +534,7 @@ func (r *DeploymentResource) Update(
 		}
 
 		// env vars
-		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, data.EnvironmentVariables)
+		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, &data)
 		if diags.HasError() {
 			resp.Diagnostics.Append(diags...)
 			return
@@ -528,7 +590,7 @@ func (r *DeploymentResource) Update(
 		}
 
 		// env vars
-		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, data.EnvironmentVariables)
+		envVars, diags = RequestDeploymentEnvironmentVariables(ctx, &data)
 		if diags.HasError() {
 			resp.Diagnostics.Append(diags...)
 			return
@@ -559,6 +621,7 @@ func (r *DeploymentResource) Update(
 		data.Id.ValueString(),
 		updateDeploymentRequest,
 	)
+	r.cache.Invalidate(ctx, clients.DeploymentsCacheKeyPrefix)
 	if err != nil {
 		tflog.Error(ctx, "failed to update deployment", map[string]interface{}{"error": err})
 		resp.Diagnostics.AddError(
@@ -578,6 +641,7 @@ func (r *DeploymentResource) Update(
 		resp.Diagnostics.Append(diags...)
 		return
 	}
+	resp.Diagnostics.Append(writeSecretEnvironmentVariablesUpdatedAt(ctx, resp.Private, deployment.JSON200)...)
 
 	tflog.Trace(ctx, fmt.Sprintf("updated a deployment resource: %v", data.Id.ValueString()))
 
@@ -591,6 +655,13 @@ func (r *DeploymentResource) Delete(
 	resp *resource.DeleteResponse,
 ) {
 	var data models.DeploymentResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_deployment", tracing.OperationDelete, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_deployment", tracing.OperationDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -598,6 +669,10 @@ func (r *DeploymentResource) Delete(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.Append(DeletionProtectionError("astro_deployment", data.Id.ValueString(), "it is destroyed or replaced"))
+		return
+	}
 
 	// delete request
 	deployment, err := r.platformClient.DeleteDeploymentWithResponse(
@@ -605,6 +680,7 @@ func (r *DeploymentResource) Delete(
 		r.organizationId,
 		data.Id.ValueString(),
 	)
+	r.cache.Invalidate(ctx, clients.DeploymentsCacheKeyPrefix)
 	if err != nil {
 		tflog.Error(ctx, "failed to delete deployment", map[string]interface{}{"error": err})
 		resp.Diagnostics.AddError(
@@ -623,12 +699,193 @@ func (r *DeploymentResource) Delete(
 	tflog.Trace(ctx, fmt.Sprintf("deleted a deployment resource: %v", data.Id.ValueString()))
 }
 
+// ImportState imports a deployment by ID, or by name with a `<workspace name>/<deployment name>` import ID
 func (r *DeploymentResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
+	importId, diags := ImportDeploymentId(ctx, r.platformClient, r.organizationId, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.ID = importId
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// deletion_protection is not stored by the API, imported resources are not protected until it is set
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+}
+
+// ModifyPlan fails the plans deleting or replacing the resource while its deletion_protection is true, checks that
+// a deployment can move to another workspace in place, and checks the sizing of hosted deployments against the
+// deployment options
+func (r *DeploymentResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Deletion protection is checked last, once the replacements of the plan are known
+	defer ModifyPlanDeletionProtection(ctx, "astro_deployment", req, resp)
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(modifyPlanSecretEnvironmentVariablesHashes(ctx, req, resp)...)
+	}
+	// Deployments are not validated again when they are destroyed or unchanged
+	if req.Plan.Raw.IsNull() || req.Plan.Raw.Equal(req.State.Raw) || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data models.DeploymentResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		r.modifyPlanWorkspaceMove(ctx, req, resp, &data)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The sizing of hosted deployments is checked against the deployment options when planning, so that invalid values
+	// do not fail halfway through an apply. The provider is not configured when validating the configuration.
+	switch platform.DeploymentType(data.Type.ValueString()) {
+	case platform.DeploymentTypeSTANDARD, platform.DeploymentTypeDEDICATED:
+	default:
+		return
+	}
+	if r.platformClient == nil || !isKnown(data.Executor) || data.CloudProvider.IsUnknown() {
+		return
+	}
+	deploymentOptions, diagnostic := r.GetDeploymentOptions(ctx, &data)
+	if diagnostic != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to validate deployment options",
+			fmt.Sprintf("The sizing of the deployment will be validated when it is applied: %v", diagnostic.Detail()),
+		)
+		return
+	}
+	resp.Diagnostics.Append(ValidateDeploymentOptions(ctx, &data, deploymentOptions)...)
+}
+
+// modifyPlanSecretEnvironmentVariablesHashes plans the hashes of the write-only secret_environment_variables, so that
+// a plan updates the deployment when a secret is changed in the configuration
+func modifyPlanSecretEnvironmentVariablesHashes(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) diag.Diagnostics {
+	var secrets types.Map
+	diags := req.Config.GetAttribute(ctx, path.Root("secret_environment_variables"), &secrets)
+	priorHashes := types.MapNull(types.StringType)
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.GetAttribute(ctx, path.Root("secret_environment_variables_hashes"), &priorHashes)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+	hashes, hashesDiags := PlanSecretEnvironmentVariablesHashes(secrets, priorHashes)
+	diags.Append(hashesDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_environment_variables_hashes"), hashes)...)
+	return diags
+}
+
+// modifyPlanWorkspaceMove plans moving a deployment to another workspace in place. The plan fails when the workspace
+// is not authorized on the cluster of the deployment, or when users and teams with a role on the deployment have no role
+// in the workspace. The deployment is replaced when the move cannot be validated, like before moves were supported.
+func (r *DeploymentResource) modifyPlanWorkspaceMove(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+	data *models.DeploymentResource,
+) {
+	var priorWorkspaceId types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("workspace_id"), &priorWorkspaceId)...)
+	if resp.Diagnostics.HasError() || priorWorkspaceId.Equal(data.WorkspaceId) {
+		return
+	}
+	// The provider is not configured yet, the move is validated when the plan is made again on apply
+	if r.platformClient == nil || r.iamClient == nil {
+		return
+	}
+
+	diags := make(diag.Diagnostics, 0)
+	if !isKnown(data.WorkspaceId) {
+		diags.AddAttributeWarning(
+			path.Root("workspace_id"),
+			"Deployment is replaced to move it to another workspace",
+			"The new workspace is not known when planning, for example because it is created in the same apply, so the move cannot be validated. Create the workspace first to move the deployment in place.",
+		)
+	} else {
+		if data.Type.ValueString() != string(platform.DeploymentTypeSTANDARD) {
+			diags.Append(r.validateWorkspaceMoveCluster(ctx, data)...)
+		}
+		if len(diags) == 0 {
+			diags.Append(r.validateWorkspaceMoveRoles(ctx, data)...)
+		}
+	}
+	resp.Diagnostics.Append(diags...)
+	// The move cannot be validated, the deployment is replaced in the new workspace
+	if !diags.HasError() && diags.WarningsCount() > 0 {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("workspace_id"))
+	}
+}
+
+// validateWorkspaceMoveCluster checks that the workspace of the plan is authorized on the cluster of the deployment, a
+// warning is added when the cluster cannot be read
+func (r *DeploymentResource) validateWorkspaceMoveCluster(ctx context.Context, data *models.DeploymentResource) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	if !isKnown(data.ClusterId) {
+		return diags
+	}
+	cluster, err := r.platformClient.GetClusterWithResponse(ctx, r.organizationId, data.ClusterId.ValueString())
+	if err != nil {
+		tflog.Error(ctx, "failed to get cluster", map[string]interface{}{"error": err})
+		diags.AddAttributeWarning(
+			path.Root("workspace_id"),
+			"Deployment is replaced to move it to another workspace",
+			fmt.Sprintf("Unable to read the workspace authorizations of cluster %v to move the deployment in place, got error: %s", data.ClusterId.ValueString(), err),
+		)
+		return diags
+	}
+	_, diagnostic := clients.NormalizeAPIError(ctx, cluster.HTTPResponse, cluster.Body)
+	if diagnostic != nil || cluster.JSON200 == nil {
+		detail := "got nil response"
+		if diagnostic != nil {
+			detail = diagnostic.Detail()
+		}
+		diags.AddAttributeWarning(
+			path.Root("workspace_id"),
+			"Deployment is replaced to move it to another workspace",
+			fmt.Sprintf("Unable to read the workspace authorizations of cluster %v to move the deployment in place: %v", data.ClusterId.ValueString(), detail),
+		)
+		return diags
+	}
+	return ValidateClusterWorkspaceAuthorization(cluster.JSON200, data.WorkspaceId.ValueString())
+}
+
+// validateWorkspaceMoveRoles checks that the users and teams with a role on the deployment have a role in the workspace
+// of the plan, a warning is added when they cannot be listed
+func (r *DeploymentResource) validateWorkspaceMoveRoles(ctx context.Context, data *models.DeploymentResource) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0)
+	users, err := r.listDeploymentUsers(ctx, data.Id.ValueString())
+	var teams []iam.Team
+	if err == nil {
+		teams, err = r.listDeploymentTeams(ctx, data.Id.ValueString())
+	}
+	if err != nil {
+		tflog.Error(ctx, "failed to list deployment users and teams", map[string]interface{}{"error": err})
+		diags.AddAttributeWarning(
+			path.Root("workspace_id"),
+			"Deployment is replaced to move it to another workspace",
+			fmt.Sprintf("Unable to list the users and teams with a role on deployment %v to move it in place, got error: %s", data.Id.ValueString(), err),
+		)
+		return diags
+	}
+	return ValidateWorkspaceMoveRoles(users, teams, data.Id.ValueString(), data.WorkspaceId.ValueString())
 }
 
 // ValidateConfig validates the configuration of the resource as a whole before any operations are performed.
@@ -659,6 +916,8 @@ func (r *DeploymentResource) ValidateConfig(
 		)
 	}
 
+	resp.Diagnostics.Append(ValidateEnvironmentVariablesMaps(&data)...)
+
 	// Type specific validation
 	switch platform.DeploymentType(data.Type.ValueString()) {
 	case platform.DeploymentTypeSTANDARD:
@@ -893,7 +1152,7 @@ func validateHostedConfig(ctx context.Context, data *models.DeploymentResource)
 		}
 
 		// scalingSpec.HibernationSpec is required if ScalingSpec is set via schemas/deployment.go
-		var hibernationSpec models.HibernationSpec
+		var hibernationSpec models.HibernationSpecResource
 		diags = scalingSpec.HibernationSpec.As(ctx, &hibernationSpec, basetypes.ObjectAsOptions{
 			UnhandledNullAsEmpty:    true,
 			UnhandledUnknownAsEmpty: true,
@@ -909,6 +1168,7 @@ func validateHostedConfig(ctx context.Context, data *models.DeploymentResource)
 			)
 			return diags
 		}
+		diags = append(diags, ValidateHibernationSchedules(ctx, hibernationSpec, time.Now())...)
 	}
 
 	// Need to check worker_queues for hosted deployments have `astro_machine` and do not have `node_pool_id`
@@ -994,7 +1254,7 @@ func RequestScalingSpec(ctx context.Context, scalingSpecObj types.Object) (*plat
 		platformScalingSpec.HibernationSpec = nil
 		return platformScalingSpec, nil
 	}
-	var hibernationSpec models.HibernationSpec
+	var hibernationSpec models.HibernationSpecResource
 	diags = scalingSpec.HibernationSpec.As(ctx, &hibernationSpec, basetypes.ObjectAsOptions{
 		UnhandledNullAsEmpty:    true,
 		UnhandledUnknownAsEmpty: true,
@@ -1031,14 +1291,32 @@ func RequestScalingSpec(ctx context.Context, scalingSpecObj types.Object) (*plat
 			tflog.Error(ctx, "failed to convert hibernation schedules", map[string]interface{}{"error": diags})
 			return nil, diags
 		}
-		requestSchedules := lo.Map(schedules, func(schedule models.HibernationSchedule, _ int) platform.DeploymentHibernationSchedule {
-			return platform.DeploymentHibernationSchedule{
+		// the API evaluates the cron expressions in UTC
+		location := time.UTC
+		if !hibernationSpec.Timezone.IsNull() {
+			var err error
+			if location, err = time.LoadLocation(hibernationSpec.Timezone.ValueString()); err != nil {
+				diags.AddAttributeError(path.Root("scaling_spec").AtName("hibernation_spec").AtName("timezone"), "Invalid timezone", err.Error())
+				return nil, diags
+			}
+		}
+		now := time.Now()
+		requestSchedules := make([]platform.DeploymentHibernationSchedule, 0, len(schedules))
+		for _, schedule := range schedules {
+			hibernateAtCron, hibernateErr := hibernationScheduleCron(schedule.HibernateAtCron.ValueString(), location, now)
+			wakeAtCron, wakeErr := hibernationScheduleCron(schedule.WakeAtCron.ValueString(), location, now)
+			if err := errors.Join(hibernateErr, wakeErr); err != nil {
+				tflog.Error(ctx, "failed to translate hibernation schedule to UTC", map[string]interface{}{"error": err})
+				diags.AddAttributeError(path.Root("scaling_spec").AtName("hibernation_spec").AtName("schedules"), "Invalid hibernation schedule", err.Error())
+				return nil, diags
+			}
+			requestSchedules = append(requestSchedules, platform.DeploymentHibernationSchedule{
 				Description:     schedule.Description.ValueStringPointer(),
-				HibernateAtCron: schedule.HibernateAtCron.ValueString(),
+				HibernateAtCron: hibernateAtCron,
 				IsEnabled:       schedule.IsEnabled.ValueBool(),
-				WakeAtCron:      schedule.WakeAtCron.ValueString(),
-			}
-		})
+				WakeAtCron:      wakeAtCron,
+			})
+		}
 		platformScalingSpec.HibernationSpec.Schedules = &requestSchedules
 	}
 
@@ -1093,14 +1371,43 @@ func RequestHybridWorkerQueues(ctx context.Context, workerQueuesObjSet types.Set
 	return &platformWorkerQueues, nil
 }
 
-// RequestDeploymentEnvironmentVariables converts a Terraform set to a list of platform.DeploymentEnvironmentVariableRequest to be used in create and update requests
-func RequestDeploymentEnvironmentVariables(ctx context.Context, environmentVariablesObjSet types.Set) ([]platform.DeploymentEnvironmentVariableRequest, diag.Diagnostics) {
-	if len(environmentVariablesObjSet.Elements()) == 0 {
+// RequestDeploymentEnvironmentVariables converts the environment_variables set, or the environment_variables_map and
+// secret_environment_variables maps, to a list of platform.DeploymentEnvironmentVariableRequest to be used in create
+// and update requests
+func RequestDeploymentEnvironmentVariables(ctx context.Context, data *models.DeploymentResource) ([]platform.DeploymentEnvironmentVariableRequest, diag.Diagnostics) {
+	if data.EnvironmentVariables.IsNull() {
+		var envVarsMap, secretEnvVarsMap map[string]string
+		diags := data.EnvironmentVariablesMap.ElementsAs(ctx, &envVarsMap, false)
+		diags.Append(data.SecretEnvironmentVariables.ElementsAs(ctx, &secretEnvVarsMap, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		platformEnvVars := make([]platform.DeploymentEnvironmentVariableRequest, 0, len(envVarsMap)+len(secretEnvVarsMap))
+		for _, key := range lo.Keys(envVarsMap) {
+			platformEnvVars = append(platformEnvVars, platform.DeploymentEnvironmentVariableRequest{
+				IsSecret: false,
+				Key:      key,
+				Value:    lo.ToPtr(envVarsMap[key]),
+			})
+		}
+		for _, key := range lo.Keys(secretEnvVarsMap) {
+			platformEnvVars = append(platformEnvVars, platform.DeploymentEnvironmentVariableRequest{
+				IsSecret: true,
+				Key:      key,
+				Value:    lo.ToPtr(secretEnvVarsMap[key]),
+			})
+		}
+		sort.Slice(platformEnvVars, func(i, j int) bool {
+			return platformEnvVars[i].Key < platformEnvVars[j].Key
+		})
+		return platformEnvVars, nil
+	}
+	if len(data.EnvironmentVariables.Elements()) == 0 {
 		return []platform.DeploymentEnvironmentVariableRequest{}, nil
 	}
 
 	var envVars []models.DeploymentEnvironmentVariable
-	diags := environmentVariablesObjSet.ElementsAs(ctx, &envVars, false)
+	diags := data.EnvironmentVariables.ElementsAs(ctx, &envVars, false)
 	if diags.HasError() {
 		return nil, diags
 	}
@@ -1115,27 +1422,45 @@ func RequestDeploymentEnvironmentVariables(ctx context.Context, environmentVaria
 }
 
 func (r *DeploymentResource) GetLatestAstroRuntimeVersion(ctx context.Context, data *models.DeploymentResource) (string, diag.Diagnostic) {
-	deploymentOptions, err := r.platformClient.GetDeploymentOptionsWithResponse(ctx, r.organizationId, &platform.GetDeploymentOptionsParams{
-		DeploymentType: lo.ToPtr(platform.GetDeploymentOptionsParamsDeploymentType(data.Type.ValueString())),
-		Executor:       lo.ToPtr(platform.GetDeploymentOptionsParamsExecutor(data.Executor.ValueString())),
-		CloudProvider:  lo.ToPtr(platform.GetDeploymentOptionsParamsCloudProvider(data.CloudProvider.ValueString())),
+	deploymentOptions, diagnostic := r.GetDeploymentOptions(ctx, data)
+	if diagnostic != nil {
+		return "", diagnostic
+	}
+	if len(deploymentOptions.RuntimeReleases) == 0 {
+		return "", diag.NewErrorDiagnostic(
+			"Client Error",
+			"Unable to get runtime releases for deployment creation, got empty runtime releases",
+		)
+	}
+	return deploymentOptions.RuntimeReleases[0].Version, nil
+}
+
+// GetDeploymentOptions returns the deployment options of the type, executor and cloud provider of a deployment
+func (r *DeploymentResource) GetDeploymentOptions(ctx context.Context, data *models.DeploymentResource) (*platform.DeploymentOptions, diag.Diagnostic) {
+	cacheKey := clients.CacheKey(clients.DeploymentOptionsCacheKeyPrefix, r.organizationId, data.Type.ValueString(), data.Executor.ValueString(), data.CloudProvider.ValueString())
+	deploymentOptions, err := clients.CachedResponse(ctx, r.cache, cacheKey, clients.DeploymentOptionsCacheTTL, func() (*platform.GetDeploymentOptionsResponse, error) {
+		return r.platformClient.GetDeploymentOptionsWithResponse(ctx, r.organizationId, &platform.GetDeploymentOptionsParams{
+			DeploymentType: lo.ToPtr(platform.GetDeploymentOptionsParamsDeploymentType(data.Type.ValueString())),
+			Executor:       lo.ToPtr(platform.GetDeploymentOptionsParamsExecutor(data.Executor.ValueString())),
+			CloudProvider:  lo.ToPtr(platform.GetDeploymentOptionsParamsCloudProvider(data.CloudProvider.ValueString())),
+		})
 	})
 	if err != nil {
 		tflog.Error(ctx, "failed to get deployment options", map[string]interface{}{"error": err})
-		return "", diag.NewErrorDiagnostic(
+		return nil, diag.NewErrorDiagnostic(
 			"Client Error",
-			fmt.Sprintf("Unable to get deployment options for deployment creation, got error: %s", err),
+			fmt.Sprintf("Unable to get deployment options, got error: %s", err),
 		)
 	}
 	_, diagnostic := clients.NormalizeAPIError(ctx, deploymentOptions.HTTPResponse, deploymentOptions.Body)
 	if diagnostic != nil {
-		return "", diagnostic
+		return nil, diagnostic
 	}
-	if deploymentOptions.JSON200 == nil || len(deploymentOptions.JSON200.RuntimeReleases) == 0 {
-		return "", diag.NewErrorDiagnostic(
+	if deploymentOptions.JSON200 == nil {
+		return nil, diag.NewErrorDiagnostic(
 			"Client Error",
-			"Unable to get runtime releases for deployment creation, got empty runtime releases",
+			"Unable to get deployment options, got empty deployment options",
 		)
 	}
-	return deploymentOptions.JSON200.RuntimeReleases[0].Version, nil
+	return deploymentOptions.JSON200, nil
 }
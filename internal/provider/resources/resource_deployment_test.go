@@ -269,6 +269,14 @@ func TestAcc_ResourceDeploymentStandard(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"external_ips", "environment_variables.1.value", "scaling_status.%", "scaling_status.hibernation_status.%", "scaling_status.hibernation_status.is_hibernating", "scaling_status.hibernation_status.reason"}, // environment_variables.1.value is a secret value
 			},
+			// Import existing deployment by the names of its workspace and itself, the workspace has the name of the deployment
+			{
+				ResourceName:            awsResourceVar,
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("%v/%v", awsDeploymentName, awsDeploymentName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"external_ips", "environment_variables.1.value", "scaling_status.%", "scaling_status.hibernation_status.%", "scaling_status.hibernation_status.is_hibernating", "scaling_status.hibernation_status.reason"},
+			},
 		},
 	})
 
@@ -507,6 +515,137 @@ func TestAcc_ResourceDeploymentStandardScalingSpec(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceDeploymentEnvironmentVariablesMap(t *testing.T) {
+	namePrefix := utils.GenerateTestResourceName(10)
+
+	deploymentName := fmt.Sprintf("%v_env_vars_map", namePrefix)
+	resourceVar := fmt.Sprintf("astro_deployment.%v", deploymentName)
+	deployment := func(environmentVariables string) string {
+		return strings.Replace(standardDeployment(standardDeploymentInput{
+			Name:          deploymentName,
+			Description:   utils.TestResourceDescription,
+			Region:        "us-east4",
+			CloudProvider: "GCP",
+			Executor:      "KUBERNETES",
+			SchedulerSize: string(platform.SchedulerMachineNameSMALL),
+		}), envVarsStr(false), environmentVariables, 1)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: astronomerprovider.TestAccProtoV6ProviderFactories,
+		PreCheck:                 func() { astronomerprovider.TestAccPreCheck(t) },
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDeploymentExistence(t, deploymentName, true, false),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + deployment(`
+					environment_variables = []
+					environment_variables_map = { key1 = "value1" }`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + deployment(`
+					environment_variables_map = { key1 = "value1" }
+					secret_environment_variables = { key1 = "secret1" }`),
+				ExpectError: regexp.MustCompile(`environment variable keys must be unique`),
+			},
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + deployment(`
+					environment_variables_map = { key1 = "value1", key2 = "value2" }
+					secret_environment_variables = { secret1 = "secret1" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceVar, "environment_variables.#"),
+					resource.TestCheckResourceAttr(resourceVar, "environment_variables_map.%", "2"),
+					resource.TestCheckResourceAttr(resourceVar, "environment_variables_map.key1", "value1"),
+					resource.TestCheckNoResourceAttr(resourceVar, "secret_environment_variables.secret1"),
+					resource.TestCheckResourceAttrWith(resourceVar, "secret_environment_variables_hashes.secret1", testAccCheckSecretHash("secret1")),
+					testAccCheckDeploymentExistence(t, deploymentName, true, true),
+				),
+			},
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + deployment(`
+					environment_variables_map = { key1 = "value1", key2 = "new_value2" }
+					secret_environment_variables = { secret1 = "new_secret1" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceVar, "environment_variables_map.key2", "new_value2"),
+					resource.TestCheckNoResourceAttr(resourceVar, "secret_environment_variables.secret1"),
+					resource.TestCheckResourceAttrWith(resourceVar, "secret_environment_variables_hashes.secret1", testAccCheckSecretHash("new_secret1")),
+				),
+			},
+			// Import an existing deployment, the environment variables are imported in the environment_variables set
+			{
+				ResourceName:            resourceVar,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"external_ips", "oidc_issuer_url", "image_version", "scaling_status", "environment_variables", "environment_variables_map", "secret_environment_variables_hashes"},
+			},
+		},
+	})
+}
+
+func TestAcc_ResourceDeploymentWorkspaceMove(t *testing.T) {
+	namePrefix := utils.GenerateTestResourceName(10)
+
+	deploymentName := fmt.Sprintf("%v_workspace_move", namePrefix)
+	resourceVar := fmt.Sprintf("astro_deployment.%v", deploymentName)
+	deployment := standardDeployment(standardDeploymentInput{
+		Name:          deploymentName,
+		Description:   utils.TestResourceDescription,
+		Region:        "us-east4",
+		CloudProvider: "GCP",
+		Executor:      "KUBERNETES",
+		SchedulerSize: string(platform.SchedulerMachineNameSMALL),
+	})
+	// The new workspace is created before the move, so that the move can be validated when planning
+	newWorkspace := fmt.Sprintf(`
+resource "astro_workspace" "%v_new_workspace" {
+	name = "%v_new"
+	description = "%v"
+	cicd_enforced_default = true
+}`, deploymentName, deploymentName, utils.TestResourceDescription)
+	movedDeployment := strings.Replace(deployment,
+		fmt.Sprintf("workspace_id = astro_workspace.%v_workspace.id", deploymentName),
+		fmt.Sprintf("workspace_id = astro_workspace.%v_new_workspace.id", deploymentName), 1) + newWorkspace
+
+	var deploymentId string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: astronomerprovider.TestAccProtoV6ProviderFactories,
+		PreCheck:                 func() { astronomerprovider.TestAccPreCheck(t) },
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDeploymentExistence(t, deploymentName, true, false),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + deployment + newWorkspace,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith(resourceVar, "id", func(value string) error {
+						deploymentId = value
+						return nil
+					}),
+					testAccCheckDeploymentExistence(t, deploymentName, true, true),
+				),
+			},
+			// Moving the deployment to another workspace updates it in place
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + movedDeployment,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(resourceVar, plancheck.ResourceActionUpdate)},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceVar, "workspace_id", fmt.Sprintf("astro_workspace.%v_new_workspace", deploymentName), "id"),
+					resource.TestCheckResourceAttrWith(resourceVar, "id", func(value string) error {
+						if value != deploymentId {
+							return fmt.Errorf("deployment %v was replaced by %v", deploymentId, value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
 func TestAcc_ResourceDeploymentStandardRemovedOutsideOfTerraform(t *testing.T) {
 	standardDeploymentName := utils.GenerateTestResourceName(10)
 	standardDeploymentResource := fmt.Sprintf("astro_deployment.%v", standardDeploymentName)
@@ -828,3 +967,13 @@ func testAccCheckDeploymentExistence(t *testing.T, name string, isHosted, should
 		return nil
 	}
 }
+
+// testAccCheckSecretHash checks that a hash in the state is the hash of the secret, the secret itself is never in the state
+func testAccCheckSecretHash(secret string) resource.CheckResourceAttrWithFunc {
+	return func(hash string) error {
+		if !utils.SecretMatchesHash(secret, hash) {
+			return fmt.Errorf("hash %s is not the hash of the secret", hash)
+		}
+		return nil
+	}
+}
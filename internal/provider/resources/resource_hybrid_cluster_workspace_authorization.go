@@ -9,9 +9,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 
 	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
 	"github.com/astronomer/terraform-provider-astro/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -32,6 +34,7 @@ func NewHybridClusterWorkspaceAuthorizationResource() resource.Resource {
 type hybridClusterWorkspaceAuthorizationResource struct {
 	platformClient *platform.ClientWithResponses
 	organizationId string
+	readOnly       bool
 }
 
 func (r *hybridClusterWorkspaceAuthorizationResource) Metadata(
@@ -72,6 +75,8 @@ func (r *hybridClusterWorkspaceAuthorizationResource) Configure(
 
 	r.platformClient = apiClients.PlatformClient
 	r.organizationId = apiClients.OrganizationId
+	r.readOnly = apiClients.ReadOnly
+	resp.Diagnostics.Append(apiClients.CallerIdentity.CheckScope("astro_hybrid_cluster_workspace_authorization", iam.ApiTokenTypeORGANIZATION)...)
 }
 
 func (r *hybridClusterWorkspaceAuthorizationResource) MutateRoles(
@@ -150,6 +155,13 @@ func (r *hybridClusterWorkspaceAuthorizationResource) Create(
 	resp *resource.CreateResponse,
 ) {
 	var data models.HybridClusterWorkspaceAuthorizationResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_hybrid_cluster_workspace_authorization", tracing.OperationCreate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.ClusterId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_hybrid_cluster_workspace_authorization", tracing.OperationCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -174,6 +186,8 @@ func (r *hybridClusterWorkspaceAuthorizationResource) Read(
 	resp *resource.ReadResponse,
 ) {
 	var data models.HybridClusterWorkspaceAuthorizationResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_hybrid_cluster_workspace_authorization", tracing.OperationRead, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.ClusterId, resp.Diagnostics) }()
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -217,6 +231,13 @@ func (r *hybridClusterWorkspaceAuthorizationResource) Update(
 	resp *resource.UpdateResponse,
 ) {
 	var data models.HybridClusterWorkspaceAuthorizationResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_hybrid_cluster_workspace_authorization", tracing.OperationUpdate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.ClusterId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_hybrid_cluster_workspace_authorization", tracing.OperationUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -242,6 +263,13 @@ func (r *hybridClusterWorkspaceAuthorizationResource) Delete(
 	resp *resource.DeleteResponse,
 ) {
 	var data models.HybridClusterWorkspaceAuthorizationResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_hybrid_cluster_workspace_authorization", tracing.OperationDelete, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.ClusterId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_hybrid_cluster_workspace_authorization", tracing.OperationDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -309,10 +337,17 @@ func (r *hybridClusterWorkspaceAuthorizationResource) Delete(
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// ImportState imports the workspace authorizations of a cluster by ID, or by name with a `cluster:<name>` import ID
 func (r *hybridClusterWorkspaceAuthorizationResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
+	importId, diags := ImportClusterId(ctx, r.platformClient, r.organizationId, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.ID = importId
 	resource.ImportStatePassthroughID(ctx, path.Root("cluster_id"), req, resp)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
 	"github.com/astronomer/terraform-provider-astro/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -36,6 +37,8 @@ type TeamResource struct {
 	IamClient      *iam.ClientWithResponses
 	PlatformClient *platform.ClientWithResponses
 	OrganizationId string
+	ReadOnly       bool
+	Cache          *clients.ResponseCache
 }
 
 func (r *TeamResource) Metadata(
@@ -77,6 +80,9 @@ func (r *TeamResource) Configure(
 	r.IamClient = apiClients.IamClient
 	r.PlatformClient = apiClients.PlatformClient
 	r.OrganizationId = apiClients.OrganizationId
+	r.ReadOnly = apiClients.ReadOnly
+	r.Cache = apiClients.Cache
+	resp.Diagnostics.Append(apiClients.CallerIdentity.CheckScope("astro_team", iam.ApiTokenTypeORGANIZATION)...)
 }
 
 func (r *TeamResource) MutateRoles(
@@ -98,6 +104,7 @@ func (r *TeamResource) MutateRoles(
 	diags = common.ValidateWorkspaceDeploymentRoles(ctx, common.ValidateWorkspaceDeploymentRolesInput{
 		PlatformClient:  r.PlatformClient,
 		OrganizationId:  r.OrganizationId,
+		Cache:           r.Cache,
 		WorkspaceRoles:  workspaceRoles,
 		DeploymentRoles: deploymentRoles,
 	})
@@ -140,6 +147,13 @@ func (r *TeamResource) Create(
 	resp *resource.CreateResponse,
 ) {
 	var data models.TeamResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_team", tracing.OperationCreate, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.ReadOnly, "astro_team", tracing.OperationCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -256,6 +270,8 @@ func (r *TeamResource) Read(
 	resp *resource.ReadResponse,
 ) {
 	var data models.TeamResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_team", tracing.OperationRead, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -315,6 +331,13 @@ func (r *TeamResource) Update(
 	resp *resource.UpdateResponse,
 ) {
 	var data models.TeamResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_team", tracing.OperationUpdate, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.ReadOnly, "astro_team", tracing.OperationUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -411,6 +434,13 @@ func (r *TeamResource) Delete(
 	resp *resource.DeleteResponse,
 ) {
 	var data models.TeamResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_team", tracing.OperationDelete, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.ReadOnly, "astro_team", tracing.OperationDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -443,11 +473,18 @@ func (r *TeamResource) Delete(
 	tflog.Trace(ctx, fmt.Sprintf("deleted a Team resource: %v", data.Id.ValueString()))
 }
 
+// ImportState imports a team by ID, or by name with a `team:<name>` import ID
 func (r *TeamResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
+	importId, diags := ImportTeamId(ctx, r.IamClient, r.OrganizationId, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.ID = importId
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -518,7 +555,10 @@ func (r *TeamResource) ValidateConfig(
 
 func (r *TeamResource) CheckOrganizationIsScim(ctx context.Context) diag.Diagnostics {
 	// Validate if org isScimEnabled and return error if it is
-	org, err := r.PlatformClient.GetOrganizationWithResponse(ctx, r.OrganizationId, nil)
+	cacheKey := clients.CacheKey(clients.OrganizationCacheKeyPrefix, r.OrganizationId)
+	org, err := clients.CachedResponse(ctx, r.Cache, cacheKey, clients.OrganizationCacheTTL, func() (*platform.GetOrganizationResponse, error) {
+		return r.PlatformClient.GetOrganizationWithResponse(ctx, r.OrganizationId, nil)
+	})
 	if err != nil {
 		tflog.Error(ctx, "failed to validate Team", map[string]interface{}{"error": err})
 		return diag.Diagnostics{
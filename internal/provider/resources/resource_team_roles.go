@@ -16,6 +16,7 @@ import (
 	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
 	"github.com/astronomer/terraform-provider-astro/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -37,6 +38,8 @@ type teamRolesResource struct {
 	iamClient      *iam.ClientWithResponses
 	platformClient *platform.ClientWithResponses
 	organizationId string
+	readOnly       bool
+	cache          *clients.ResponseCache
 }
 
 func (r *teamRolesResource) Metadata(
@@ -78,6 +81,9 @@ func (r *teamRolesResource) Configure(
 	r.iamClient = apiClients.IamClient
 	r.platformClient = apiClients.PlatformClient
 	r.organizationId = apiClients.OrganizationId
+	r.readOnly = apiClients.ReadOnly
+	r.cache = apiClients.Cache
+	resp.Diagnostics.Append(apiClients.CallerIdentity.CheckScope("astro_team_roles", iam.ApiTokenTypeORGANIZATION)...)
 }
 
 func (r *teamRolesResource) MutateRoles(
@@ -100,6 +106,7 @@ func (r *teamRolesResource) MutateRoles(
 	diags = common.ValidateWorkspaceDeploymentRoles(ctx, common.ValidateWorkspaceDeploymentRolesInput{
 		PlatformClient:  r.platformClient,
 		OrganizationId:  r.organizationId,
+		Cache:           r.cache,
 		WorkspaceRoles:  workspaceRoles,
 		DeploymentRoles: deploymentRoles,
 	})
@@ -147,6 +154,13 @@ func (r *teamRolesResource) Create(
 	resp *resource.CreateResponse,
 ) {
 	var data models.TeamRoles
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_team_roles", tracing.OperationCreate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.TeamId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_team_roles", tracing.OperationCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -170,6 +184,8 @@ func (r *teamRolesResource) Read(
 	resp *resource.ReadResponse,
 ) {
 	var data models.TeamRoles
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_team_roles", tracing.OperationRead, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.TeamId, resp.Diagnostics) }()
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -229,6 +245,13 @@ func (r *teamRolesResource) Update(
 	resp *resource.UpdateResponse,
 ) {
 	var data models.TeamRoles
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_team_roles", tracing.OperationUpdate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.TeamId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_team_roles", tracing.OperationUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -252,6 +275,13 @@ func (r *teamRolesResource) Delete(
 	resp *resource.DeleteResponse,
 ) {
 	var data models.TeamRoles
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_team_roles", tracing.OperationDelete, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.TeamId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_team_roles", tracing.OperationDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -297,10 +327,17 @@ func (r *teamRolesResource) Delete(
 	tflog.Trace(ctx, fmt.Sprintf("deleted a team_roles resource for team '%v'", teamId))
 }
 
+// ImportState imports the roles of a team by ID, or by name with a `team:<name>` import ID
 func (r *teamRolesResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
+	importId, diags := ImportTeamId(ctx, r.iamClient, r.organizationId, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.ID = importId
 	resource.ImportStatePassthroughID(ctx, path.Root("team_id"), req, resp)
 }
@@ -208,6 +208,13 @@ func TestAcc_ResourceTeam(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{},
 			},
+			// Import existing team by name
+			{
+				ResourceName:      resourceVar,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("team:%v", teamName),
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
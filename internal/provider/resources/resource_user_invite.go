@@ -4,15 +4,19 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/astronomer/terraform-provider-astro/internal/clients"
 	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
 	"github.com/astronomer/terraform-provider-astro/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/samber/lo"
@@ -20,6 +24,7 @@ import (
 
 var _ resource.Resource = &UserInviteResource{}
 var _ resource.ResourceWithConfigure = &UserInviteResource{}
+var _ resource.ResourceWithImportState = &UserInviteResource{}
 
 func NewUserInviteResource() resource.Resource {
 	return &UserInviteResource{}
@@ -29,6 +34,7 @@ func NewUserInviteResource() resource.Resource {
 type UserInviteResource struct {
 	IamClient      *iam.ClientWithResponses
 	OrganizationId string
+	ReadOnly       bool
 }
 
 func (r *UserInviteResource) Metadata(
@@ -69,6 +75,8 @@ func (r *UserInviteResource) Configure(
 
 	r.IamClient = apiClients.IamClient
 	r.OrganizationId = apiClients.OrganizationId
+	r.ReadOnly = apiClients.ReadOnly
+	resp.Diagnostics.Append(apiClients.CallerIdentity.CheckScope("astro_user_invite", iam.ApiTokenTypeORGANIZATION)...)
 }
 
 func (r *UserInviteResource) Create(
@@ -77,6 +85,13 @@ func (r *UserInviteResource) Create(
 	resp *resource.CreateResponse,
 ) {
 	var data models.UserInvite
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_user_invite", tracing.OperationCreate, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.InviteId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.ReadOnly, "astro_user_invite", tracing.OperationCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -130,6 +145,8 @@ func (r *UserInviteResource) Read(
 	resp *resource.ReadResponse,
 ) {
 	var data models.UserInvite
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_user_invite", tracing.OperationRead, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.InviteId, resp.Diagnostics) }()
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -164,11 +181,17 @@ func (r *UserInviteResource) Read(
 		}
 	}
 
+	// Imported user invites only know the ID of the invited user
+	userId := invitee.Id.ValueString()
+	if len(userId) == 0 {
+		userId = data.UserId.ValueString()
+	}
+
 	// Get the user invite
 	user, err := r.IamClient.GetUserWithResponse(
 		ctx,
 		r.OrganizationId,
-		invitee.Id.ValueString(),
+		userId,
 	)
 	if err != nil {
 		tflog.Error(ctx, "failed to get User Invite", map[string]interface{}{"error": err})
@@ -190,6 +213,25 @@ func (r *UserInviteResource) Read(
 		return
 	}
 
+	// Fill in the invite details that are missing from the state of an imported user invite
+	email := data.Email.ValueString()
+	if data.Email.IsNull() {
+		email = user.JSON200.Username
+	}
+	role := data.Role.ValueString()
+	if data.Role.IsNull() && user.JSON200.OrganizationRole != nil {
+		role = string(*user.JSON200.OrganizationRole)
+	}
+	if invitee.Id.IsNull() {
+		invitee = models.SubjectProfile{
+			Id:          types.StringValue(user.JSON200.Id),
+			SubjectType: types.StringValue(string(iam.USER)),
+			Username:    types.StringValue(user.JSON200.Username),
+			FullName:    types.StringValue(user.JSON200.FullName),
+			AvatarUrl:   types.StringValue(user.JSON200.AvatarUrl),
+		}
+	}
+
 	// Generate userInvite from the get user API response
 	userInvite := iam.Invite{
 		ExpiresAt: data.ExpiresAt.ValueString(),
@@ -214,7 +256,7 @@ func (r *UserInviteResource) Read(
 		UserId:         lo.ToPtr(user.JSON200.Id),
 	}
 
-	diags := data.ReadFromResponse(ctx, &userInvite, data.Email.ValueString(), data.Role.ValueString())
+	diags := data.ReadFromResponse(ctx, &userInvite, email, role)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
@@ -233,6 +275,13 @@ func (r *UserInviteResource) Update(
 	resp *resource.UpdateResponse,
 ) {
 	var data models.UserInvite
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_user_invite", tracing.OperationUpdate, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.InviteId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.ReadOnly, "astro_user_invite", tracing.OperationUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Delete existing user invite
 
@@ -319,6 +368,13 @@ func (r *UserInviteResource) Delete(
 	resp *resource.DeleteResponse,
 ) {
 	var data models.UserInvite
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_user_invite", tracing.OperationDelete, r.OrganizationId)
+	defer func() { tracing.EndResourceOperation(span, data.InviteId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.ReadOnly, "astro_user_invite", tracing.OperationDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -351,3 +407,53 @@ func (r *UserInviteResource) Delete(
 
 	tflog.Trace(ctx, fmt.Sprintf("deleted a User Invite resource: %v", data.InviteId.ValueString()))
 }
+
+// ImportState imports a pending User Invite by the ID of the invited user and the ID of the invite,
+// `<user ID>/<invite ID>`. The user can also be given by email, `user:<email>/<invite ID>`. The API does not list
+// invites, so the invite ID cannot be looked up from the user and is required to revoke the invite on delete.
+func (r *UserInviteResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	userImportId, inviteId, ok := strings.Cut(req.ID, "/")
+	if !ok || len(userImportId) == 0 || len(inviteId) == 0 {
+		resp.Diagnostics.AddError(
+			"Cannot import User Invite",
+			fmt.Sprintf("The import ID %v is not of the form <user ID>/<invite ID>. The API does not list the invites of a user, so the ID of the invite is required to revoke it.", req.ID),
+		)
+		return
+	}
+
+	userId, diags := ImportUserId(ctx, r.IamClient, r.OrganizationId, userImportId)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Invited users stay in the PENDING status until they accept their invite
+	user, err := r.IamClient.GetUserWithResponse(ctx, r.OrganizationId, userId)
+	if err != nil {
+		tflog.Error(ctx, "failed to get user to import User Invite", map[string]interface{}{"error": err})
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get user to import User Invite, got error: %s", err),
+		)
+		return
+	}
+	statusCode, diagnostic := clients.NormalizeAPIError(ctx, user.HTTPResponse, user.Body)
+	if statusCode == http.StatusNotFound || (diagnostic == nil && user.JSON200.Status != iam.PENDING) {
+		resp.Diagnostics.AddError(
+			"Cannot import User Invite",
+			fmt.Sprintf("User %v has no pending invite in the organization.", userId),
+		)
+		return
+	}
+	if diagnostic != nil {
+		resp.Diagnostics.Append(diagnostic)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("invite_id"), inviteId)...)
+}
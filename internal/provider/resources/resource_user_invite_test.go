@@ -85,6 +85,28 @@ func TestAcc_ResourceUserInvite(t *testing.T) {
 					testAccCheckUserInviteExistence(t, email, true),
 				),
 			},
+			// Import existing user invite by the invited user's ID alone fails, the invite ID is required to revoke it
+			{
+				ResourceName: tfVarName,
+				ImportState:  true,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					return state.RootModule().Resources[tfVarName].Primary.Attributes["user_id"], nil
+				},
+				ExpectError: regexp.MustCompile("is not of the form <user ID>/<invite ID>"),
+			},
+			// Import existing user invite by the invited user's ID and the invite ID
+			{
+				ResourceName:      tfVarName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					attributes := state.RootModule().Resources[tfVarName].Primary.Attributes
+					return fmt.Sprintf("%v/%v", attributes["user_id"], attributes["invite_id"]), nil
+				},
+				ImportStateVerifyIdentifierAttribute: "invite_id",
+				// the expiration and the inviter are not returned by the API outside of the invite creation
+				ImportStateVerifyIgnore: []string{"expires_at", "inviter", "invitee"},
+			},
 		},
 	})
 }
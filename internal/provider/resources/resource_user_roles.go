@@ -13,6 +13,7 @@ import (
 	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
 	"github.com/astronomer/terraform-provider-astro/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -36,6 +37,8 @@ type UserRolesResource struct {
 	iamClient      *iam.ClientWithResponses
 	platformClient *platform.ClientWithResponses
 	organizationId string
+	readOnly       bool
+	cache          *clients.ResponseCache
 }
 
 func (r *UserRolesResource) Metadata(
@@ -77,6 +80,9 @@ func (r *UserRolesResource) Configure(
 	r.iamClient = apiClients.IamClient
 	r.platformClient = apiClients.PlatformClient
 	r.organizationId = apiClients.OrganizationId
+	r.readOnly = apiClients.ReadOnly
+	r.cache = apiClients.Cache
+	resp.Diagnostics.Append(apiClients.CallerIdentity.CheckScope("astro_user_roles", iam.ApiTokenTypeORGANIZATION)...)
 }
 
 func (r *UserRolesResource) MutateRoles(
@@ -99,6 +105,7 @@ func (r *UserRolesResource) MutateRoles(
 	diags = common.ValidateWorkspaceDeploymentRoles(ctx, common.ValidateWorkspaceDeploymentRolesInput{
 		PlatformClient:  r.platformClient,
 		OrganizationId:  r.organizationId,
+		Cache:           r.cache,
 		WorkspaceRoles:  workspaceRoles,
 		DeploymentRoles: deploymentRoles,
 	})
@@ -146,6 +153,13 @@ func (r *UserRolesResource) Create(
 	resp *resource.CreateResponse,
 ) {
 	var data models.UserRoles
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_user_roles", tracing.OperationCreate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.UserId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_user_roles", tracing.OperationCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -169,6 +183,8 @@ func (r *UserRolesResource) Read(
 	resp *resource.ReadResponse,
 ) {
 	var data models.UserRoles
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_user_roles", tracing.OperationRead, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.UserId, resp.Diagnostics) }()
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -236,6 +252,13 @@ func (r *UserRolesResource) Update(
 	resp *resource.UpdateResponse,
 ) {
 	var data models.UserRoles
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_user_roles", tracing.OperationUpdate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.UserId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_user_roles", tracing.OperationUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -259,6 +282,13 @@ func (r *UserRolesResource) Delete(
 	resp *resource.DeleteResponse,
 ) {
 	var data models.UserRoles
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_user_roles", tracing.OperationDelete, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.UserId, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_user_roles", tracing.OperationDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -304,11 +334,18 @@ func (r *UserRolesResource) Delete(
 	tflog.Trace(ctx, fmt.Sprintf("deleted a user_roles resource for user '%v'", userId))
 }
 
+// ImportState imports the roles of a user by ID, or by email with a `user:<email>` import ID
 func (r *UserRolesResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
+	importId, diags := ImportUserId(ctx, r.iamClient, r.organizationId, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.ID = importId
 	resource.ImportStatePassthroughID(ctx, path.Root("user_id"), req, resp)
 }
 
@@ -6,9 +6,11 @@ import (
 	"net/http"
 
 	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/models"
 	"github.com/astronomer/terraform-provider-astro/internal/provider/schemas"
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
 	"github.com/astronomer/terraform-provider-astro/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -20,6 +22,14 @@ import (
 var _ resource.Resource = &workspaceResource{}
 var _ resource.ResourceWithImportState = &workspaceResource{}
 var _ resource.ResourceWithConfigure = &workspaceResource{}
+var _ resource.ResourceWithModifyPlan = &workspaceResource{}
+var _ resource.ResourceWithUpgradeState = &workspaceResource{}
+
+// workspaceStateUpgrades upgrade the state of each prior schema version to the next one, the schema version is the
+// number of upgrades
+var workspaceStateUpgrades = []StateUpgrade{
+	upgradeWorkspaceStateV0,
+}
 
 func NewWorkspaceResource() resource.Resource {
 	return &workspaceResource{}
@@ -28,7 +38,10 @@ func NewWorkspaceResource() resource.Resource {
 // workspaceResource defines the resource implementation.
 type workspaceResource struct {
 	platformClient *platform.ClientWithResponses
+	iamClient      *iam.ClientWithResponses
 	organizationId string
+	readOnly       bool
+	cache          *clients.ResponseCache
 }
 
 func (r *workspaceResource) Metadata(
@@ -47,10 +60,23 @@ func (r *workspaceResource) Schema(
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Workspace resource",
+		Version:             int64(len(workspaceStateUpgrades)),
 		Attributes:          schemas.WorkspaceResourceSchemaAttributes(),
 	}
 }
 
+// UpgradeState upgrades the state of the prior schema versions of the workspace to the current version
+func (r *workspaceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return StateUpgraders(workspaceStateUpgrades...)
+}
+
+// upgradeWorkspaceStateV0 upgrades the state of the workspaces created before the schema was versioned, which did not
+// have deletion_protection and force_destroy
+func upgradeWorkspaceStateV0(state map[string]any) {
+	SetStateDefault(state, "deletion_protection", false)
+	SetStateDefault(state, "force_destroy", false)
+}
+
 func (r *workspaceResource) Configure(
 	ctx context.Context,
 	req resource.ConfigureRequest,
@@ -68,7 +94,11 @@ func (r *workspaceResource) Configure(
 	}
 
 	r.platformClient = apiClients.PlatformClient
+	r.iamClient = apiClients.IamClient
 	r.organizationId = apiClients.OrganizationId
+	r.readOnly = apiClients.ReadOnly
+	r.cache = apiClients.Cache
+	resp.Diagnostics.Append(apiClients.CallerIdentity.CheckScope("astro_workspace", iam.ApiTokenTypeORGANIZATION)...)
 }
 
 func (r *workspaceResource) Create(
@@ -76,7 +106,14 @@ func (r *workspaceResource) Create(
 	req resource.CreateRequest,
 	resp *resource.CreateResponse,
 ) {
-	var data models.Workspace
+	var data models.WorkspaceResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_workspace", tracing.OperationCreate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_workspace", tracing.OperationCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -96,6 +133,7 @@ func (r *workspaceResource) Create(
 		r.organizationId,
 		createWorkspaceRequest,
 	)
+	r.cache.Invalidate(ctx, clients.WorkspacesCacheKeyPrefix)
 	if err != nil {
 		tflog.Error(ctx, "failed to create workspace", map[string]interface{}{"error": err})
 		resp.Diagnostics.AddError(
@@ -127,7 +165,9 @@ func (r *workspaceResource) Read(
 	req resource.ReadRequest,
 	resp *resource.ReadResponse,
 ) {
-	var data models.Workspace
+	var data models.WorkspaceResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_workspace", tracing.OperationRead, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -179,7 +219,14 @@ func (r *workspaceResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
-	var data models.Workspace
+	var data models.WorkspaceResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_workspace", tracing.OperationUpdate, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_workspace", tracing.OperationUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -200,6 +247,7 @@ func (r *workspaceResource) Update(
 		data.Id.ValueString(),
 		updateWorkspaceRequest,
 	)
+	r.cache.Invalidate(ctx, clients.WorkspacesCacheKeyPrefix)
 	if err != nil {
 		tflog.Error(ctx, "failed to update workspace", map[string]interface{}{"error": err})
 		resp.Diagnostics.AddError(
@@ -231,7 +279,14 @@ func (r *workspaceResource) Delete(
 	req resource.DeleteRequest,
 	resp *resource.DeleteResponse,
 ) {
-	var data models.Workspace
+	var data models.WorkspaceResource
+	ctx, span := tracing.StartResourceOperation(ctx, "astro_workspace", tracing.OperationDelete, r.organizationId)
+	defer func() { tracing.EndResourceOperation(span, data.Id, resp.Diagnostics) }()
+
+	resp.Diagnostics.Append(CheckReadOnly(r.readOnly, "astro_workspace", tracing.OperationDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -239,6 +294,32 @@ func (r *workspaceResource) Delete(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.Append(DeletionProtectionError("astro_workspace", data.Id.ValueString(), "it is destroyed or replaced"))
+		return
+	}
+
+	// The deployments and workspace API tokens of the workspace are deleted with it when force_destroy is true,
+	// otherwise they block its deletion
+	dependents, err := r.listWorkspaceDependents(ctx, data.Id.ValueString())
+	if err != nil {
+		tflog.Error(ctx, "failed to list workspace dependents", map[string]interface{}{"error": err})
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to list the deployments and API tokens of the workspace, got error: %s", err),
+		)
+		return
+	}
+	if !dependents.IsEmpty() {
+		if !data.ForceDestroy.ValueBool() {
+			resp.Diagnostics.Append(WorkspaceDependentsError(data.Id.ValueString(), dependents))
+			return
+		}
+		resp.Diagnostics.Append(r.deleteWorkspaceDependents(ctx, dependents)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// delete request
 	workspace, err := r.platformClient.DeleteWorkspaceWithResponse(
@@ -246,6 +327,7 @@ func (r *workspaceResource) Delete(
 		r.organizationId,
 		data.Id.ValueString(),
 	)
+	r.cache.Invalidate(ctx, clients.WorkspacesCacheKeyPrefix, clients.DeploymentsCacheKeyPrefix)
 	if err != nil {
 		tflog.Error(ctx, "failed to delete workspace", map[string]interface{}{"error": err})
 		resp.Diagnostics.AddError(
@@ -264,10 +346,29 @@ func (r *workspaceResource) Delete(
 	tflog.Trace(ctx, fmt.Sprintf("deleted a workspace resource: %v", data.Id.ValueString()))
 }
 
+// ImportState imports a workspace by ID, or by name with a `workspace:<name>` import ID
 func (r *workspaceResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
+	importId, diags := ImportWorkspaceId(ctx, r.platformClient, r.organizationId, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	req.ID = importId
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// deletion_protection and force_destroy are not stored by the API, they have their default value until they are set
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+}
+
+// ModifyPlan fails the plans deleting or replacing the resource while its deletion_protection is true
+func (r *workspaceResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	ModifyPlanDeletionProtection(ctx, "astro_workspace", req, resp)
 }
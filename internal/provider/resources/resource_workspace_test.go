@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/astronomer/terraform-provider-astro/internal/clients"
+	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 
 	"github.com/astronomer/terraform-provider-astro/internal/clients/platform"
 	astronomerprovider "github.com/astronomer/terraform-provider-astro/internal/provider"
@@ -60,6 +62,50 @@ func TestAcc_ResourceWorkspace(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// Import existing workspace by name
+			{
+				ResourceName:      "astro_workspace.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("workspace:%v", workspace2Name),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "astro_workspace.test",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("workspace:%v", workspace1Name),
+				ExpectError:   regexp.MustCompile("Cannot import workspace"),
+			},
+		},
+	})
+}
+
+func TestAcc_ResourceWorkspaceDeletionProtection(t *testing.T) {
+	workspaceName := utils.GenerateTestResourceName(10)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: astronomerprovider.TestAccProtoV6ProviderFactories,
+		PreCheck:                 func() { astronomerprovider.TestAccPreCheck(t) },
+		CheckDestroy:             testAccCheckWorkspaceExistence(t, workspaceName, false),
+		Steps: []resource.TestStep{
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + workspaceWithDeletionProtection(workspaceName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astro_workspace.test", "deletion_protection", "true"),
+					testAccCheckWorkspaceExistence(t, workspaceName, true),
+				),
+			},
+			// Removing the resource block does not delete a protected workspace
+			{
+				Config:      astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED),
+				ExpectError: regexp.MustCompile("Deletion protection is enabled"),
+			},
+			// Disable the protection so that the workspace can be destroyed
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + workspaceWithDeletionProtection(workspaceName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astro_workspace.test", "deletion_protection", "false"),
+					testAccCheckWorkspaceExistence(t, workspaceName, true),
+				),
+			},
 		},
 	})
 }
@@ -106,6 +152,42 @@ func TestAcc_WorkspaceRemovedOutsideOfTerraform(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceWorkspaceForceDestroy(t *testing.T) {
+	workspaceName := utils.GenerateTestResourceName(10)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: astronomerprovider.TestAccProtoV6ProviderFactories,
+		PreCheck:                 func() { astronomerprovider.TestAccPreCheck(t) },
+		CheckDestroy:             testAccCheckWorkspaceExistence(t, workspaceName, false),
+		Steps: []resource.TestStep{
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + workspaceWithForceDestroy(workspaceName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astro_workspace.test", "force_destroy", "false"),
+					testAccCheckWorkspaceExistence(t, workspaceName, true),
+				),
+			},
+			// A workspace with a workspace API token is not deleted, the token is listed in the error
+			{
+				PreConfig:   func() { createWorkspaceApiTokenOutsideOfTerraform(t, workspaceName) },
+				Config:      astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("(?s)Workspace is not empty.*workspace API token %v", workspaceName)),
+			},
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED) + workspaceWithForceDestroy(workspaceName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astro_workspace.test", "force_destroy", "true"),
+					testAccCheckWorkspaceExistence(t, workspaceName, true),
+				),
+			},
+			// The workspace is deleted with its API token once force_destroy is true
+			{
+				Config: astronomerprovider.ProviderConfig(t, astronomerprovider.HOSTED),
+				Check:  testAccCheckWorkspaceExistence(t, workspaceName, false),
+			},
+		},
+	})
+}
+
 func workspaceWithVariableName() string {
 	return fmt.Sprintf(`
 variable "name" {
@@ -119,6 +201,26 @@ resource "astro_workspace" "test" {
 }`, utils.TestResourceDescription)
 }
 
+func workspaceWithDeletionProtection(name string, deletionProtection bool) string {
+	return fmt.Sprintf(`
+resource "astro_workspace" "test" {
+	name = "%s"
+	description = "%s"
+	cicd_enforced_default = true
+	deletion_protection = %t
+}`, name, utils.TestResourceDescription, deletionProtection)
+}
+
+func workspaceWithForceDestroy(name string, forceDestroy bool) string {
+	return fmt.Sprintf(`
+resource "astro_workspace" "test" {
+	name = "%s"
+	description = "%s"
+	cicd_enforced_default = true
+	force_destroy = %t
+}`, name, utils.TestResourceDescription, forceDestroy)
+}
+
 func workspace(tfVarName, name, description string, cicdEnforcedDefault bool) string {
 	return fmt.Sprintf(`
 resource "astro_workspace" "%s" {
@@ -147,6 +249,31 @@ func deleteWorkspaceOutsideOfTerraform(t *testing.T, name string) {
 	assert.NoError(t, err)
 }
 
+// createWorkspaceApiTokenOutsideOfTerraform creates a workspace API token with the name of the workspace
+func createWorkspaceApiTokenOutsideOfTerraform(t *testing.T, name string) {
+	t.Helper()
+
+	platformClient, err := utils.GetTestHostedPlatformClient()
+	assert.NoError(t, err)
+	iamClient, err := utils.GetTestHostedIamClient()
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	resp, err := platformClient.ListWorkspacesWithResponse(ctx, os.Getenv("HOSTED_ORGANIZATION_ID"), &platform.ListWorkspacesParams{
+		Names: &[]string{name},
+	})
+	assert.NoError(t, err)
+	assert.True(t, len(resp.JSON200.Workspaces) >= 1, "workspace should exist but list workspaces did not find it")
+	apiToken, err := iamClient.CreateApiTokenWithResponse(ctx, os.Getenv("HOSTED_ORGANIZATION_ID"), iam.CreateApiTokenRequest{
+		EntityId: &resp.JSON200.Workspaces[0].Id,
+		Name:     name,
+		Role:     string(iam.WORKSPACEMEMBER),
+		Type:     iam.WORKSPACE,
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, apiToken.JSON200, "API token should be created")
+}
+
 func testAccCheckWorkspaceExistence(t *testing.T, name string, shouldExist bool) func(state *terraform.State) error {
 	t.Helper()
 	return func(state *terraform.State) error {
@@ -0,0 +1,33 @@
+package schemas
+
+import (
+	datasourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+func CallerIdentityDataSourceSchemaAttributes() map[string]datasourceSchema.Attribute {
+	return map[string]datasourceSchema.Attribute{
+		"id": datasourceSchema.StringAttribute{
+			MarkdownDescription: "API Token identifier",
+			Computed:            true, // This is computed because we retrieve it from the provider token
+		},
+		"organization_id": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Organization identifier of the provider",
+			Computed:            true,
+		},
+		"name": datasourceSchema.StringAttribute{
+			MarkdownDescription: "API Token name",
+			Computed:            true,
+		},
+		"type": datasourceSchema.StringAttribute{
+			MarkdownDescription: "API Token type, one of ORGANIZATION, WORKSPACE or DEPLOYMENT",
+			Computed:            true,
+		},
+		"roles": datasourceSchema.SetNestedAttribute{
+			NestedObject: datasourceSchema.NestedAttributeObject{
+				Attributes: DataSourceApiTokenRoleSchemaAttributes(),
+			},
+			Computed:            true,
+			MarkdownDescription: "The roles assigned to the API Token",
+		},
+	}
+}
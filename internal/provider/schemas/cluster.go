@@ -161,6 +161,13 @@ func ClusterResourceSchemaAttributes(ctx context.Context) map[string]resourceSch
 			MarkdownDescription: "Whether the cluster is limited",
 			Computed:            true,
 		},
+		"deletion_protection": DeletionProtectionResourceSchemaAttribute("cluster"),
+		"force_destroy":       ForceDestroyResourceSchemaAttribute("cluster", "deployments"),
+		"force_destroy_deployment_name_pattern": resourceSchema.StringAttribute{
+			MarkdownDescription: "Regular expression matching the names of the deployments deleted with the cluster when force_destroy is true. Destroying the cluster fails if it has deployments that do not match, with the list of them. All deployments are deleted when it is not set.",
+			Optional:            true,
+			Validators:          []validator.String{validators.IsRegex()},
+		},
 		"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 			Create: true,
 			Update: true,
@@ -0,0 +1,22 @@
+package schemas
+
+import (
+	"fmt"
+
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+)
+
+// DeletionProtectionResourceSchemaAttribute is the deletion_protection attribute of the resources of objectName that
+// cannot be deleted or replaced while it is true
+func DeletionProtectionResourceSchemaAttribute(objectName string) resourceSchema.BoolAttribute {
+	return resourceSchema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf(
+			"Whether the %[1]v is protected from deletion. While `true`, destroying or replacing the %[1]v fails, including when its resource block is removed from the configuration; set it to `false` and apply before deleting the %[1]v. Default is `false`.",
+			objectName,
+		),
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
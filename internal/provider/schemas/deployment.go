@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	datasourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -64,12 +65,9 @@ func DeploymentResourceSchemaAttributes() map[string]resourceSchema.Attribute {
 			Attributes:          ResourceSubjectProfileSchemaAttributes(),
 		},
 		"workspace_id": resourceSchema.StringAttribute{
-			MarkdownDescription: "Deployment workspace identifier - if changing this value, the deployment will be recreated in the new workspace",
+			MarkdownDescription: "Deployment workspace identifier - if changing this value, the deployment is moved to the new workspace in place. The users and teams with a role on the deployment must have a role in the new workspace, and for dedicated and hybrid deployments the new workspace must be authorized on the deployment cluster. If the move cannot be validated when planning, for example because the new workspace is created in the same apply, the deployment will be recreated in the new workspace.",
 			Required:            true,
 			Validators:          []validator.String{validators.IsCuid()},
-			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplaceIfConfigured(),
-			},
 		},
 		"original_astro_runtime_version": resourceSchema.StringAttribute{
 			MarkdownDescription: "Deployment's original Astro Runtime version. The Terraform provider will use this provided Astro runtime version to create the Deployment. The Astro runtime version can be updated with your Astro project Dockerfile, but if this value is changed, the Deployment will be recreated with this new Astro runtime version.",
@@ -132,8 +130,28 @@ func DeploymentResourceSchemaAttributes() map[string]resourceSchema.Attribute {
 			NestedObject: resourceSchema.NestedAttributeObject{
 				Attributes: DeploymentEnvironmentVariableResourceAttributes(),
 			},
-			MarkdownDescription: "Deployment environment variables",
-			Required:            true,
+			MarkdownDescription: "Deployment environment variables. Changing a variable shows the whole set as replaced in plans, use environment_variables_map and secret_environment_variables to see the changes of each variable instead.",
+			Optional:            true,
+			Validators: []validator.Set{
+				setvalidator.ConflictsWith(path.MatchRoot("environment_variables_map"), path.MatchRoot("secret_environment_variables")),
+			},
+		},
+		"environment_variables_map": resourceSchema.MapAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Deployment environment variables that are not secret, by key",
+			Optional:            true,
+		},
+		"secret_environment_variables": resourceSchema.MapAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Deployment secret environment variables, by key. The values are write-only, they are not stored in the plan or the state and require Terraform 1.11 or later. Changes are detected with secret_environment_variables_hashes, and a secret changed outside of Terraform is detected from its update time and is applied again.",
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+		},
+		"secret_environment_variables_hashes": resourceSchema.MapAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Salted SHA-256 hashes of the secret_environment_variables, by key. A hash is unknown in the plan when its secret is changed in the configuration or outside of Terraform, and the secret is then applied again.",
+			Computed:            true,
 		},
 		"webserver_ingress_hostname": resourceSchema.StringAttribute{
 			MarkdownDescription: "Deployment webserver ingress hostname",
@@ -343,6 +361,7 @@ func DeploymentResourceSchemaAttributes() map[string]resourceSchema.Attribute {
 				stringplanmodifier.RequiresReplaceIfConfigured(),
 			},
 		},
+		"deletion_protection": DeletionProtectionResourceSchemaAttribute("deployment"),
 	}
 }
 
@@ -0,0 +1,128 @@
+package schemas
+
+import (
+	"github.com/astronomer/terraform-provider-astro/internal/provider/validators"
+	datasourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+)
+
+// DeploymentTemplateDataSourceSchemaAttributes are the attributes of a deployment template. They have the types of the
+// attributes of the astro_deployment resource with the same names, so that they can be assigned to them directly.
+func DeploymentTemplateDataSourceSchemaAttributes() map[string]datasourceSchema.Attribute {
+	return map[string]datasourceSchema.Attribute{
+		"id": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment identifier",
+			Required:            true,
+			Validators:          []validator.String{validators.IsCuid()},
+		},
+		"description": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment description",
+			Computed:            true,
+		},
+		"workspace_id": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment workspace identifier",
+			Computed:            true,
+		},
+		"type": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment type",
+			Computed:            true,
+		},
+		"cloud_provider": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment cloud provider - only for 'STANDARD' deployments",
+			Computed:            true,
+		},
+		"region": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment region - only for 'STANDARD' deployments",
+			Computed:            true,
+		},
+		"cluster_id": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment cluster identifier - only for 'DEDICATED' and 'HYBRID' deployments",
+			Computed:            true,
+		},
+		"astro_runtime_version": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment Astro Runtime version, to be used as the original_astro_runtime_version of the new deployment",
+			Computed:            true,
+		},
+		"contact_emails": datasourceSchema.SetAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Source deployment contact emails",
+			Computed:            true,
+		},
+		"executor": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment executor",
+			Computed:            true,
+		},
+		"is_cicd_enforced": datasourceSchema.BoolAttribute{
+			MarkdownDescription: "Whether the source deployment requires CI/CD deploys",
+			Computed:            true,
+		},
+		"is_dag_deploy_enabled": datasourceSchema.BoolAttribute{
+			MarkdownDescription: "Whether DAG deploy is enabled on the source deployment",
+			Computed:            true,
+		},
+		"environment_variables_map": datasourceSchema.MapAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Values of the environment variables of the source deployment that are not secret, by key",
+			Computed:            true,
+		},
+		"secret_environment_variable_keys": datasourceSchema.SetAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Keys of the secret environment variables of the source deployment. The API does not return secret values, they must be set in the secret_environment_variables of the new deployment.",
+			Computed:            true,
+		},
+		"worker_queues": datasourceSchema.SetNestedAttribute{
+			NestedObject: datasourceSchema.NestedAttributeObject{
+				Attributes: lo.OmitByKeys(WorkerQueueDataSourceSchemaAttributes(), []string{"id"}),
+			},
+			MarkdownDescription: "Source deployment worker queues, their pod_cpu and pod_memory are null since they are computed from the Astro machine or node pool",
+			Computed:            true,
+		},
+		"scheduler_au": datasourceSchema.Int64Attribute{
+			MarkdownDescription: "Source deployment scheduler Astro Units - only for 'HYBRID' deployments",
+			Computed:            true,
+		},
+		"scheduler_replicas": datasourceSchema.Int64Attribute{
+			MarkdownDescription: "Source deployment scheduler replicas - only for 'HYBRID' deployments",
+			Computed:            true,
+		},
+		"task_pod_node_pool_id": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment task pod node pool identifier - only for 'HYBRID' deployments",
+			Computed:            true,
+		},
+		"scheduler_size": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment scheduler size - only for 'STANDARD' and 'DEDICATED' deployments",
+			Computed:            true,
+		},
+		"is_development_mode": datasourceSchema.BoolAttribute{
+			MarkdownDescription: "Whether the source deployment is in development mode - only for 'STANDARD' and 'DEDICATED' deployments",
+			Computed:            true,
+		},
+		"is_high_availability": datasourceSchema.BoolAttribute{
+			MarkdownDescription: "Whether the source deployment has high availability - only for 'STANDARD' and 'DEDICATED' deployments",
+			Computed:            true,
+		},
+		"resource_quota_cpu": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment resource quota CPU - only for 'STANDARD' and 'DEDICATED' deployments",
+			Computed:            true,
+		},
+		"resource_quota_memory": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment resource quota memory - only for 'STANDARD' and 'DEDICATED' deployments",
+			Computed:            true,
+		},
+		"default_task_pod_cpu": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment default task pod CPU - only for 'STANDARD' and 'DEDICATED' deployments",
+			Computed:            true,
+		},
+		"default_task_pod_memory": datasourceSchema.StringAttribute{
+			MarkdownDescription: "Source deployment default task pod memory - only for 'STANDARD' and 'DEDICATED' deployments",
+			Computed:            true,
+		},
+		"scaling_spec": datasourceSchema.SingleNestedAttribute{
+			MarkdownDescription: "Source deployment hibernation schedules, null when it has none. The hibernation override of the source deployment is not copied.",
+			Computed:            true,
+			Attributes:          ScalingSpecTemplateDataSourceSchemaAttributes(),
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package schemas
+
+import (
+	"fmt"
+
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+)
+
+// ForceDestroyResourceSchemaAttribute is the force_destroy attribute of the resources of objectName that cannot be
+// deleted while they have dependents, dependents describes them
+func ForceDestroyResourceSchemaAttribute(objectName, dependents string) resourceSchema.BoolAttribute {
+	return resourceSchema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf(
+			"Whether destroying the %[1]v also deletes its %[2]v. While `false`, destroying the %[1]v fails if it has any, with the list of what blocks its deletion. The value of the state is used, so it must be set to `true` and applied before the %[1]v is destroyed. Default is `false`.",
+			objectName, dependents,
+		),
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"regexp"
 
 	"github.com/astronomer/terraform-provider-astro/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func ProviderSchemaAttributes() map[string]schema.Attribute {
@@ -16,9 +20,56 @@ func ProviderSchemaAttributes() map[string]schema.Attribute {
 			Sensitive:           true,
 			MarkdownDescription: "Astro API Token. Can be set with an `ASTRO_API_TOKEN` env var.",
 		},
+		"token_file": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Path of a file containing the Astro API Token. The file is read again when the token expires.",
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRoot("token")),
+			},
+		},
+		"exec": schema.SingleNestedAttribute{
+			Optional:            true,
+			MarkdownDescription: "Credential helper command printing the Astro API Token as JSON, such as `{\"token\":\"...\",\"expiresAt\":\"2024-01-01T00:00:00Z\"}`. The command runs again when the token expires, `expiresAt` defaults to the expiry of the token.",
+			Attributes: map[string]schema.Attribute{
+				"command": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "Command to run",
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
+				},
+				"args": schema.ListAttribute{
+					ElementType:         types.StringType,
+					Optional:            true,
+					MarkdownDescription: "Arguments of the command",
+				},
+				"env": schema.MapAttribute{
+					ElementType:         types.StringType,
+					Optional:            true,
+					MarkdownDescription: "Environment variables added to the environment of the command",
+				},
+			},
+			Validators: []validator.Object{
+				objectvalidator.ConflictsWith(path.MatchRoot("token"), path.MatchRoot("token_file")),
+			},
+		},
+		"astro_cli_config": schema.SingleNestedAttribute{
+			Optional:            true,
+			MarkdownDescription: "Read the Astro API Token, organization and host of a context of the Astro CLI config file, such as the context of `astro login`. The token is used if no other token is configured, and the file is read again when the token expires.",
+			Attributes: map[string]schema.Attribute{
+				"path": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Path of the Astro CLI config file. Default is `~/.astro/config.yaml`",
+				},
+				"context": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Astro CLI context to use, such as `astronomer_io`. Default is the current context of the Astro CLI.",
+				},
+			},
+		},
 		"organization_id": schema.StringAttribute{
-			Required:            true,
-			MarkdownDescription: "Organization ID this provider will operate on.",
+			Optional:            true,
+			MarkdownDescription: "Organization ID this provider will operate on. Required unless it is read from `astro_cli_config`.",
 			Validators: []validator.String{
 				validators.IsCuid(),
 			},
@@ -27,9 +78,35 @@ func ProviderSchemaAttributes() map[string]schema.Attribute {
 			Optional:            true,
 			MarkdownDescription: "API host to use for the provider. Default is `https://api.astronomer.io`",
 			Validators: []validator.String{
-				stringvalidator.RegexMatches(regexp.MustCompile(`^https://(pr\d+)?api.astronomer(-(dev|stage))?.io$`),
+				// loopback hosts are allowed so the provider can be tested against a local fake of the API
+				stringvalidator.RegexMatches(regexp.MustCompile(`^(https://(pr\d+)?api.astronomer(-(dev|stage))?.io|http://(localhost|127\.0\.0\.1):\d+)$`),
 					"must be a valid Astronomer API host such as `https://api.astronomer.io`"),
 			},
 		},
+		"tracing_endpoint": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "OTLP HTTP endpoint to export OpenTelemetry traces of the provider operations to, such as `http://localhost:4318`. Tracing is also enabled by the `OTEL_EXPORTER_OTLP_ENDPOINT` and `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT` env vars.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^https?://`), "must be an http or https URL"),
+			},
+		},
+		"read_only": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Reject every change of the provider: resources cannot be created, updated or deleted and only GET requests are sent to the Astro API, while resources can still be read and imported and data sources still work. Can be set with an `ASTRO_READ_ONLY` env var. Default is `false`.",
+		},
+		"max_concurrent_requests": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Maximum number of concurrent requests the provider sends to the Astro API, across all resources and data sources. Requests over the limit wait in a queue. Unlimited by default.",
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"requests_per_second": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Maximum number of requests per second the provider sends to the Astro API, across all resources and data sources. Requests over the limit wait in a queue. Unlimited by default.",
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
 	}
 }
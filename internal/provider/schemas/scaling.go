@@ -1,6 +1,7 @@
 package schemas
 
 import (
+	"github.com/astronomer/terraform-provider-astro/internal/provider/validators"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -33,6 +34,22 @@ func HibernationSpecAttributeTypes() map[string]attr.Type {
 	}
 }
 
+// ScalingSpecResourceAttributeTypes are the attribute types of the scaling_spec of the resource, whose hibernation_spec
+// also has a timezone
+func ScalingSpecResourceAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"hibernation_spec": types.ObjectType{
+			AttrTypes: HibernationSpecResourceAttributeTypes(),
+		},
+	}
+}
+
+func HibernationSpecResourceAttributeTypes() map[string]attr.Type {
+	attributeTypes := HibernationSpecAttributeTypes()
+	attributeTypes["timezone"] = types.StringType
+	return attributeTypes
+}
+
 func ScalingSpecDataSourceSchemaAttributes() map[string]datasourceSchema.Attribute {
 	return map[string]datasourceSchema.Attribute{
 		"hibernation_spec": datasourceSchema.SingleNestedAttribute{
@@ -57,6 +74,22 @@ func HibernationSpecDataSourceSchemaAttributes() map[string]datasourceSchema.Att
 	}
 }
 
+// ScalingSpecTemplateDataSourceSchemaAttributes are the attributes of the scaling_spec of a deployment template, which
+// have the types of the scaling_spec of the resource
+func ScalingSpecTemplateDataSourceSchemaAttributes() map[string]datasourceSchema.Attribute {
+	hibernationSpecAttributes := HibernationSpecDataSourceSchemaAttributes()
+	hibernationSpecAttributes["timezone"] = datasourceSchema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Time zone of the cron expressions, always null since the API evaluates them in UTC",
+	}
+	return map[string]datasourceSchema.Attribute{
+		"hibernation_spec": datasourceSchema.SingleNestedAttribute{
+			Attributes: hibernationSpecAttributes,
+			Computed:   true,
+		},
+	}
+}
+
 func HibernationOverrideDataSourceSchemaAttributes() map[string]datasourceSchema.Attribute {
 	return map[string]datasourceSchema.Attribute{
 		"is_active": datasourceSchema.BoolAttribute{
@@ -123,6 +156,13 @@ func HibernationSpecResourceSchemaAttributes() map[string]resourceSchema.Attribu
 			},
 			Optional: true,
 		},
+		"timezone": resourceSchema.StringAttribute{
+			MarkdownDescription: "IANA time zone of the cron expressions of the schedules, such as 'America/New_York'. The API evaluates cron expressions in UTC, so the expressions are translated to UTC with the current offset of the time zone when they are applied, and a plan shows the change when the offset changes. Schedules with a time zone must be at a single minute and hour with '*' day of month and month. Defaults to UTC.",
+			Optional:            true,
+			Validators: []validator.String{
+				validators.IsTimezone(),
+			},
+		},
 	}
 }
 
@@ -152,13 +192,21 @@ func HibernationScheduleResourceSchemaAttributes() map[string]resourceSchema.Att
 			},
 		},
 		"hibernate_at_cron": resourceSchema.StringAttribute{
-			Required: true,
+			MarkdownDescription: "5-part cron expression of when the deployment hibernates, in UTC unless hibernation_spec.timezone is set",
+			Required:            true,
+			Validators: []validator.String{
+				validators.IsCron(),
+			},
 		},
 		"is_enabled": resourceSchema.BoolAttribute{
 			Required: true,
 		},
 		"wake_at_cron": resourceSchema.StringAttribute{
-			Required: true,
+			MarkdownDescription: "5-part cron expression of when the deployment wakes, in UTC unless hibernation_spec.timezone is set",
+			Required:            true,
+			Validators: []validator.String{
+				validators.IsCron(),
+			},
 		},
 	}
 }
@@ -174,10 +222,12 @@ func ScalingStatusAttributeTypes() map[string]attr.Type {
 
 func HibernationStatusAttributeTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"is_hibernating":  types.BoolType,
-		"next_event_at":   types.StringType,
-		"next_event_type": types.StringType,
-		"reason":          types.StringType,
+		"is_hibernating":    types.BoolType,
+		"next_event_at":     types.StringType,
+		"next_event_type":   types.StringType,
+		"next_hibernate_at": types.StringType,
+		"next_wake_at":      types.StringType,
+		"reason":            types.StringType,
 	}
 }
 
@@ -221,6 +271,14 @@ func HibernationStatusDataSourceSchemaAttributes() map[string]datasourceSchema.A
 			Computed:            true,
 			MarkdownDescription: "Type of the next event",
 		},
+		"next_hibernate_at": datasourceSchema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Time the enabled schedules next hibernate the deployment, in UTC",
+		},
+		"next_wake_at": datasourceSchema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Time the enabled schedules next wake the deployment, in UTC",
+		},
 		"reason": datasourceSchema.StringAttribute{
 			Computed:            true,
 			MarkdownDescription: "Reason for the current state",
@@ -248,6 +306,14 @@ func HibernationStatusResourceSchemaAttributes() map[string]resourceSchema.Attri
 		"next_event_type": resourceSchema.StringAttribute{
 			Computed: true,
 		},
+		"next_hibernate_at": resourceSchema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Time the enabled schedules next hibernate the deployment, in UTC",
+		},
+		"next_wake_at": resourceSchema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Time the enabled schedules next wake the deployment, in UTC",
+		},
 		"reason": resourceSchema.StringAttribute{
 			Computed: true,
 		},
@@ -99,5 +99,7 @@ func WorkspaceResourceSchemaAttributes() map[string]resourceSchema.Attribute {
 			Computed:            true,
 			Attributes:          ResourceSubjectProfileSchemaAttributes(),
 		},
+		"deletion_protection": DeletionProtectionResourceSchemaAttribute("workspace"),
+		"force_destroy":       ForceDestroyResourceSchemaAttribute("workspace", "deployments and workspace API tokens"),
 	}
 }
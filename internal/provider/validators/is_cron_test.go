@@ -0,0 +1,30 @@
+package validators_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_Validators_IsCron(t *testing.T) {
+	testCases := map[types.String]bool{
+		types.StringNull():                  true,
+		types.StringUnknown():               true,
+		types.StringValue("0 19 * * 1-5"):   true,
+		types.StringValue("*/30 * * * sun"): true,
+		types.StringValue("0 19 * * 1-8"):   false,
+		types.StringValue("0 19 * *"):       false,
+		types.StringValue("@daily"):         false,
+		types.StringValue(""):               false,
+	}
+	for value, expectedIsCron := range testCases {
+		response := validator.StringResponse{}
+		validators.IsCron().ValidateString(context.Background(), validator.StringRequest{ConfigValue: value}, &response)
+		assert.Equal(t, !expectedIsCron, response.Diagnostics.HasError(), fmt.Sprintf("test case: %s failed", value))
+	}
+}
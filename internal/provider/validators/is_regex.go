@@ -0,0 +1,48 @@
+package validators
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = isRegexValidator{}
+
+type isRegexValidator struct {
+}
+
+func (v isRegexValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v isRegexValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v isRegexValidator) ValidateString(
+	ctx context.Context,
+	request validator.StringRequest,
+	response *validator.StringResponse,
+) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	if _, err := regexp.Compile(value); err == nil {
+		return
+	}
+
+	response.Diagnostics.Append(validatordiag.InvalidAttributeValueMatchDiagnostic(
+		request.Path,
+		v.Description(ctx),
+		value,
+	))
+}
+
+func IsRegex() validator.String {
+	return isRegexValidator{}
+}
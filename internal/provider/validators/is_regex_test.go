@@ -0,0 +1,30 @@
+package validators_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_Validators_IsRegex(t *testing.T) {
+	testCases := map[types.String]bool{
+		types.StringNull():               true,
+		types.StringUnknown():            true,
+		types.StringValue("^test-"):      true,
+		types.StringValue("ci-[0-9]+$"):  true,
+		types.StringValue(""):            true,
+		types.StringValue("test-(\\d+"):  false,
+		types.StringValue("[a-z"):        false,
+		types.StringValue("*.ephemeral"): false,
+	}
+	for value, expectedIsRegex := range testCases {
+		response := validator.StringResponse{}
+		validators.IsRegex().ValidateString(context.Background(), validator.StringRequest{ConfigValue: value}, &response)
+		assert.Equal(t, !expectedIsRegex, response.Diagnostics.HasError(), fmt.Sprintf("test case: %s failed", value))
+	}
+}
@@ -0,0 +1,49 @@
+package validators
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = isTimezoneValidator{}
+
+type isTimezoneValidator struct {
+}
+
+func (v isTimezoneValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v isTimezoneValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be an IANA time zone name such as 'America/New_York'"
+}
+
+func (v isTimezoneValidator) ValidateString(
+	ctx context.Context,
+	request validator.StringRequest,
+	response *validator.StringResponse,
+) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	// time.LoadLocation returns UTC for an empty name
+	if _, err := time.LoadLocation(value); err == nil && value != "" {
+		return
+	}
+
+	response.Diagnostics.Append(validatordiag.InvalidAttributeValueMatchDiagnostic(
+		request.Path,
+		v.Description(ctx),
+		value,
+	))
+}
+
+func IsTimezone() validator.String {
+	return isTimezoneValidator{}
+}
@@ -0,0 +1,30 @@
+package validators_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/astronomer/terraform-provider-astro/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_Validators_IsTimezone(t *testing.T) {
+	testCases := map[types.String]bool{
+		types.StringNull():                     true,
+		types.StringUnknown():                  true,
+		types.StringValue("UTC"):               true,
+		types.StringValue("America/New_York"):  true,
+		types.StringValue("Europe/Paris"):      true,
+		types.StringValue("America/New_Yrok"):  false,
+		types.StringValue("Eastern Time (US)"): false,
+		types.StringValue(""):                  false,
+	}
+	for value, expectedIsTimezone := range testCases {
+		response := validator.StringResponse{}
+		validators.IsTimezone().ValidateString(context.Background(), validator.StringRequest{ConfigValue: value}, &response)
+		assert.Equal(t, !expectedIsTimezone, response.Diagnostics.HasError(), fmt.Sprintf("test case: %s failed", value))
+	}
+}
@@ -0,0 +1,143 @@
+// Package tracing traces the operations of the provider with OpenTelemetry.
+//
+// Tracing is disabled unless an OTLP endpoint is configured with the tracing_endpoint provider attribute or the
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variables. Until then spans are
+// created with the no-op tracer provider of OpenTelemetry.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer of the provider
+const TracerName = "github.com/astronomer/terraform-provider-astro"
+
+// Attributes of the spans of the provider
+const (
+	OrganizationIdAttribute = attribute.Key("astro.organization_id")
+	ResourceTypeAttribute   = attribute.Key("astro.resource_type")
+	EntityIdAttribute       = attribute.Key("astro.entity_id")
+	RequestIdAttribute      = attribute.Key("astro.request_id")
+	StateAttribute          = attribute.Key("astro.state")
+)
+
+// Operations of a resource
+const (
+	OperationCreate = "Create"
+	OperationRead   = "Read"
+	OperationUpdate = "Update"
+	OperationDelete = "Delete"
+)
+
+// flushTimeout bounds the time an operation waits for its spans to be exported, so that a slow or unreachable
+// collector does not stall it
+const flushTimeout = 5 * time.Second
+
+var (
+	configureOnce  sync.Once
+	configureErr   error
+	tracerProvider *sdktrace.TracerProvider
+)
+
+// Configure installs a tracer provider exporting spans to an OTLP HTTP endpoint if endpoint is set or the OTLP
+// endpoint environment variables are. The tracer provider is installed once per provider process, later calls return
+// the error of the first one.
+func Configure(ctx context.Context, endpoint, version string) error {
+	if endpoint == "" && os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return nil
+	}
+	configureOnce.Do(func() {
+		var options []otlptracehttp.Option
+		if endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(endpoint))
+		}
+		exporter, err := otlptracehttp.New(ctx, options...)
+		if err != nil {
+			configureErr = fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+			return
+		}
+		// Spans are exported in batches in the background, Terraform stops the provider process without notice so
+		// they are flushed at the end of each resource operation
+		tracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(resource.NewSchemaless(
+				semconv.ServiceName("terraform-provider-astro"),
+				semconv.ServiceVersion(version),
+			)),
+		)
+		otel.SetTracerProvider(tracerProvider)
+	})
+	return configureErr
+}
+
+// Flush exports the ended spans that are not exported yet, waiting at most flushTimeout. Export errors are reported
+// to the OpenTelemetry error handler, tracing never fails an operation.
+func Flush(ctx context.Context) {
+	if tracerProvider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+	defer cancel()
+	if err := tracerProvider.ForceFlush(ctx); err != nil {
+		otel.Handle(err)
+	}
+}
+
+// Tracer returns the tracer of the provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// StartResourceOperation starts the span of a resource CRUD operation, it must be ended with EndResourceOperation
+func StartResourceOperation(ctx context.Context, resourceType, operation, organizationId string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, fmt.Sprintf("%v.%v", resourceType, operation), trace.WithAttributes(
+		ResourceTypeAttribute.String(resourceType),
+		OrganizationIdAttribute.String(organizationId),
+	))
+}
+
+// EndResourceOperation tags the span of a resource operation with the ID of its entity, records the errors of diags,
+// ends it and flushes the spans of the operation
+func EndResourceOperation(span trace.Span, entityId types.String, diags diag.Diagnostics) {
+	if !entityId.IsNull() && !entityId.IsUnknown() && entityId.ValueString() != "" {
+		span.SetAttributes(EntityIdAttribute.String(entityId.ValueString()))
+	}
+	if diags.HasError() {
+		errors := diags.Errors()
+		span.SetStatus(codes.Error, fmt.Sprintf("%v: %v", errors[0].Summary(), errors[0].Detail()))
+	}
+	span.End()
+	Flush(context.Background())
+}
+
+// TraceRefresh creates the refresh function of a retry.StateChangeConf that traces every polling iteration in a span.
+// refresh is called with the context of the span so the API calls it makes are traced in child spans.
+func TraceRefresh(ctx context.Context, name, entityId string, refresh func(ctx context.Context) (any, string, error)) retry.StateRefreshFunc {
+	return func() (any, string, error) {
+		ctx, span := Tracer().Start(ctx, name, trace.WithAttributes(EntityIdAttribute.String(entityId)))
+		defer span.End()
+		result, state, err := refresh(ctx)
+		span.SetAttributes(StateAttribute.String(state))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return result, state, err
+	}
+}
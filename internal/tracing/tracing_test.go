@@ -0,0 +1,120 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/astronomer/terraform-provider-astro/internal/tracing"
+)
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]string {
+	attributes := map[attribute.Key]string{}
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value.Emit()
+	}
+	return attributes
+}
+
+func TestUnit_Tracing(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("exports spans to an OTLP endpoint", func(t *testing.T) {
+		var exported atomic.Int32
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/traces" {
+				exported.Add(1)
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer collector.Close()
+
+		assert.NoError(t, tracing.Configure(ctx, collector.URL, "test"))
+		operationCtx, span := tracing.StartResourceOperation(ctx, "astro_workspace", tracing.OperationRead, "organization-id")
+		for i := 0; i < 3; i++ {
+			_, _, err := tracing.TraceRefresh(operationCtx, "workspace.Refresh", "workspace-id", func(ctx context.Context) (any, string, error) {
+				return nil, "CREATED", nil
+			})()
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int32(0), exported.Load(), "spans are exported in a batch at the end of the operation")
+		tracing.EndResourceOperation(span, types.StringValue("workspace-id"), nil)
+		assert.Equal(t, int32(1), exported.Load())
+
+		// the tracer provider is configured once, later calls return the result of the first one
+		assert.NoError(t, tracing.Configure(ctx, "http://localhost:1", "test"))
+		_, span = tracing.StartResourceOperation(ctx, "astro_workspace", tracing.OperationRead, "organization-id")
+		tracing.EndResourceOperation(span, types.StringValue("workspace-id"), nil)
+		assert.Equal(t, int32(2), exported.Load())
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	t.Run("traces resource operations", func(t *testing.T) {
+		_, span := tracing.StartResourceOperation(ctx, "astro_workspace", tracing.OperationCreate, "organization-id")
+		var diags diag.Diagnostics
+		diags.AddError("Client Error", "Unable to create workspace")
+		tracing.EndResourceOperation(span, types.StringValue("workspace-id"), diags)
+
+		spans := recorder.Ended()
+		ended := spans[len(spans)-1]
+		assert.Equal(t, "astro_workspace.Create", ended.Name())
+		assert.Equal(t, map[attribute.Key]string{
+			tracing.ResourceTypeAttribute:   "astro_workspace",
+			tracing.OrganizationIdAttribute: "organization-id",
+			tracing.EntityIdAttribute:       "workspace-id",
+		}, spanAttributes(ended))
+		assert.Equal(t, codes.Error, ended.Status().Code)
+		assert.Equal(t, "Client Error: Unable to create workspace", ended.Status().Description)
+	})
+
+	t.Run("does not tag unknown entity IDs", func(t *testing.T) {
+		_, span := tracing.StartResourceOperation(ctx, "astro_workspace", tracing.OperationCreate, "organization-id")
+		tracing.EndResourceOperation(span, types.StringUnknown(), nil)
+
+		spans := recorder.Ended()
+		ended := spans[len(spans)-1]
+		assert.NotContains(t, spanAttributes(ended), tracing.EntityIdAttribute)
+		assert.Equal(t, codes.Unset, ended.Status().Code)
+	})
+
+	t.Run("traces polling iterations", func(t *testing.T) {
+		ctx, parent := tracing.StartResourceOperation(ctx, "astro_cluster", tracing.OperationCreate, "organization-id")
+		polls := 0
+		refresh := tracing.TraceRefresh(ctx, "astro_cluster.Poll", "cluster-id", func(ctx context.Context) (any, string, error) {
+			polls++
+			if polls == 2 {
+				return nil, "CREATE_FAILED", errors.New("cluster mutation failed")
+			}
+			return nil, "CREATING", nil
+		})
+		_, state, err := refresh()
+		assert.NoError(t, err)
+		assert.Equal(t, "CREATING", state)
+		_, _, err = refresh()
+		assert.Error(t, err)
+		parent.End()
+
+		spans := recorder.Ended()
+		first, second := spans[len(spans)-3], spans[len(spans)-2]
+		assert.Equal(t, "astro_cluster.Poll", first.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), first.Parent().SpanID())
+		assert.Equal(t, "CREATING", spanAttributes(first)[tracing.StateAttribute])
+		assert.Equal(t, "cluster-id", spanAttributes(first)[tracing.EntityIdAttribute])
+		assert.Equal(t, "CREATE_FAILED", spanAttributes(second)[tracing.StateAttribute])
+		assert.Equal(t, codes.Error, second.Status().Code)
+	})
+}
@@ -0,0 +1,216 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed 5-part cron expression, the format of the hibernation schedules of the API
+type CronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// as in cron, days match either dayOfMonth or dayOfWeek when both are restricted
+	dayOfMonthStar, dayOfWeekStar bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	// 7 is also Sunday
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+// cronSearchYears bounds the search of the next time of expressions that never match, such as "0 0 30 2 *"
+const cronSearchYears = 5
+
+// ParseCron parses a 5-part cron expression (minute, hour, day of month, month and day of week). Fields accept '*',
+// values, ranges, steps and lists, and month and day of week also accept three letter names.
+func ParseCron(expression string) (*CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields (minute, hour, day of month, month and day of week), got %v", expression, len(fields))
+	}
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		var err error
+		if bits[i], err = parseCronField(field, cronFields[i]); err != nil {
+			return nil, fmt.Errorf("cron expression %q has an invalid %v: %w", expression, cronFields[i].name, err)
+		}
+	}
+	schedule := &CronSchedule{
+		minute:         bits[0],
+		hour:           bits[1],
+		dayOfMonth:     bits[2],
+		month:          bits[3],
+		dayOfWeek:      bits[4],
+		dayOfMonthStar: strings.HasPrefix(fields[2], "*"),
+		dayOfWeekStar:  strings.HasPrefix(fields[4], "*"),
+	}
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+	return schedule, nil
+}
+
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+		var start, end int
+		switch lower, upper, isRange := strings.Cut(rangePart, "-"); {
+		case rangePart == "*":
+			start, end = spec.min, spec.max
+		case isRange:
+			var err error
+			if start, err = parseCronValue(lower, spec); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(upper, spec); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			var err error
+			if start, err = parseCronValue(rangePart, spec); err != nil {
+				return 0, err
+			}
+			end = start
+			// as in cron, "5/15" means every 15 from 5
+			if hasStep {
+				end = spec.max
+			}
+		}
+		for value := start; value <= end; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, spec cronField) (int, error) {
+	if number, ok := spec.names[strings.ToLower(value)]; ok {
+		return number, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if number < spec.min || number > spec.max {
+		return 0, fmt.Errorf("%v is out of range %v-%v", number, spec.min, spec.max)
+	}
+	return number, nil
+}
+
+// Next returns the first time matching the schedule strictly after t, in UTC. It returns the zero time if the schedule
+// does not match within the next years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	yearLimit := t.Year() + cronSearchYears
+	for t.Year() <= yearLimit {
+		switch {
+		case s.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<t.Day()) != 0
+	dayOfWeek := s.dayOfWeek&(1<<int(t.Weekday())) != 0
+	if s.dayOfMonthStar || s.dayOfWeekStar {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// CronToUTC translates a cron expression evaluated in location to the expression evaluated in UTC, with the UTC offset
+// of location at the time at. Only expressions at a single minute and hour of every day of month and month can be
+// translated, the days of week are shifted when the UTC time is on another day.
+func CronToUTC(expression string, location *time.Location, at time.Time) (string, error) {
+	if _, err := ParseCron(expression); err != nil {
+		return "", err
+	}
+	fields := strings.Fields(expression)
+	minute, minuteErr := strconv.Atoi(fields[0])
+	hour, hourErr := strconv.Atoi(fields[1])
+	if minuteErr != nil || hourErr != nil || fields[2] != "*" || fields[3] != "*" {
+		return "", fmt.Errorf("cron expression %q cannot be translated to UTC, only expressions with a single minute and hour and '*' day of month and month can be used with a timezone", expression)
+	}
+
+	_, offset := at.In(location).Zone()
+	minutes := hour*60 + minute - offset/60
+	dayShift := 0
+	for minutes < 0 {
+		minutes += 24 * 60
+		dayShift--
+	}
+	for minutes >= 24*60 {
+		minutes -= 24 * 60
+		dayShift++
+	}
+
+	dayOfWeek := fields[4]
+	if dayShift != 0 && !strings.HasPrefix(dayOfWeek, "*") {
+		bits, _ := parseCronField(dayOfWeek, cronFields[4])
+		var days []int
+		for day := 0; day < 7; day++ {
+			if bits&(1<<day) != 0 || (day == 0 && bits&(1<<7) != 0) {
+				days = append(days, ((day+dayShift)%7+7)%7)
+			}
+		}
+		dayOfWeek = formatCronDays(days)
+	}
+	return fmt.Sprintf("%v %v * * %v", minutes%60, minutes/60, dayOfWeek), nil
+}
+
+// formatCronDays formats days of week as a list of values and ranges, such as "1-5" or "0,6"
+func formatCronDays(days []int) string {
+	sort.Ints(days)
+	var parts []string
+	for i := 0; i < len(days); {
+		j := i
+		for j+1 < len(days) && days[j+1] == days[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, fmt.Sprintf("%v-%v", days[i], days[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(days[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,108 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
+)
+
+func TestUnit_ParseCron(t *testing.T) {
+	t.Run("valid expressions", func(t *testing.T) {
+		for _, expression := range []string{
+			"* * * * *",
+			"0 19 * * 1-5",
+			"*/15 0-6,22-23 * * *",
+			"5/10 * 1,15 jan-MAR sun",
+			"0 0 * * 7",
+		} {
+			_, err := utils.ParseCron(expression)
+			assert.NoError(t, err, expression)
+		}
+	})
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		for expression, message := range map[string]string{
+			"0 19 * *":       "must have 5 fields",
+			"0 19 * * * *":   "must have 5 fields",
+			"60 19 * * *":    "invalid minute: 60 is out of range 0-59",
+			"0 24 * * *":     "invalid hour: 24 is out of range 0-23",
+			"0 0 0 * *":      "invalid day of month: 0 is out of range 1-31",
+			"0 0 * 13 *":     "invalid month: 13 is out of range 1-12",
+			"0 0 * * 8":      "invalid day of week: 8 is out of range 0-7",
+			"0 0 * * 5-1":    `invalid range "5-1"`,
+			"*/0 0 * * *":    `invalid step "0"`,
+			"0 0 * * fryday": `invalid value "fryday"`,
+			"@daily":         "must have 5 fields",
+		} {
+			_, err := utils.ParseCron(expression)
+			if assert.Error(t, err, expression) {
+				assert.Contains(t, err.Error(), message)
+			}
+		}
+	})
+}
+
+func TestUnit_CronScheduleNext(t *testing.T) {
+	// a Wednesday
+	now := time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)
+	for expression, next := range map[string]time.Time{
+		"* * * * *":      time.Date(2024, 5, 15, 10, 31, 0, 0, time.UTC),
+		"30 10 * * *":    time.Date(2024, 5, 16, 10, 30, 0, 0, time.UTC),
+		"0 19 * * 1-5":   time.Date(2024, 5, 15, 19, 0, 0, 0, time.UTC),
+		"0 7 * * sat":    time.Date(2024, 5, 18, 7, 0, 0, 0, time.UTC),
+		"0 0 * * 7":      time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC),
+		"0 0 1 * *":      time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		"*/20 * * * *":   time.Date(2024, 5, 15, 10, 40, 0, 0, time.UTC),
+		"0 0 29 2 *":     time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		"0 12 1 * mon":   time.Date(2024, 5, 20, 12, 0, 0, 0, time.UTC),
+		"0 0 31 dec sun": time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		schedule, err := utils.ParseCron(expression)
+		assert.NoError(t, err)
+		assert.Equal(t, next, schedule.Next(now), expression)
+	}
+
+	schedule, err := utils.ParseCron("0 0 30 2 *")
+	assert.NoError(t, err)
+	assert.True(t, schedule.Next(now).IsZero())
+}
+
+func TestUnit_CronToUTC(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+	winter := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	summer := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("translates expressions", func(t *testing.T) {
+		for _, test := range []struct {
+			expression string
+			location   *time.Location
+			at         time.Time
+			expected   string
+		}{
+			{"0 19 * * 1-5", newYork, winter, "0 0 * * 2-6"},
+			{"0 19 * * 1-5", newYork, summer, "0 23 * * 1-5"},
+			{"30 7 * * *", newYork, winter, "30 12 * * *"},
+			{"0 8 * * 1-5", tokyo, winter, "0 23 * * 0-4"},
+			{"0 8 * * sun,sat", tokyo, winter, "0 23 * * 5-6"},
+			{"0 2 * * 0", tokyo, winter, "0 17 * * 6"},
+			{"15 10 * * *", time.UTC, winter, "15 10 * * *"},
+		} {
+			translated, err := utils.CronToUTC(test.expression, test.location, test.at)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, translated, test.expression)
+		}
+	})
+
+	t.Run("rejects expressions that cannot be translated", func(t *testing.T) {
+		for _, expression := range []string{"*/15 19 * * *", "0 19-20 * * *", "0 19 1 * *", "0 19 * 1 *", "invalid"} {
+			_, err := utils.CronToUTC(expression, newYork, winter)
+			assert.Error(t, err, expression)
+		}
+	})
+}
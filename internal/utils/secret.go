@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+// secretHashPrefix identifies the algorithm of the secret hashes, so that it can be changed without misreading the
+// hashes of older states
+const secretHashPrefix = "sha256"
+
+// HashSecret returns a salted SHA-256 hash of a secret value in the form sha256:<salt>:<hash>, the salt is random so
+// that equal secrets do not have equal hashes
+func HashSecret(value string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return secretHash(salt, value), nil
+}
+
+// SecretMatchesHash returns whether value is the secret of a hash returned by HashSecret
+func SecretMatchesHash(value, hash string) bool {
+	prefix, rest, ok := strings.Cut(hash, ":")
+	if !ok || prefix != secretHashPrefix {
+		return false
+	}
+	encodedSalt, _, ok := strings.Cut(rest, ":")
+	if !ok {
+		return false
+	}
+	salt, err := hex.DecodeString(encodedSalt)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secretHash(salt, value)), []byte(hash)) == 1
+}
+
+func secretHash(salt []byte, value string) string {
+	sum := sha256.Sum256(append(append([]byte{}, salt...), value...))
+	return strings.Join([]string{secretHashPrefix, hex.EncodeToString(salt), hex.EncodeToString(sum[:])}, ":")
+}
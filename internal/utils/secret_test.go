@@ -0,0 +1,37 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/astronomer/terraform-provider-astro/internal/utils"
+)
+
+func TestUnit_SecretHash(t *testing.T) {
+	t.Run("hashes match their secret only", func(t *testing.T) {
+		hash, err := utils.HashSecret("secret")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "sha256:"))
+		assert.NotContains(t, hash, "secret")
+		assert.True(t, utils.SecretMatchesHash("secret", hash))
+		assert.False(t, utils.SecretMatchesHash("other", hash))
+		assert.False(t, utils.SecretMatchesHash("", hash))
+	})
+
+	t.Run("hashes are salted", func(t *testing.T) {
+		hash1, err := utils.HashSecret("secret")
+		assert.NoError(t, err)
+		hash2, err := utils.HashSecret("secret")
+		assert.NoError(t, err)
+		assert.NotEqual(t, hash1, hash2)
+		assert.True(t, utils.SecretMatchesHash("secret", hash2))
+	})
+
+	t.Run("invalid hashes match nothing", func(t *testing.T) {
+		for _, hash := range []string{"", "secret", "md5:00:00", "sha256:zz:00", "sha256:00"} {
+			assert.False(t, utils.SecretMatchesHash("secret", hash), hash)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/astronomer/terraform-provider-astro/internal/clients"
 	"github.com/astronomer/terraform-provider-astro/internal/clients/iam"
 
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -16,6 +17,7 @@ import (
 
 var hostedPlatformClient, hybridPlatformClient *platform.ClientWithResponses
 var hostedIamClient, hybridIamClient *iam.ClientWithResponses
+var testHttpClient clients.HttpRequestDoer
 
 const TestResourceDescription = "Created by Terraform Acceptance Test - should self-cleanup but can delete manually if needed after 2 hours."
 
@@ -23,6 +25,26 @@ func GenerateTestResourceName(numRandomChars int) string {
 	return fmt.Sprintf("TFAcceptanceTest_%v", strings.ToUpper(acctest.RandStringFromCharSet(numRandomChars, acctest.CharSetAlpha)))
 }
 
+// SetTestHttpClient sets the HTTP client of the API clients returned by GetTest*Client, for example a cassette recorder.
+// It must be called before any of them.
+func SetTestHttpClient(httpClient clients.HttpRequestDoer) {
+	testHttpClient = httpClient
+}
+
+func testIamClientOptions() []iam.ClientOption {
+	if testHttpClient == nil {
+		return nil
+	}
+	return []iam.ClientOption{iam.WithHTTPClient(testHttpClient)}
+}
+
+func testPlatformClientOptions() []platform.ClientOption {
+	if testHttpClient == nil {
+		return nil
+	}
+	return []platform.ClientOption{platform.WithHTTPClient(testHttpClient)}
+}
+
 func GetTestIamClient(isHosted bool) (*iam.ClientWithResponses, error) {
 	if isHosted {
 		return GetTestHostedIamClient()
@@ -36,7 +58,7 @@ func GetTestHybridIamClient() (*iam.ClientWithResponses, error) {
 		return hybridIamClient, nil
 	}
 	var err error
-	hybridIamClient, err = iam.NewIamClient(os.Getenv("ASTRO_API_HOST"), os.Getenv("HYBRID_ORGANIZATION_API_TOKEN"), "acceptancetests")
+	hybridIamClient, err = iam.NewIamClient(os.Getenv("ASTRO_API_HOST"), clients.StaticToken(os.Getenv("HYBRID_ORGANIZATION_API_TOKEN")), "acceptancetests", testIamClientOptions()...)
 	return hybridIamClient, err
 }
 
@@ -45,7 +67,7 @@ func GetTestHostedIamClient() (*iam.ClientWithResponses, error) {
 		return hostedIamClient, nil
 	}
 	var err error
-	hostedIamClient, err = iam.NewIamClient(os.Getenv("ASTRO_API_HOST"), os.Getenv("HOSTED_ORGANIZATION_API_TOKEN"), "acceptancetests")
+	hostedIamClient, err = iam.NewIamClient(os.Getenv("ASTRO_API_HOST"), clients.StaticToken(os.Getenv("HOSTED_ORGANIZATION_API_TOKEN")), "acceptancetests", testIamClientOptions()...)
 	return hostedIamClient, err
 }
 
@@ -62,7 +84,7 @@ func GetTestHybridPlatformClient() (*platform.ClientWithResponses, error) {
 		return hybridPlatformClient, nil
 	}
 	var err error
-	hybridPlatformClient, err = platform.NewPlatformClient(os.Getenv("ASTRO_API_HOST"), os.Getenv("HYBRID_ORGANIZATION_API_TOKEN"), "acceptancetests")
+	hybridPlatformClient, err = platform.NewPlatformClient(os.Getenv("ASTRO_API_HOST"), clients.StaticToken(os.Getenv("HYBRID_ORGANIZATION_API_TOKEN")), "acceptancetests", testPlatformClientOptions()...)
 	return hybridPlatformClient, err
 }
 
@@ -71,7 +93,7 @@ func GetTestHostedPlatformClient() (*platform.ClientWithResponses, error) {
 		return hostedPlatformClient, nil
 	}
 	var err error
-	hostedPlatformClient, err = platform.NewPlatformClient(os.Getenv("ASTRO_API_HOST"), os.Getenv("HOSTED_ORGANIZATION_API_TOKEN"), "acceptancetests")
+	hostedPlatformClient, err = platform.NewPlatformClient(os.Getenv("ASTRO_API_HOST"), clients.StaticToken(os.Getenv("HOSTED_ORGANIZATION_API_TOKEN")), "acceptancetests", testPlatformClientOptions()...)
 	return hostedPlatformClient, err
 }
 
@@ -7,6 +7,8 @@ import (
 	"context"
 	"flag"
 	"log"
+	// embeds the time zone database so that the timezone of hibernation schedules does not depend on the host
+	_ "time/tzdata"
 
 	"github.com/astronomer/terraform-provider-astro/internal/provider"
 